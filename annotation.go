@@ -0,0 +1,72 @@
+package gover
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Annotation is a finding tied to a source location, in the form code review tools expect.
+type Annotation struct {
+	Level   string // LevelWarning or LevelError
+	File    string
+	Line    int
+	Column  int
+	Rule    string // identifies the kind of finding, e.g. "deprecated"
+	Title   string
+	Message string
+}
+
+// Annotated is implemented by findings that can be reported against source locations.
+// Output formats such as "github" accept values, or slices of values, implementing it.
+type Annotated interface {
+	Annotation() Annotation
+}
+
+// Annotation reports f against the line using the symbol.
+func (f Finding) Annotation() Annotation {
+	return Annotation{
+		Level:   f.Level,
+		File:    f.File,
+		Line:    f.Line,
+		Column:  f.Column,
+		Rule:    f.Rule,
+		Title:   fmt.Sprintf("%s %s", f.Symbol, f.Rule),
+		Message: f.Message,
+	}
+}
+
+// Annotation reports f against the line pinning the version.
+func (f AuditFinding) Annotation() Annotation {
+	a := Annotation{
+		Level:   LevelWarning,
+		File:    f.Pin.File,
+		Line:    f.Pin.Line,
+		Rule:    "outdated-go-version",
+		Title:   "Outdated Go version",
+		Message: f.Message,
+	}
+	if f.EOL {
+		a.Level, a.Rule, a.Title = LevelError, "eol-go-version", "End-of-life Go version"
+	}
+	return a
+}
+
+// annotations returns the annotations of v, which must be Annotated or a slice of Annotated values.
+func annotations(v any) ([]Annotation, error) {
+	if a, ok := v.(Annotated); ok {
+		return []Annotation{a.Annotation()}, nil
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, fmt.Errorf("output of type %T has no source locations to annotate", v)
+	}
+	out := make([]Annotation, 0, rv.Len())
+	for i := range rv.Len() {
+		a, ok := rv.Index(i).Interface().(Annotated)
+		if !ok {
+			return nil, fmt.Errorf("output of type %T has no source locations to annotate", v)
+		}
+		out = append(out, a.Annotation())
+	}
+	return out, nil
+}
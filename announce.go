@@ -0,0 +1,171 @@
+package gover
+
+import (
+	"cmp"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+)
+
+// blogIndexPath is the go.dev page listing every Go blog post.
+const blogIndexPath = "/blog/all"
+
+// blogFeedPath is the Atom feed of the Go blog, which dates posts to the second.
+const blogFeedPath = "/blog/feed.atom"
+
+// postDelay spaces out the announcement posts fetched for highlights and contributions.
+const postDelay = time.Second
+
+// Announcement is the Go blog post announcing a release.
+type Announcement struct {
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Highlights string `json:"highlights,omitempty"` // the post's opening paragraph, see WithHighlights
+	Published  string `json:"published,omitempty"`  // RFC3339 time the post was published, from the blog feed
+
+	contributors int // as counted by the post, see WithContributions
+}
+
+// Announcements scrapes the Go blog index for release announcement posts, keyed by version.
+// With WithHighlights or WithContributions, each post is also fetched.
+func (c *Client) Announcements(ctx context.Context) (map[string]Announcement, error) {
+	log := c.opts.logger
+	announcements := make(map[string]Announcement)
+
+	col := c.newCollector(ctx)
+	col.OnError(func(r *colly.Response, err error) {
+		log.Printf("Blog request URL: %s failed with response: %d, error: %v", r.Request.URL, r.StatusCode, err)
+	})
+	col.OnHTML("a[href]", func(e *colly.HTMLElement) {
+		title := strings.Join(strings.Fields(e.Text), " ")
+		m := c.sel.announcement.FindStringSubmatch(title)
+		if m == nil {
+			return
+		}
+		version := "go1"
+		if m[1] != "" && m[1] != "0" {
+			version += "." + m[1]
+		}
+		if _, ok := announcements[version]; !ok {
+			announcements[version] = Announcement{Title: title, URL: e.Request.AbsoluteURL(e.Attr("href"))}
+		}
+	})
+
+	indexURL := c.url(blogIndexPath)
+	if err := col.Visit(indexURL); err != nil {
+		return nil, networkError(fmt.Errorf("failed to visit blog index: %w", err))
+	}
+	if len(announcements) == 0 {
+		return nil, parseError(fmt.Errorf("no release announcements found on %s", indexURL))
+	}
+	log.Printf("Found %d release announcements", len(announcements))
+
+	if published, err := c.publishTimes(ctx); err != nil {
+		log.Printf("Warning: announcement publish times not collected: %v", err)
+	} else {
+		for version, a := range announcements {
+			if u, err := url.Parse(a.URL); err == nil {
+				a.Published = published[u.Path]
+				announcements[version] = a
+			}
+		}
+	}
+
+	if c.opts.highlights || c.opts.contributions {
+		c.fetchPosts(col.Clone(), announcements)
+	}
+	return announcements, nil
+}
+
+// publishTimes reads the publish time of the posts of the blog feed, keyed by URL path. The
+// times keep the time zone the feed gives them in.
+func (c *Client) publishTimes(ctx context.Context) (map[string]string, error) {
+	body, err := c.get(ctx, c.url(blogFeedPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blog feed: %w", err)
+	}
+	var feed struct {
+		Entries []struct {
+			Links []struct {
+				Rel  string `xml:"rel,attr"`
+				Href string `xml:"href,attr"`
+			} `xml:"link"`
+			Published string `xml:"published"`
+		} `xml:"entry"`
+	}
+	if err := xml.Unmarshal(body, &feed); err != nil {
+		return nil, parseError(fmt.Errorf("failed to decode blog feed: %w", err))
+	}
+	published := make(map[string]string, len(feed.Entries))
+	for _, e := range feed.Entries {
+		t, err := time.Parse(time.RFC3339, strings.TrimSpace(e.Published))
+		if err != nil {
+			continue
+		}
+		for _, l := range e.Links {
+			if u, err := url.Parse(l.Href); err == nil && (l.Rel == "" || l.Rel == "alternate") {
+				published[u.Path] = t.Format(time.RFC3339)
+			}
+		}
+	}
+	return published, nil
+}
+
+// fetchPosts fills in the opening paragraph and contributor count of each announcement.
+// Posts that cannot be fetched are left without them.
+func (c *Client) fetchPosts(col *colly.Collector, announcements map[string]Announcement) {
+	log := c.opts.logger
+	col.Limit(&colly.LimitRule{DomainGlob: "*", Delay: postDelay})
+
+	for version, a := range announcements {
+		pc := col.Clone()
+		pc.OnHTML("html", func(e *colly.HTMLElement) {
+			a.contributors = countContributors(e.DOM.Text())
+			if !c.opts.highlights {
+				return
+			}
+			e.DOM.Find(c.sel.Announcements.Paragraphs).EachWithBreak(func(_ int, s *goquery.Selection) bool {
+				if s.HasClass("author") {
+					return true
+				}
+				if text := strings.Join(strings.Fields(s.Text()), " "); text != "" {
+					a.Highlights = text
+					return false
+				}
+				return true
+			})
+		})
+		if err := pc.Visit(a.URL); err != nil {
+			log.Printf("Failed to fetch announcement for %s: %v", version, err)
+		}
+		announcements[version] = a
+	}
+}
+
+// addAnnouncements links each version to its announcement post. Failing to find the posts
+// only loses the links, so it is logged rather than returned.
+func (c *Client) addAnnouncements(ctx context.Context, data []VersionData) {
+	announcements, err := c.Announcements(ctx)
+	if err != nil {
+		c.opts.logger.Printf("Warning: release announcements not linked: %v", err)
+		return
+	}
+	for i := range data {
+		if a, ok := announcements[data[i].Version]; ok {
+			data[i].Announcement = &a
+			if at, err := time.Parse(time.RFC3339, a.Published); err == nil && at.Format(DateLayout) == data[i].ReleaseDate {
+				data[i].ReleasedAt = a.Published
+			}
+			if a.contributors > 0 {
+				data[i].Contributions = cmp.Or(data[i].Contributions, &ContributionStats{})
+				data[i].Contributions.Contributors = a.contributors
+			}
+		}
+	}
+}
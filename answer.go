@@ -0,0 +1,143 @@
+package gover
+
+import (
+	"cmp"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Evidence is a passage of the release notes found by Answer, with the version and the URL
+// of the section it comes from as its citation.
+type Evidence struct {
+	Version  string  `json:"version"`
+	Category string  `json:"category"`
+	Package  string  `json:"package,omitempty"`
+	Symbol   string  `json:"symbol,omitempty"`
+	Text     string  `json:"text"`
+	URL      string  `json:"url,omitempty"`
+	Score    float64 `json:"score"`
+}
+
+// Citation returns the version and URL citing e, e.g. "go1.22, https://go.dev/doc/go1.22#net/http".
+func (e Evidence) Citation() string {
+	if e.URL == "" {
+		return e.Version
+	}
+	return e.Version + ", " + e.URL
+}
+
+// maxEvidence caps the number of passages returned by Answer.
+const maxEvidence = 5
+
+var (
+	// questionVersionRe matches the Go release a question is about, such as "Go 1.22" or "go1.22".
+	questionVersionRe = regexp.MustCompile(`(?i)\bgo\s*(1(?:\.\d+){0,2})\b`)
+	// wordRe matches the words of questions and passages.
+	wordRe = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+)
+
+// questionStopWords are the words of questions that say nothing about what is asked for.
+var questionStopWords = map[string]bool{
+	"a": true, "about": true, "an": true, "and": true, "any": true, "are": true, "at": true,
+	"be": true, "can": true, "change": true, "changed": true, "changes": true, "did": true,
+	"do": true, "does": true, "for": true, "from": true, "happened": true, "how": true,
+	"i": true, "in": true, "is": true, "it": true, "new": true, "of": true, "on": true,
+	"or": true, "release": true, "the": true, "there": true, "to": true, "was": true,
+	"were": true, "what": true, "when": true, "which": true, "why": true, "with": true,
+}
+
+// answerPassage is a passage Answer ranks, with the frequency of each of its words.
+type answerPassage struct {
+	evidence Evidence
+	words    map[string]int
+}
+
+// Answer finds the passages of the release notes in data that best answer question, best
+// first, for retrieval-augmented tools. Passages are the sections and symbol changes of each
+// version, ranked by the question's words they contain, rarer words weighing more. A question
+// naming a release, such as "What changed in net/http in Go 1.22?", only considers that
+// release. No language model is involved: the passages are returned as evidence, with their
+// citations, for the caller to present or summarize.
+func Answer(question string, data []VersionData) []Evidence {
+	if m := questionVersionRe.FindStringSubmatch(question); m != nil {
+		if vd, ok := FindVersion(data, m[1]); ok {
+			data = []VersionData{vd}
+		}
+		question = strings.Replace(question, m[0], " ", 1)
+	}
+	var terms []string
+	for _, w := range wordRe.FindAllString(strings.ToLower(question), -1) {
+		if !questionStopWords[w] && !slices.Contains(terms, w) {
+			terms = append(terms, w)
+		}
+	}
+	if len(terms) == 0 {
+		return nil
+	}
+
+	filter := SectionFilter{Deny: DefaultSectionDeny}
+	var passages []answerPassage
+	add := func(e Evidence, text string) {
+		words := make(map[string]int)
+		for _, w := range wordRe.FindAllString(strings.ToLower(text), -1) {
+			words[w]++
+		}
+		passages = append(passages, answerPassage{e, words})
+	}
+	for _, vd := range data {
+		for _, cat := range vd.Changes {
+			if cat.Boilerplate || cat.Category == overviewCategory || filter.IsBoilerplate(cat.Category) {
+				continue
+			}
+			base := Evidence{Version: vd.Version, Category: cat.Category, Package: cat.Package, URL: cmp.Or(cat.URL, vd.URL)}
+			if text := collapseSpace(joinNonEmpty(append([]string{cat.Title, cat.Description}, cat.Examples...))); text != "" {
+				e := base
+				e.Text = text
+				add(e, strings.Join([]string{cat.Category, cat.Package, text}, " "))
+			}
+			for _, sc := range cat.Changes {
+				e := base
+				e.Symbol, e.Text = sc.Symbol, collapseSpace(sc.Description)
+				add(e, strings.Join([]string{cat.Package, sc.Symbol, e.Text}, " "))
+			}
+		}
+	}
+
+	// Weigh each term by its inverse document frequency, and its occurrences in a passage
+	// with diminishing returns, as BM25 does.
+	idf := make(map[string]float64, len(terms))
+	for _, t := range terms {
+		n := 0
+		for _, p := range passages {
+			if p.words[t] > 0 {
+				n++
+			}
+		}
+		idf[t] = math.Log(1 + (float64(len(passages))-float64(n)+0.5)/(float64(n)+0.5))
+	}
+	var evidence []Evidence
+	for _, p := range passages {
+		score := 0.0
+		for _, t := range terms {
+			if tf := float64(p.words[t]); tf > 0 {
+				score += idf[t] * tf * 2.2 / (tf + 1.2)
+			}
+		}
+		if score > 0 {
+			p.evidence.Score = math.Round(score*1000) / 1000
+			evidence = append(evidence, p.evidence)
+		}
+	}
+	slices.SortStableFunc(evidence, func(a, b Evidence) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(parseVersionMinor(b.Version), parseVersionMinor(a.Version))
+	})
+	if len(evidence) > maxEvidence {
+		evidence = evidence[:maxEvidence]
+	}
+	return evidence
+}
@@ -0,0 +1,155 @@
+package gover
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// apiFileURL is the location of the api/<version>.txt files in the Go repository,
+// which list every exported symbol added in each release, and of api/except.txt.
+const apiFileURL = "https://raw.githubusercontent.com/golang/go/master/api/%s.txt"
+
+// APIFeature is one line of an api/go1.N.txt file, e.g.
+// "pkg net/http, func NewRequestWithContext(context.Context, string, string, io.Reader) (*Request, error)".
+type APIFeature struct {
+	Package  string `json:"package"`
+	Platform string `json:"platform,omitempty"` // e.g. "linux-amd64"; empty if the feature is portable
+	Kind     string `json:"kind"`               // const, var, func, method, type
+	Name     string `json:"name"`               // e.g. "NewRequestWithContext", "(*Client).Do", "Request.Pattern"
+	Decl     string `json:"decl"`               // the declaration as written in the api file
+}
+
+// Symbol returns the fully-qualified symbol name, e.g. "net/http.NewRequestWithContext".
+func (f APIFeature) Symbol() string {
+	return f.Package + "." + f.Name
+}
+
+// APIGrowth is the growth of the exported standard library API in one release.
+type APIGrowth struct {
+	Version  string         `json:"version"`
+	Added    int            `json:"added"`    // symbols added in this release
+	Total    int            `json:"total"`    // symbols in the standard library as of this release
+	Packages map[string]int `json:"packages"` // symbols added per package
+}
+
+// FetchAPIFeatures downloads and parses the api file for version (e.g. "go1.21", or "go1" for the original API).
+// It is a shorthand for NewClient().APIFeatures.
+func FetchAPIFeatures(version string) ([]APIFeature, error) {
+	return NewClient().APIFeatures(context.Background(), version)
+}
+
+// APIFeatures downloads and parses the api file for version (e.g. "go1.21", "go1" for the
+// original API, or "except" for the compatibility exceptions).
+func (c *Client) APIFeatures(ctx context.Context, version string) ([]APIFeature, error) {
+	url := fmt.Sprintf(apiFileURL, version)
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	return ParseAPIFile(bytes.NewReader(body))
+}
+
+// ParseAPIFile parses the contents of an api/go1.N.txt file. Blank lines and comments are skipped.
+func ParseAPIFile(r io.Reader) ([]APIFeature, error) {
+	var features []APIFeature
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f, err := parseAPILine(line)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, f)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read api file: %w", err)
+	}
+	return features, nil
+}
+
+// parseAPILine parses a single "pkg path[ (platform)], decl" line.
+func parseAPILine(line string) (APIFeature, error) {
+	head, decl, ok := strings.Cut(line, ", ")
+	if !ok || !strings.HasPrefix(head, "pkg ") {
+		return APIFeature{}, parseError(fmt.Errorf("malformed api line: %s", line))
+	}
+	f := APIFeature{Package: strings.TrimPrefix(head, "pkg "), Decl: decl}
+	if pkg, plat, ok := strings.Cut(f.Package, " ("); ok {
+		f.Package, f.Platform = pkg, strings.TrimSuffix(plat, ")")
+	}
+
+	kind, rest, _ := strings.Cut(decl, " ")
+	f.Kind = kind
+	switch kind {
+	case "method":
+		// method (*Client) Do(*Request) (*Response, error)
+		if end := strings.Index(rest, ") "); end >= 0 {
+			recv := rest[1:end]
+			f.Name = "(" + recv + ")." + identifier(rest[end+2:])
+		}
+	case "type":
+		// type Request struct, Pattern string
+		// type Handler interface, ServeHTTP(ResponseWriter, *Request)
+		name := identifier(rest)
+		if _, member, ok := strings.Cut(rest, ", "); ok {
+			name += "." + identifier(member)
+		}
+		f.Name = name
+	default:
+		f.Name = identifier(rest)
+	}
+	if f.Name == "" {
+		return APIFeature{}, parseError(fmt.Errorf("malformed api declaration: %s", line))
+	}
+	return f, nil
+}
+
+// identifier returns the leading Go identifier of s.
+func identifier(s string) string {
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r > 0x7f)
+	})
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
+
+// ComputeAPIGrowth summarizes the api files of each version, in release order. Symbols are
+// counted once regardless of how many platforms they are listed for. versions should start
+// with "go1" so the totals include the original API.
+func ComputeAPIGrowth(versions []string, features map[string][]APIFeature) []APIGrowth {
+	versions = slices.Clone(versions)
+	slices.SortFunc(versions, func(a, b string) int {
+		return cmp.Compare(parseVersionMinor(a), parseVersionMinor(b))
+	})
+
+	seen := make(map[string]bool)
+	growth := make([]APIGrowth, 0, len(versions))
+	total := 0
+	for _, v := range versions {
+		g := APIGrowth{Version: v, Packages: make(map[string]int)}
+		for _, f := range features[v] {
+			sym := f.Symbol()
+			if seen[sym] {
+				continue
+			}
+			seen[sym] = true
+			g.Added++
+			g.Packages[f.Package]++
+		}
+		total += g.Added
+		g.Total = total
+		growth = append(growth, g)
+	}
+	return growth
+}
@@ -0,0 +1,68 @@
+package gover
+
+import (
+	"regexp"
+	"strings"
+)
+
+// APIAudit is the result of cross-checking the API additions of a release against its
+// release notes, see AuditAPI.
+type APIAudit struct {
+	Version     string       `json:"version"`
+	Added       int          `json:"added"`     // symbols in the release's api file
+	Mentioned   int          `json:"mentioned"` // of those, mentioned by the release notes
+	Coverage    float64      `json:"coverage"`  // Mentioned / Added, or 1 if nothing was added
+	Unmentioned []APIFeature `json:"unmentioned,omitempty"`
+}
+
+// AuditAPI reports which symbols of features, the api file of vd's release, the release
+// notes never mention. A symbol counts as mentioned if it is one of the symbol changes of
+// vd, or if a category of its package names it, e.g. "Request.Pattern" for the Pattern
+// field of net/http.Request. Symbols listed for several platforms are counted once.
+func AuditAPI(vd VersionData, features []APIFeature) APIAudit {
+	symbols := make(map[string]bool)
+	texts := make(map[string][]string) // the texts of the categories of each package
+	for _, cat := range vd.Changes {
+		for _, sc := range cat.Changes {
+			symbols[sc.Symbol] = true
+		}
+		if cat.Package != "" {
+			texts[cat.Package] = append(texts[cat.Package], cat.Description)
+			for _, sc := range cat.Changes {
+				texts[cat.Package] = append(texts[cat.Package], sc.Description)
+			}
+		}
+	}
+
+	audit := APIAudit{Version: vd.Version}
+	seen := make(map[string]bool)
+	for _, f := range features {
+		sym := apiSymbol(f)
+		if seen[sym] {
+			continue
+		}
+		seen[sym] = true
+		audit.Added++
+		if symbols[sym] || namedIn(strings.TrimPrefix(sym, f.Package+"."), texts[f.Package]) {
+			audit.Mentioned++
+		} else {
+			audit.Unmentioned = append(audit.Unmentioned, f)
+		}
+	}
+	audit.Coverage = 1
+	if audit.Added > 0 {
+		audit.Coverage = float64(audit.Mentioned) / float64(audit.Added)
+	}
+	return audit
+}
+
+// namedIn reports whether any of texts contains name as a whole word.
+func namedIn(name string, texts []string) bool {
+	re := regexp.MustCompile(`(^|[^\w.])` + regexp.QuoteMeta(name) + `($|[^\w])`)
+	for _, t := range texts {
+		if re.MatchString(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+// Package app implements the gover command, so that programs can run any of its
+// subcommands as the binary would, see Run. cmd/gover only calls Main.
+package app
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+// command is a gover subcommand.
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{name: "scrape", usage: "scrape go.dev and write the dataset (default)", run: runScrape},
+	{name: "archive", usage: "store a scrape as a dated snapshot in an archive directory", run: runArchive},
+	{name: "audit", usage: "report API additions the release notes of a version never mention", run: runAudit},
+	{name: "audit-images", usage: "report outdated Go versions pinned in Dockerfiles and CI config", run: runAuditImages},
+	{name: "check", usage: "report uses of symbols affected by upgrading Go", run: runCheck},
+	{name: "chunks", usage: "export one record per change, sized for embedding", run: runChunks},
+	{name: "delta", usage: "report release notes edited on go.dev between two scrapes", run: runDelta},
+	{name: "deprecations", usage: "harvest the Deprecated notes of the standard library source", run: runDeprecations},
+	{name: "deprecated", usage: "report uses of deprecated standard library symbols", run: runDeprecated},
+	{name: "diff", usage: "print the changes between two versions", run: runDiff},
+	{name: "digest", usage: "summarize a release in Markdown or Slack markup for posting on release day", run: runDigest},
+	{name: "eol", usage: "report which releases are still supported", run: runEOL},
+	{name: "feature", usage: "report the Go release that introduced a go.mod feature or build tag", run: runFeature},
+	{name: "get", usage: "print the changes in a version", run: runGet},
+	{name: "grep", usage: "print the sentences of the release notes matching a regular expression", run: runGrep},
+	{name: "healthcheck", usage: "report OK, WARNING or CRITICAL by how stale the toolchain or dataset is", run: runHealthcheck},
+	{name: "highlights", usage: "list the most notable changes of a version", run: runHighlights},
+	{name: "latest", usage: "print the latest Go release, or cross-check its sources", run: runLatest},
+	{name: "links", usage: "report dead links in the release notes of a version", run: runLinks},
+	{name: "list", usage: "list the versions in the dataset", run: runList},
+	{name: "load", usage: "load the dataset into a PostgreSQL database", run: runLoad},
+	{name: "matrix", usage: "run a command under each matching Go toolchain", run: runMatrix},
+	{name: "matrix-view", usage: "compare the changes of several releases by category, package or kind", run: runMatrixView},
+	{name: "merge", usage: "combine datasets covering different versions", run: runMerge},
+	{name: "minver", usage: "report the oldest Go release that could compile a file", run: runMinVer},
+	{name: "mirror", usage: "save the pages a scrape reads, for offline use", run: runMirror},
+	{name: "open", usage: "open the release notes of a version or section in the browser", run: runOpen},
+	{name: "package", usage: "print the changes to a package across versions", run: runPackage},
+	{name: "pin", usage: "print the snippets pinning the latest patch of a Go version", run: runPin},
+	{name: "policy", usage: "evaluate a Go version against an organization's policy file", run: runPolicy},
+	{name: "port", usage: "print the timeline of a GOOS/GOARCH port", run: runPort},
+	{name: "preflight", usage: "list the release notes to read before upgrading a kind of program", run: runPreflight},
+	{name: "releases", usage: "print the release timeline from go.dev", run: runReleases},
+	{name: "search", usage: "search the text of all changes", run: runSearch},
+	{name: "security", usage: "summarize the security patches of a minor line and the release to be on", run: runSecurity},
+	{name: "serve", usage: "serve the dataset over HTTP", run: runServe},
+	{name: "stats", usage: "summarize the dataset and API growth", run: runStats},
+	{name: "symbol", usage: "print the timeline of a standard library symbol", run: runSymbol},
+	{name: "toolchains", usage: "list the downloadable toolchains for a GOOS/GOARCH", run: runToolchains},
+	{name: "verify-dataset", usage: "check a dataset's checksum, schema, version order and release dates", run: runVerifyDataset},
+	{name: "when", usage: "report when a symbol was added or changed", run: runWhen},
+	{name: "weekly", usage: "print the weekly snapshots that preceded Go 1, from golang.org's archives", run: runWeekly},
+}
+
+// Main runs the gover command with the arguments of the process and exits with the code
+// of its outcome, see ExitCode. It is all cmd/gover does.
+func Main() {
+	// Keep stdout for results, so that output can be piped.
+	log.SetOutput(os.Stderr)
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	err := Run(os.Args[1:])
+	var fe flagParseError
+	if err != nil && !errors.As(err, &fe) { // the flag set has reported it
+		log.Printf("Error %v", err)
+	}
+	os.Exit(ExitCode(err))
+}
+
+// Run runs the gover command with args, the arguments following the program name, as
+// the gover binary does: the first argument names the subcommand, scrape if it is a flag
+// or there is none. An unknown subcommand is a usage error, rather than a scrape. Results are written to stdout, or the files the flags name, and progress to
+// the standard logger. Flag errors are reported to stderr and returned, but never exit
+// the program, so that another program can embed the command.
+func Run(args []string) error {
+	cmd := commands[0]
+	if len(args) > 0 {
+		if c, ok := lookupCommand(args[0]); ok {
+			cmd, args = c, args[1:]
+		} else if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+			usage()
+			return nil
+		} else if !strings.HasPrefix(args[0], "-") {
+			return usageError("unknown command %q; run gover help for the list", args[0])
+		}
+	}
+	if err := cmd.run(args); err != nil {
+		return fmt.Errorf("running %s: %w", cmd.name, err)
+	}
+	return nil
+}
+
+// Command describes a subcommand of gover.
+type Command struct {
+	Name  string
+	Usage string
+}
+
+// Commands returns the subcommands of gover, scrape, the default, first.
+func Commands() []Command {
+	cmds := make([]Command, len(commands))
+	for i, c := range commands {
+		cmds[i] = Command{Name: c.name, Usage: c.usage}
+	}
+	return cmds
+}
+
+// lookupCommand returns the subcommand with the given name.
+func lookupCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return command{}, false
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: gover [command] [flags]\n\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-14s %s\n", c.name, c.usage)
+	}
+}
+
+// newClient returns a client logging its progress and warnings to stderr, which the
+// library leaves to its users.
+func newClient(opts ...gover.Option) *gover.Client {
+	return gover.NewClient(append([]gover.Option{gover.WithLogger(log.Default())}, opts...)...)
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/paulstuart/gover"
+)
+
+func runArchive(args []string) error {
+	fs := flag.NewFlagSet("archive", flag.ContinueOnError)
+	dir := fs.String("dir", "snapshots", "Archive directory")
+	dataFile := fs.String("data", "", "Archive this dataset JSON file instead of scraping")
+	baseURL := fs.String("base-url", "https://go.dev", "Site to scrape release notes from, or a file URL of a directory written by gover mirror")
+	drafts := fs.Bool("drafts", true, "Add the draft release notes of the next release from tip.golang.org, if any")
+	cacheDir := fs.String("cache-dir", "", "Directory caching fetched pages between runs")
+	cacheTTL := fs.Duration("cache-ttl", 24*time.Hour, "How long cached pages are reused (0 keeps them forever)")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 0 {
+		return usageError("usage: gover archive [-dir snapshots] [-data file]")
+	}
+
+	var dataset *gover.Dataset
+	var err error
+	if *dataFile != "" {
+		if dataset, err = gover.LoadDataset(*dataFile); err != nil {
+			return err
+		}
+	} else {
+		opts := []gover.Option{gover.WithBaseURL(*baseURL), gover.WithDrafts(*drafts)}
+		if *cacheDir != "" {
+			cache, err := gover.NewDirCache(*cacheDir, *cacheTTL)
+			if err != nil {
+				return err
+			}
+			opts = append(opts, gover.WithCache(cache))
+		}
+		// Reuse the unchanged versions of the newest snapshot.
+		if _, err := os.Stat(*dir); err == nil {
+			if prev, err := gover.LoadArchivedDataset(*dir, time.Now()); err == nil {
+				opts = append(opts, gover.WithPrevious(prev.Versions))
+			}
+		}
+		if dataset, err = newClient(opts...).ScrapeDataset(context.Background()); err != nil {
+			return fmt.Errorf("scraping: %w", err)
+		}
+	}
+
+	snapshot, err := gover.ArchiveDataset(*dir, dataset)
+	if err != nil {
+		return err
+	}
+	changed := "none"
+	if len(snapshot.Changed) > 0 {
+		changed = strings.Join(snapshot.Changed, ", ")
+	}
+	log.Printf("Archived %d versions as %s; changed: %s", snapshot.Versions, snapshot.File, changed)
+	return nil
+}
@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/paulstuart/gover"
+)
+
+func runAudit(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("audit", flag.ContinueOnError)
+	q.register(fs, false)
+	apiFile := fs.String("api-file", "", "Read the api file from this path instead of the Go repository")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover audit [-data file] [-api-file file] <version>")
+	}
+
+	data, err := q.loadData()
+	if err != nil {
+		return err
+	}
+	vd, ok := gover.FindVersion(data, fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("version %s not found", fs.Arg(0))
+	}
+	var features []gover.APIFeature
+	if *apiFile != "" {
+		f, err := os.Open(*apiFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		features, err = gover.ParseAPIFile(f)
+	} else {
+		features, err = newClient().APIFeatures(context.Background(), vd.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("reading api file for %s: %w", vd.Version, err)
+	}
+
+	audit := gover.AuditAPI(vd, features)
+	if err := q.print(audit); err != nil {
+		return err
+	}
+	if n := len(audit.Unmentioned); n > 0 {
+		return violationError("%d of %d API additions in %s not mentioned by the release notes", n, audit.Added, vd.Version)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runAuditImages(args []string) error {
+	fs := flag.NewFlagSet("audit-images", flag.ContinueOnError)
+	maxBehind := fs.Int("max-behind", 2, "Report pins trailing the latest patch release by more than this many releases")
+	format := fs.String("format", "text", "Output format (text|"+strings.Join(gover.Formats(), "|")+")")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	roots := fs.Args()
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	var pins []gover.PinnedVersion
+	for _, root := range roots {
+		found, err := gover.ScanPins(root)
+		if err != nil {
+			return err
+		}
+		pins = append(pins, found...)
+	}
+	if len(pins) == 0 {
+		fmt.Println("No pinned Go versions found")
+		return nil
+	}
+
+	releases, err := newClient().Downloads(context.Background())
+	if err != nil {
+		return err
+	}
+	findings := gover.AuditPins(pins, gover.StableVersions(releases), *maxBehind)
+	if *format == "text" {
+		for _, f := range findings {
+			fmt.Printf("%s:%d: %s: %s\n", f.Pin.File, f.Pin.Line, f.Pin.Source, f.Message)
+		}
+		fmt.Printf("%d pinned versions checked, %d need attention\n", len(pins), len(findings))
+	} else if err := gover.Encode(os.Stdout, *format, findings); err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		return violationError("%d outdated Go version pin(s)", len(findings))
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package app
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/paulstuart/gover"
+)
+
+func runDeprecated(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("deprecated", flag.ContinueOnError)
+	q.register(fs, false)
+	deprecations := fs.String("deprecations", "", "Deprecation dataset from gover deprecations to check besides the release notes")
+	byModule := fs.Bool("by-module", false, "Print the number of findings in each module of a go.work workspace instead of the findings")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	if *deprecations != "" {
+		deps, err := gover.LoadDeprecations(*deprecations)
+		if err != nil {
+			return err
+		}
+		data = deprecationData(data, deps)
+	}
+	targets, err := scanTargets(fs.Args())
+	if err != nil {
+		return err
+	}
+	var findings []gover.Finding
+	for _, t := range targets {
+		findings = append(findings, t.findings(gover.FindDeprecatedUses(t.uses, data))...)
+	}
+	return q.report(findings, targets, *byModule)
+}
+
+func runCheck(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("check", flag.ContinueOnError)
+	q.register(fs, false)
+	from := fs.String("from", "", "Go version upgrading from (default: the go directive of go.mod, or of each module's in a workspace)")
+	to := fs.String("to", "", "Go version upgrading to (default: the newest version in the dataset)")
+	byModule := fs.Bool("by-module", false, "Print the number of findings in each module of a go.work workspace instead of the findings")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	roots := fs.Args()
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+	if *to == "" {
+		for _, vd := range data {
+			if *to == "" || gover.CompareVersions(vd.Version, *to) > 0 {
+				*to = vd.Version
+			}
+		}
+	}
+
+	targets, err := scanTargets(roots)
+	if err != nil {
+		return err
+	}
+	var findings []gover.Finding
+	for _, t := range targets {
+		upgradeFrom := *from
+		if upgradeFrom == "" {
+			if upgradeFrom, err = t.goVersion(); err != nil {
+				return err
+			}
+		}
+		found, err := gover.CheckUpgrade(t.uses, data, upgradeFrom, *to)
+		if err != nil {
+			err = fmt.Errorf("%s: %w", cmp.Or(t.module.Path, t.dir), err)
+			if *from != "" { // only the flags are the user's arguments, not a go.mod
+				err = exitError{exitUsage, err}
+			}
+			return err
+		}
+		findings = append(findings, t.findings(found)...)
+	}
+	return q.report(findings, targets, *byModule)
+}
+
+// scanTarget is the symbol uses found in a directory given to scan or, if it has a go.work
+// file, in a module of the workspace.
+type scanTarget struct {
+	dir    string
+	module gover.WorkspaceModule // set in a workspace
+	uses   []gover.SymbolUse
+}
+
+// scanTargets scans the given directories, or the current one, for symbol uses. A
+// directory with a go.work file is scanned module by module.
+func scanTargets(roots []string) ([]scanTarget, error) {
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+	var targets []scanTarget
+	for _, root := range roots {
+		work := filepath.Join(root, "go.work")
+		if _, err := os.Stat(work); err == nil {
+			ws, err := gover.LoadWorkspace(work)
+			if err != nil {
+				return nil, err
+			}
+			uses, err := ws.ScanSymbolUses()
+			if err != nil {
+				return nil, err
+			}
+			for _, m := range ws.Modules {
+				targets = append(targets, scanTarget{dir: m.Dir, module: m, uses: uses[m.Path]})
+			}
+			continue
+		}
+		uses, err := gover.ScanSymbolUses(root)
+		if err != nil {
+			return nil, err
+		}
+		targets = append(targets, scanTarget{dir: root, uses: uses})
+	}
+	return targets, nil
+}
+
+// goVersion returns the go directive of the module of t in a workspace or, outside one,
+// of the go.mod file in its directory.
+func (t scanTarget) goVersion() (string, error) {
+	if t.module.Go != "" {
+		return t.module.Go, nil
+	}
+	return moduleGoVersion(t.dir)
+}
+
+// findings returns findings attributed to the module of t, if any.
+func (t scanTarget) findings(findings []gover.Finding) []gover.Finding {
+	for i := range findings {
+		findings[i].Module = t.module.Path
+	}
+	return findings
+}
+
+// goDirectiveRe matches the go directive of a go.mod file.
+var goDirectiveRe = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+(?:\.\d+)?)\s*$`)
+
+// moduleGoVersion returns the Go version declared by the go.mod file in dir.
+func moduleGoVersion(dir string) (string, error) {
+	b, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", usageError("no -from version given and %v", err)
+	}
+	m := goDirectiveRe.FindSubmatch(b)
+	if m == nil {
+		return "", usageError("no -from version given and no go directive in %s", filepath.Join(dir, "go.mod"))
+	}
+	return string(m[1]), nil
+}
+
+// report prints findings, or with byModule the number in each module of the workspaces
+// scanned, and fails with a violation if there are any.
+func (q *queryFlags) report(findings []gover.Finding, targets []scanTarget, byModule bool) error {
+	var out any = findings
+	if byModule {
+		var modules []gover.WorkspaceModule
+		for _, t := range targets {
+			if t.module.Path != "" {
+				modules = append(modules, t.module)
+			}
+		}
+		if len(modules) == 0 {
+			return usageError("-by-module needs a directory with a go.work file")
+		}
+		out = gover.BreakDown(modules, findings)
+	}
+	if err := q.print(out); err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		return violationError("%d finding(s)", len(findings))
+	}
+	return nil
+}
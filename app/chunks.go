@@ -0,0 +1,24 @@
+package app
+
+import (
+	"flag"
+
+	"github.com/paulstuart/gover"
+)
+
+func runChunks(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("chunks", flag.ContinueOnError)
+	q.register(fs, true)
+	q.registerDates(fs)
+	maxTokens := fs.Int("max-tokens", 0, "Trim each chunk to about this many tokens (0 disables trimming)")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	data, types, err := q.load()
+	if err != nil {
+		return err
+	}
+	return q.print(gover.Chunks(gover.FilterByType(data, types...), *maxTokens))
+}
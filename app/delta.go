@@ -0,0 +1,37 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/paulstuart/gover"
+)
+
+func runDelta(args []string) error {
+	fs := flag.NewFlagSet("delta", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 2 {
+		return usageError("usage: gover delta <previous dataset> <current dataset>")
+	}
+
+	previous, err := gover.LoadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	current, err := gover.LoadFile(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+
+	deltas := gover.Delta(previous, current)
+	if len(deltas) == 0 {
+		fmt.Println("No release notes changed since the last scrape")
+		return nil
+	}
+	for _, d := range deltas {
+		fmt.Println(d.Message)
+	}
+	return nil
+}
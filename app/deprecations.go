@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runDeprecations(args []string) error {
+	fs := flag.NewFlagSet("deprecations", flag.ContinueOnError)
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	format := fs.String("format", "json", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	goroot := fs.String("goroot", "", "Harvest the Go installation at this GOROOT instead of downloading sources")
+	versions := fs.String("versions", "", "Only download the sources of versions matching this constraint, e.g. \">=1.16\"")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	var deps []gover.Deprecation
+	var err error
+	if *goroot != "" {
+		deps, err = gover.LocalDeprecations(*goroot)
+	} else {
+		var c gover.Constraint
+		if *versions != "" {
+			if c, err = gover.ParseConstraint(*versions); err != nil {
+				return usageError("invalid -versions: %v", err)
+			}
+		}
+		deps, err = newClient().StdlibDeprecations(context.Background(), c)
+	}
+	if err != nil {
+		return err
+	}
+	log.Printf("Found %d deprecations", len(deps))
+	return writeOutput(*output, func(w io.Writer) error {
+		return gover.Encode(w, *format, deps)
+	})
+}
+
+// deprecationData returns data with deps recorded in it. Versions of deps missing from data
+// are added, so that every deprecation is found.
+func deprecationData(data []gover.VersionData, deps []gover.Deprecation) []gover.VersionData {
+	known := make(map[string]bool)
+	for _, vd := range data {
+		known[vd.Version] = true
+	}
+	for _, d := range deps {
+		if !known[d.Version] {
+			known[d.Version] = true
+			data = append(data, gover.VersionData{Version: d.Version})
+		}
+	}
+	gover.AddDeprecations(data, deps)
+	return data
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/paulstuart/gover"
+	"github.com/paulstuart/gover/store"
+)
+
+func runDigest(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	q.register(fs, false)
+	q.setDefaultFormat(fs, "markdown")
+	n := fs.Int("n", 5, "Number of highlights to list (0 for all)")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover digest [-data file] [-n count] [-format markdown|slack] <version>")
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	vd, ok := store.Load(data).ByVersion(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("version %s not found", fs.Arg(0))
+	}
+	return q.print(gover.NewDigest(vd, *n))
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/url"
+
+	"github.com/paulstuart/gover"
+)
+
+// Exit codes, documented in the README.
+const (
+	exitOK        = 0
+	exitFailure   = 1 // any failure not listed below
+	exitUsage     = 2 // invalid arguments or flags
+	exitPartial   = 3 // some versions could not be fully scraped
+	exitViolation = 4 // a check found problems, e.g. outdated pins or failing toolchains
+	exitNetwork   = 5 // go.dev or another source could not be reached
+	exitParse     = 6 // a page or file did not have the expected content
+)
+
+// exitError is an error that determines the exit code.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e exitError) Error() string { return e.err.Error() }
+func (e exitError) Unwrap() error { return e.err }
+
+func usageError(format string, args ...any) error {
+	return exitError{exitUsage, fmt.Errorf(format, args...)}
+}
+
+func violationError(format string, args ...any) error {
+	return exitError{exitViolation, fmt.Errorf(format, args...)}
+}
+
+// flagParseError is the error of flags that fail to parse, which the flag set reports
+// along with its usage.
+type flagParseError struct{ err error }
+
+func (e flagParseError) Error() string { return e.err.Error() }
+func (e flagParseError) Unwrap() error { return e.err }
+
+func flagError(err error) error {
+	return flagParseError{err}
+}
+
+// parseArgs parses args with fs, accepting flags after the positional arguments too, as in
+// "gover merge a.json b.json -o merged.json"; arguments after "--" are all positional.
+// fs.Args returns the positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) error {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return flagError(err)
+		}
+		rest := fs.Args()
+		if n := len(args) - len(rest); n > 0 && args[n-1] == "--" || len(rest) == 0 {
+			positional = append(positional, rest...)
+			break
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+	return fs.Parse(append([]string{"--"}, positional...))
+}
+
+// ExitCode returns the exit code of the gover binary for the error Run returns: 0 for
+// none, and otherwise one of the codes listed in the README.
+func ExitCode(err error) int {
+	var ee exitError
+	var fe flagParseError
+	var urlErr *url.Error
+	var opErr *net.OpError
+	switch {
+	case err == nil, errors.Is(err, flag.ErrHelp):
+		return exitOK
+	case errors.As(err, &fe):
+		return exitUsage
+	case errors.As(err, &ee):
+		return ee.code
+	case errors.Is(err, gover.ErrIncomplete):
+		return exitPartial
+	case errors.Is(err, gover.ErrNetwork), errors.As(err, &urlErr), errors.As(err, &opErr):
+		return exitNetwork
+	case errors.Is(err, gover.ErrParse):
+		return exitParse
+	}
+	return exitFailure
+}
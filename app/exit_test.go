@@ -0,0 +1,60 @@
+package app
+
+import (
+	"flag"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestParseArgs(t *testing.T) {
+	tests := []struct {
+		args       []string
+		output     string
+		verbose    bool
+		positional []string
+	}{
+		{nil, "-", false, nil},
+		{[]string{"a.json", "b.json"}, "-", false, []string{"a.json", "b.json"}},
+		{[]string{"-o", "m.json", "a.json", "b.json"}, "m.json", false, []string{"a.json", "b.json"}},
+		{[]string{"a.json", "b.json", "-o", "m.json"}, "m.json", false, []string{"a.json", "b.json"}},
+		{[]string{"a.json", "-v", "b.json", "--o=m.json"}, "m.json", true, []string{"a.json", "b.json"}},
+		{[]string{"a.json", "--", "-v", "b.json"}, "-", false, []string{"a.json", "-v", "b.json"}},
+		{[]string{"-v", "--", "-o", "x"}, "-", true, []string{"-o", "x"}},
+		{[]string{"a.json", "-"}, "-", false, []string{"a.json", "-"}},
+	}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		output := fs.String("o", "-", "")
+		verbose := fs.Bool("v", false, "")
+		if err := parseArgs(fs, tt.args); err != nil {
+			t.Errorf("parseArgs(%q): %v", tt.args, err)
+			continue
+		}
+		if *output != tt.output || *verbose != tt.verbose || !slices.Equal(fs.Args(), tt.positional) {
+			t.Errorf("parseArgs(%q): -o %q -v %t args %q, want -o %q -v %t args %q",
+				tt.args, *output, *verbose, fs.Args(), tt.output, tt.verbose, tt.positional)
+		}
+	}
+}
+
+func TestParseArgsErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"a.json", "-unknown"},
+		{"a.json", "-o"},
+		{"-v=maybe", "a.json"},
+	} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.String("o", "-", "")
+		fs.Bool("v", false, "")
+		err := parseArgs(fs, args)
+		if err == nil {
+			t.Errorf("parseArgs(%q) succeeded, want an error", args)
+			continue
+		}
+		if code := ExitCode(err); code != exitUsage {
+			t.Errorf("parseArgs(%q) exits %d, want %d", args, code, exitUsage)
+		}
+	}
+}
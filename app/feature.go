@@ -0,0 +1,52 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/paulstuart/gover"
+)
+
+func runFeature(args []string) error {
+	fs := flag.NewFlagSet("feature", flag.ContinueOnError)
+	kind := fs.String("kind", "", "Only list features of this kind (go.mod, go.work, build-constraint, build-tag, language, go-command)")
+	goVersion := fs.String("go", "", "Report whether each feature is available in this Go version")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	if fs.NArg() > 1 {
+		return usageError("usage: gover feature [-kind kind] [-go version] [name]")
+	}
+	features := gover.Features
+	if fs.NArg() == 1 {
+		query := fs.Arg(0)
+		var suggestions []string
+		features, suggestions = gover.LookupFeature(query)
+		if len(features) == 0 {
+			if len(suggestions) > 0 {
+				fmt.Printf("No feature matching %q. Did you mean:\n", query)
+				for _, s := range suggestions {
+					fmt.Printf("  %s\n", s)
+				}
+			}
+			return fmt.Errorf("no feature matching %q", query)
+		}
+	}
+
+	for _, f := range features {
+		if *kind != "" && f.Kind != *kind {
+			continue
+		}
+		line := fmt.Sprintf("%s (%s): added in %s - %s", f.Name, f.Kind, f.Version, f.Description)
+		if *goVersion != "" {
+			if f.AvailableIn(*goVersion) {
+				line += fmt.Sprintf(" [available in %s]", gover.NormalizeVersion(*goVersion))
+			} else {
+				line += fmt.Sprintf(" [not available in %s]", gover.NormalizeVersion(*goVersion))
+			}
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"bufio"
+	"cmp"
+	"flag"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runGrep(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("grep", flag.ContinueOnError)
+	q.register(fs, false)
+	q.setDefaultFormat(fs, "text")
+	fs.Lookup("format").Usage = "Output format (text|" + strings.Join(gover.Formats(), "|") + ")"
+	ignoreCase := fs.Bool("i", false, "Match case-insensitively")
+	after := fs.Int("A", 0, "Print this many sentences of context after each match")
+	before := fs.Int("B", 0, "Print this many sentences of context before each match")
+	context := fs.Int("C", 0, "Print this many sentences of context around each match")
+	versions := fs.String("versions", "", "Only search versions matching this constraint, e.g. \">=1.21\"")
+	pkg := fs.String("package", "", "Only search the changes to this import path, or to those under it if it ends in /...")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover grep [-data file] [-i] [-A n] [-B n] [-C n] [-versions constraint] [-package path] <regexp>")
+	}
+
+	pattern := fs.Arg(0)
+	if *ignoreCase {
+		pattern = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return usageError("invalid pattern: %v", err)
+	}
+	opts := gover.GrepOptions{Package: *pkg, Before: max(*before, *context), After: max(*after, *context)}
+	if *versions != "" {
+		if opts.Versions, err = gover.ParseConstraint(*versions); err != nil {
+			return usageError("invalid -versions: %v", err)
+		}
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	matches := gover.Grep(data, re, opts)
+	if q.format != "text" {
+		return q.print(matches)
+	}
+	return writeOutput(q.output, func(w io.Writer) error {
+		return writeGrep(w, matches, opts.Before > 0 || opts.After > 0)
+	})
+}
+
+// writeGrep prints matches as grep does, each sentence prefixed with its version and line,
+// followed by ":" and the package or category for the match, and by "-" for context. With
+// context, each match is printed with its own, separated by "--".
+func writeGrep(w io.Writer, matches []gover.GrepMatch, withContext bool) error {
+	bw := bufio.NewWriter(w)
+	for i, m := range matches {
+		if withContext && i > 0 {
+			fmt.Fprintln(bw, "--")
+		}
+		for j, text := range m.Before {
+			fmt.Fprintf(bw, "%s-%d-%s\n", m.Version, m.Line-len(m.Before)+j, text)
+		}
+		fmt.Fprintf(bw, "%s:%d:%s: %s\n", m.Version, m.Line, cmp.Or(m.Package, m.Category), m.Text)
+		for j, text := range m.After {
+			fmt.Fprintf(bw, "%s-%d-%s\n", m.Version, m.Line+1+j, text)
+		}
+	}
+	return bw.Flush()
+}
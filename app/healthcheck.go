@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/paulstuart/gover"
+)
+
+// healthExitCodes are the exit codes of the health states, those of Nagios plugins, which
+// healthcheck uses in place of the usual ones.
+var healthExitCodes = map[string]int{
+	gover.HealthOK:       0,
+	gover.HealthWarning:  1,
+	gover.HealthCritical: 2,
+	gover.HealthUnknown:  3,
+}
+
+func runHealthcheck(args []string) error {
+	fs := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	warn := fs.Int("warn-days", 30, "Warn when a newer release has been out this many days")
+	crit := fs.Int("crit-days", 90, "Go critical when a newer release has been out this many days")
+	dataFile := fs.String("data", "", "Check this dataset instead of the go command on the PATH")
+	version := fs.String("go", "", "Check this Go version instead of the go command on the PATH")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	health, err := checkHealth(*warn, *crit, *dataFile, *version, fs.NArg())
+	if err != nil {
+		fmt.Printf("GOVER %s - %v\n", gover.HealthUnknown, err)
+		return exitError{healthExitCodes[gover.HealthUnknown], err}
+	}
+	fmt.Println(health)
+	if health.State == gover.HealthOK {
+		return nil
+	}
+	return exitError{healthExitCodes[health.State], fmt.Errorf("%s %s is %s", health.Subject, health.Version, health.State)}
+}
+
+// checkHealth checks the dataset in dataFile if set, otherwise version, or the local go command.
+func checkHealth(warn, crit int, dataFile, version string, nargs int) (gover.Health, error) {
+	switch {
+	case nargs != 0:
+		return gover.Health{}, fmt.Errorf("usage: gover healthcheck [-warn-days n] [-crit-days n] [-data file | -go version]")
+	case warn > crit:
+		return gover.Health{}, fmt.Errorf("-warn-days %d exceeds -crit-days %d", warn, crit)
+	case dataFile != "" && version != "":
+		return gover.Health{}, fmt.Errorf("-data and -go are mutually exclusive")
+	}
+
+	ctx := context.Background()
+	subject := gover.HealthToolchain
+	switch {
+	case dataFile != "":
+		data, err := gover.LoadFile(dataFile)
+		if err != nil {
+			return gover.Health{}, err
+		}
+		subject = gover.HealthDataset
+		for _, vd := range data {
+			if gover.CompareVersions(vd.Version, version) > 0 {
+				version = vd.Version
+			}
+		}
+		if version == "" {
+			return gover.Health{}, fmt.Errorf("dataset %s has no versions", dataFile)
+		}
+	case version == "":
+		var err error
+		if version, err = gover.LocalGoVersion(ctx); err != nil {
+			return gover.Health{}, err
+		}
+	}
+
+	releases, err := newClient().ReleaseHistory(ctx)
+	if err != nil {
+		return gover.Health{}, err
+	}
+	return gover.CheckHealth(subject, version, releases, time.Now(), warn, crit), nil
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/paulstuart/gover"
+	"github.com/paulstuart/gover/store"
+)
+
+func runHighlights(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("highlights", flag.ContinueOnError)
+	q.register(fs, false)
+	q.registerOnlyUsed(fs)
+	n := fs.Int("n", 10, "Number of changes to list (0 for all)")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover highlights [-data file] [-n count] <version>")
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	vd, ok := store.Load(data).ByVersion(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("version %s not found", fs.Arg(0))
+	}
+	return q.print(gover.Highlights(vd, *n))
+}
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runLatest(args []string) error {
+	fs := flag.NewFlagSet("latest", flag.ContinueOnError)
+	source := fs.String("source", gover.SourceGoDev, "Where to look up the latest release ("+gover.SourceGoDev+"|"+gover.SourceProxy+")")
+	verify := fs.Bool("verify", false, "Cross-check go.dev, the module proxy and the checksum database, and exit with code 4 if one lags behind")
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	format := fs.String("format", "json", "Output format of -verify ("+strings.Join(gover.Formats(), "|")+")")
+	proxy := fs.String("proxy", "https://proxy.golang.org", "Module proxy listing the published toolchains")
+	sumdb := fs.String("sumdb", "https://sum.golang.org", "Checksum database recording the published toolchains")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 0 {
+		return usageError("usage: gover latest [-source go.dev|proxy] [-verify]")
+	}
+
+	ctx := context.Background()
+	c := newClient(gover.WithModuleProxy(*proxy), gover.WithSumDB(*sumdb))
+	if *verify {
+		probe := c.ProbeLatestVersion(ctx)
+		if err := writeOutput(*output, func(w io.Writer) error {
+			return gover.Encode(w, *format, probe)
+		}); err != nil {
+			return err
+		}
+		if !probe.Consistent() {
+			for _, e := range probe.Errors {
+				log.Printf("Warning: %s", e)
+			}
+			return violationError("latest release %s, lagging: %v, failed: %d sources", probe.Latest, probe.Lagging, len(probe.Errors))
+		}
+		return nil
+	}
+
+	var (
+		version string
+		err     error
+	)
+	switch *source {
+	case gover.SourceGoDev:
+		version, err = c.LatestVersion(ctx)
+	case gover.SourceProxy:
+		version, err = c.LatestToolchain(ctx)
+	default:
+		return usageError("unknown -source %q", *source)
+	}
+	if err != nil {
+		return err
+	}
+	return writeOutput(*output, func(w io.Writer) error {
+		_, err := fmt.Fprintln(w, version)
+		return err
+	})
+}
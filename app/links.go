@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runLinks(args []string) error {
+	fs := flag.NewFlagSet("links", flag.ContinueOnError)
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	format := fs.String("format", "json", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	all := fs.Bool("all", false, "Report every link, not just dead ones")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover links [-all] <version>")
+	}
+
+	links, err := newClient().CheckLinks(context.Background(), fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	checked := len(links)
+	dead := slices.DeleteFunc(slices.Clone(links), func(l gover.LinkStatus) bool { return !l.Dead })
+	log.Printf("Checked %d links, %d dead", checked, len(dead))
+	if !*all {
+		links = dead
+	}
+	if err := writeOutput(*output, func(w io.Writer) error {
+		return gover.Encode(w, *format, links)
+	}); err != nil {
+		return err
+	}
+	if len(dead) > 0 {
+		return violationError("%d dead link(s)", len(dead))
+	}
+	return nil
+}
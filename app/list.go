@@ -0,0 +1,57 @@
+package app
+
+import (
+	"flag"
+
+	"github.com/paulstuart/gover"
+)
+
+// listEntry is a line of gover list output.
+type listEntry struct {
+	Version       string  `json:"version"`
+	ReleaseDate   string  `json:"releaseDate,omitempty"`
+	Categories    int     `json:"categories"`
+	SymbolChanges int     `json:"symbolChanges"`
+	Quality       float64 `json:"quality"` // see gover.Completeness
+}
+
+func runList(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	q.register(fs, false)
+	q.registerDates(fs)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	entries := make([]listEntry, 0, len(data))
+	for i, vs := range gover.ComputeStats(data).Versions {
+		entries = append(entries, listEntry{
+			Version:       vs.Version,
+			ReleaseDate:   vs.ReleaseDate,
+			Categories:    vs.Categories,
+			SymbolChanges: vs.SymbolChanges,
+			Quality:       gover.Completeness(data[i]).Score,
+		})
+	}
+	return q.print(entries)
+}
+
+func runEOL(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("eol", flag.ContinueOnError)
+	q.register(fs, false)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	return q.print(gover.SupportStatus(data))
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/paulstuart/gover"
+)
+
+func runMatrix(args []string) error {
+	fs := flag.NewFlagSet("matrix", flag.ContinueOnError)
+	versions := fs.String("versions", ">=1.21", "Go versions to test, e.g. \">=1.21\" or \">=1.22,<1.24\"")
+	verbose := fs.Bool("v", false, "Print the output of failed runs")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() == 0 {
+		return usageError("usage: gover matrix [-versions constraint] [-v] -- command [args...]")
+	}
+
+	constraint, err := gover.ParseConstraint(*versions)
+	if err != nil {
+		return exitError{exitUsage, fmt.Errorf("-versions: %w", err)}
+	}
+	available, err := newClient().ToolchainVersions(context.Background())
+	if err != nil {
+		return err
+	}
+	var toolchains []string
+	for _, v := range gover.LatestPatches(available) {
+		if constraint.Match(v) {
+			toolchains = append(toolchains, v)
+		}
+	}
+	if len(toolchains) == 0 {
+		return fmt.Errorf("no downloadable toolchains match %q (toolchains are available from go1.21 on)", *versions)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	results := gover.RunMatrix(ctx, toolchains, fs.Arg(0), fs.Args()[1:]...)
+	failed := 0
+	for _, r := range results {
+		status := "PASS"
+		if !r.Passed {
+			status = "FAIL"
+			failed++
+		}
+		fmt.Printf("%-12s %s  %s\n", r.Toolchain, status, r.Duration.Round(time.Millisecond))
+		if !r.Passed && *verbose {
+			fmt.Println(r.Output)
+		}
+	}
+	fmt.Printf("%d/%d toolchains passed\n", len(results)-failed, len(toolchains))
+	if failed > 0 {
+		return violationError("%d toolchain(s) failed", failed)
+	}
+	return nil
+}
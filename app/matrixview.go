@@ -0,0 +1,39 @@
+package app
+
+import (
+	"flag"
+
+	"github.com/paulstuart/gover"
+)
+
+func runMatrixView(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("matrix-view", flag.ContinueOnError)
+	q.register(fs, false)
+	q.setDefaultFormat(fs, "table")
+	by := fs.String("by", gover.CompareByCategory, "Group changes by category, package or kind")
+	presence := fs.Bool("presence", false, "Show whether each version has changes instead of how many")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usageError("usage: gover matrix-view [-data file] [-by category|package|kind] [-presence] [-format table|csv|html] <version>...")
+	}
+
+	switch *by {
+	case gover.CompareByCategory, gover.CompareByPackage, gover.CompareByKind:
+	default:
+		return usageError("unknown -by grouping %q", *by)
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	c, err := gover.Compare(data, fs.Args(), *by)
+	if err != nil {
+		return err
+	}
+	c.Presence = *presence
+	return q.print(c)
+}
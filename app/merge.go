@@ -0,0 +1,37 @@
+package app
+
+import (
+	"flag"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runMerge(args []string) error {
+	fs := flag.NewFlagSet("merge", flag.ContinueOnError)
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	fs.StringVar(output, "o", "-", "Shorthand for -output")
+	format := fs.String("format", "json", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() < 2 {
+		return usageError("usage: gover merge [-o file] <dataset> <dataset>...")
+	}
+
+	var datasets []*gover.Dataset
+	for _, path := range fs.Args() {
+		ds, err := gover.LoadDataset(path)
+		if err != nil {
+			return err
+		}
+		datasets = append(datasets, ds)
+	}
+	merged := gover.MergeDatasets(datasets...)
+	log.Printf("Merged %d datasets into %d versions (%d complete)", len(datasets), merged.Summary.Versions, merged.Summary.Complete)
+	return writeOutput(*output, func(w io.Writer) error {
+		return gover.Encode(w, *format, merged)
+	})
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"context"
+	"flag"
+)
+
+func runMinVer(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("minver", flag.ContinueOnError)
+	q.register(fs, false)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return usageError("usage: gover minver [-data file] <file.go>...")
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	mv, err := newClient().MinVersion(context.Background(), fs.Args(), data)
+	if err != nil {
+		return err
+	}
+	return q.print(mv)
+}
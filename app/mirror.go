@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/paulstuart/gover"
+)
+
+func runMirror(args []string) error {
+	fs := flag.NewFlagSet("mirror", flag.ContinueOnError)
+	out := fs.String("out", "mirror", "Directory to write the mirror to")
+	baseURL := fs.String("base-url", "https://go.dev", "Site to mirror")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 0 {
+		return usageError("usage: gover mirror [-out dir] [-base-url url]")
+	}
+
+	report, err := newClient(gover.WithBaseURL(*baseURL)).Mirror(context.Background(), *out)
+	if err != nil {
+		return err
+	}
+	log.Printf("Mirrored %d pages and %d assets to %s", len(report.Pages), len(report.Assets), *out)
+	if len(report.Failed) > 0 {
+		log.Printf("Failed to mirror %d files: %v", len(report.Failed), report.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	"github.com/paulstuart/gover"
+)
+
+func runOpen(args []string) error {
+	fs := flag.NewFlagSet("open", flag.ContinueOnError)
+	dataFile := fs.String("data", "go_version_data.json", "Dataset JSON file path")
+	printOnly := fs.Bool("print", false, "Print the URL instead of opening it")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	if fs.NArg() < 1 || fs.NArg() > 2 {
+		return usageError("usage: gover open [-data file] [-print] <version> [section]")
+	}
+	data, err := gover.LoadFile(*dataFile)
+	if err != nil {
+		return err
+	}
+	vd, ok := gover.FindVersion(data, fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("version %s not found", fs.Arg(0))
+	}
+
+	// Datasets scraped before URLs were recorded still get the page, if not the anchor.
+	url := vd.URL
+	if url == "" {
+		url = "https://go.dev/doc/" + vd.Version
+	}
+	if fs.NArg() == 2 {
+		cat, ok := gover.FindSection(vd, fs.Arg(1))
+		if !ok {
+			return fmt.Errorf("no section matching %q in %s", fs.Arg(1), vd.Version)
+		}
+		if cat.URL != "" {
+			url = cat.URL
+		}
+	}
+
+	fmt.Println(url)
+	if *printOnly {
+		return nil
+	}
+	return openBrowser(url)
+}
+
+// openBrowser opens url in the user's default browser.
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("opening browser: %w", err)
+	}
+	return nil
+}
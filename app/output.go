@@ -0,0 +1,91 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// defaultPerm is the permissions of newly created output files.
+const defaultPerm os.FileMode = 0o644
+
+// writeOutput calls write with stdout if path is "-", or with the file at path otherwise.
+// Files are replaced atomically, keeping their permissions, as by writeFile.
+func writeOutput(path string, write func(io.Writer) error) error {
+	return writeFile(path, 0, false, write)
+}
+
+// writeFile calls write with stdout if path is "-", or with a temporary file that then
+// replaces the file at path, so that readers never see a partly written file and a
+// failed write leaves the existing one untouched. The file gets permissions perm or,
+// if perm is 0, those of the file it replaces (defaultPerm for a new file). If backup is
+// set, the file being replaced is copied to path.bak once the new content is written.
+func writeFile(path string, perm os.FileMode, backup bool, write func(io.Writer) error) error {
+	if path == "-" {
+		return write(os.Stdout)
+	}
+	existing, err := os.Stat(path)
+	switch {
+	case err == nil && perm == 0:
+		perm = existing.Mode().Perm()
+	case err != nil && !os.IsNotExist(err):
+		return err
+	case perm == 0:
+		perm = defaultPerm
+	}
+	dir, base := filepath.Split(path)
+	f, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return err
+	}
+	err = write(f)
+	if err == nil {
+		err = f.Sync()
+	}
+	if err == nil {
+		err = f.Chmod(perm)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && backup && existing != nil {
+		if err = backupFile(path, existing.Mode().Perm()); err != nil {
+			err = fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// backupFile atomically copies the file at path to path.bak.
+func backupFile(path string, perm os.FileMode) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return writeFile(path+".bak", perm, false, func(w io.Writer) error {
+		_, err := io.Copy(w, src)
+		return err
+	})
+}
+
+// parsePerm parses an octal permission flag value such as "0644". An empty value is 0.
+func parsePerm(s string) (os.FileMode, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || n > 0o777 {
+		return 0, usageError("invalid -perm %q: want octal permissions such as 0644", s)
+	}
+	return os.FileMode(n), nil
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+// pinFormats maps the names accepted by -format to pin sources.
+var pinFormats = map[string]string{
+	"asdf":       gover.PinAsdf,
+	"mise":       gover.PinMise,
+	"actions":    gover.PinWorkflow,
+	"dockerfile": gover.PinDockerfile,
+	"gomod":      gover.PinGoMod,
+}
+
+func runPin(args []string) error {
+	fs := flag.NewFlagSet("pin", flag.ContinueOnError)
+	format := fs.String("format", "", "Comma-separated snippets to print (asdf|mise|actions|dockerfile|gomod), default all")
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover pin [-format asdf|mise|actions|dockerfile|gomod] <version>")
+	}
+	var sources []string
+	if *format != "" {
+		for _, f := range strings.Split(*format, ",") {
+			source, ok := pinFormats[strings.TrimSpace(f)]
+			if !ok {
+				return usageError("unknown -format %q", f)
+			}
+			sources = append(sources, source)
+		}
+	}
+
+	version, err := newClient().LatestPatch(context.Background(), fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	snippets, err := gover.PinSnippets(version, sources...)
+	if err != nil {
+		return usageError("%v", err)
+	}
+	return writeOutput(*output, func(w io.Writer) error {
+		for i, s := range snippets {
+			if len(snippets) > 1 {
+				if i > 0 {
+					fmt.Fprintln(w)
+				}
+				fmt.Fprintf(w, "# %s\n", s.File)
+			}
+			if _, err := fmt.Fprintln(w, s.Snippet); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runPolicy(args []string) error {
+	if len(args) == 0 || args[0] != "eval" {
+		return usageError("usage: gover policy eval [-policy file] [-current version]")
+	}
+	fs := flag.NewFlagSet("policy eval", flag.ContinueOnError)
+	policyFile := fs.String("policy", "gover-policy.yaml", "Policy file")
+	current := fs.String("current", "", "Go version to evaluate (default: that of the go command on the PATH)")
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	format := fs.String("format", "table", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	if err := fs.Parse(args[1:]); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 0 {
+		return usageError("usage: gover policy eval [-policy file] [-current version]")
+	}
+
+	policy, err := gover.LoadPolicy(*policyFile)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	version := *current
+	if version == "" {
+		if version, err = gover.LocalGoVersion(ctx); err != nil {
+			return err
+		}
+	}
+	releases, err := newClient().ReleaseHistory(ctx)
+	if err != nil {
+		return err
+	}
+	result, err := gover.EvaluatePolicy(policy, releases, version)
+	if err != nil {
+		return usageError("%v", err)
+	}
+	if err := writeOutput(*output, func(w io.Writer) error {
+		return gover.Encode(w, *format, result)
+	}); err != nil {
+		return err
+	}
+	if !result.Pass {
+		return violationError("%s does not comply with %s", result.Version, *policyFile)
+	}
+	return nil
+}
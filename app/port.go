@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/paulstuart/gover"
+)
+
+func runPort(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("port", flag.ContinueOnError)
+	q.register(fs, false)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover port [-data file] <goos|goarch|goos/goarch>")
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	// The local toolchain only adds the current status of each port, so it is optional.
+	ports, err := gover.DistPorts(context.Background())
+	if err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	return q.print(gover.BuildPortTimeline(fs.Arg(0), data, ports))
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"flag"
+
+	"github.com/paulstuart/gover"
+)
+
+func runPreflight(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("preflight", flag.ContinueOnError)
+	q.register(fs, false)
+	q.registerProfile(fs)
+	q.registerOnlyUsed(fs)
+	from := fs.String("from", "", "Version upgrading from")
+	to := fs.String("to", "", "Version upgrading to")
+	n := fs.Int("n", 0, "Number of entries to list (0 for all)")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 || *from == "" || *to == "" {
+		return usageError("usage: gover preflight [-data file] [-config file] [-profile name] -from <version> -to <version>")
+	}
+
+	profile, err := q.loadProfile()
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return usageError("no profile: pass -profile or set a default profile in the configuration file")
+	}
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	list, err := gover.Preflight(data, *from, *to, *profile)
+	if err != nil {
+		return err
+	}
+	if *n > 0 && len(list) > *n {
+		list = list[:*n]
+	}
+	return q.print(list)
+}
@@ -0,0 +1,349 @@
+package app
+
+import (
+	"cmp"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/paulstuart/gover"
+	"github.com/paulstuart/gover/store"
+)
+
+// queryFlags are the flags shared by the commands that query an existing dataset.
+type queryFlags struct {
+	dataFile string
+	output   string
+	format   string
+	types    string
+	after    string
+	before   string
+	goos     string
+	goarch   string
+	asOf     string
+	archive  string
+	overlay  string
+	query    string
+	profile  string
+	config   string
+
+	onlyUsed listFlag
+
+	withProfile bool
+	selected    *gover.Profile
+}
+
+func (q *queryFlags) register(fs *flag.FlagSet, withType bool) {
+	q.registerData(fs)
+	fs.StringVar(&q.output, "output", "-", "Output file path, or - for stdout")
+	fs.StringVar(&q.format, "format", "json", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	fs.StringVar(&q.query, "query", "", "Print only the value this GJSON or JSONPath style path selects from the output, e.g. versions.0.releaseDate")
+	fs.StringVar(&q.goos, "goos", "", "Hide changes scoped to operating systems other than this GOOS")
+	fs.StringVar(&q.goarch, "goarch", "", "Hide changes scoped to architectures other than this GOARCH")
+	if withType {
+		fs.StringVar(&q.types, "type", "", "Only show changes of these types (comma-separated: "+gover.JoinNames(gover.ChangeTypes)+")")
+	}
+}
+
+// registerData adds the flags choosing the dataset load reads, and nothing else, for
+// commands that do not print query results.
+func (q *queryFlags) registerData(fs *flag.FlagSet) {
+	fs.StringVar(&q.dataFile, "data", "go_version_data.json", "Dataset JSON file path")
+	fs.StringVar(&q.asOf, "as-of", "", "Read the dataset as archived on this date (YYYY-MM-DD) instead of -data")
+	fs.StringVar(&q.archive, "archive", "snapshots", "Archive directory read by -as-of")
+	fs.StringVar(&q.overlay, "overlay", "", "YAML file of local notes to merge into the changes they concern")
+}
+
+// setDefaultFormat changes the default of the -format flag, for commands whose output
+// reads best in another format than JSON.
+func (q *queryFlags) setDefaultFormat(fs *flag.FlagSet, format string) {
+	q.format = format
+	fs.Lookup("format").DefValue = format
+}
+
+// registerDates adds the -after and -before release date filters.
+func (q *queryFlags) registerDates(fs *flag.FlagSet) {
+	fs.StringVar(&q.after, "after", "", "Only include versions released on or after this date (YYYY-MM-DD)")
+	fs.StringVar(&q.before, "before", "", "Only include versions released before this date (YYYY-MM-DD)")
+}
+
+// registerProfile adds the -profile and -config flags; the selected profile, or the
+// default of the configuration, is applied to the versions load returns.
+func (q *queryFlags) registerProfile(fs *flag.FlagSet) {
+	q.withProfile = true
+	fs.StringVar(&q.profile, "profile", "", "Profile limiting output to what matters to a kind of program, e.g. server, cli, cgo or wasm (default: the profile set in the configuration file, if any; none for no profile)")
+	fs.StringVar(&q.config, "config", "", "Configuration file defining profiles (default: gover/config.yaml in the user configuration directory, if present)")
+}
+
+// registerOnlyUsed adds the -only-used flag, restricting the changes load returns to the
+// standard library packages the given code imports.
+func (q *queryFlags) registerOnlyUsed(fs *flag.FlagSet) {
+	fs.Var(&q.onlyUsed, "only-used", "Only show changes to the standard library packages imported by the Go files of this directory, or with /... under it, e.g. ./... (repeatable)")
+}
+
+// loadProfile returns the profile selected by -profile or, without it, the default of the
+// configuration file, or nil if there is none.
+func (q *queryFlags) loadProfile() (*gover.Profile, error) {
+	if q.selected != nil || q.profile == "none" {
+		return q.selected, nil
+	}
+	config, err := loadConfig(q.config)
+	if err != nil {
+		return nil, err
+	}
+	name := cmp.Or(q.profile, config.Profile)
+	if name == "" {
+		return nil, nil
+	}
+	p, err := config.Lookup(name)
+	if err != nil {
+		return nil, usageError("%v", err)
+	}
+	q.selected = &p
+	return q.selected, nil
+}
+
+// loadConfig reads the configuration file path or, if it is empty, the default one.
+func loadConfig(path string) (*gover.Config, error) {
+	if path == "" {
+		return gover.LoadDefaultConfig()
+	}
+	return gover.LoadConfig(path)
+}
+
+// load reads the dataset named by the -data flag, or the snapshot selected by -as-of,
+// applies the date and platform filters, the profile and -only-used, and parses the -type
+// flag.
+func (q *queryFlags) load() ([]gover.VersionData, []gover.ChangeType, error) {
+	types, err := q.changeTypes()
+	if err != nil {
+		return nil, nil, err
+	}
+	after, err := parseDate("after", q.after)
+	if err != nil {
+		return nil, nil, err
+	}
+	before, err := parseDate("before", q.before)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := q.loadData()
+	if err != nil {
+		return nil, nil, err
+	}
+	data = gover.FilterByPlatform(gover.FilterByDate(data, after, before), q.goos, q.goarch)
+	if q.withProfile {
+		p, err := q.loadProfile()
+		if err != nil {
+			return nil, nil, err
+		}
+		if p != nil {
+			data = p.Apply(data)
+		}
+	}
+	if len(q.onlyUsed) > 0 {
+		pkgs, err := gover.StdlibImports(data, q.onlyUsed...)
+		if err != nil {
+			return nil, nil, err
+		}
+		data = gover.FilterByPackages(data, pkgs...)
+	}
+	return data, types, nil
+}
+
+// loadData reads the dataset named by -data or, with -as-of, the newest snapshot of the
+// archive taken by the end of that day, and merges the notes of -overlay into it.
+func (q *queryFlags) loadData() ([]gover.VersionData, error) {
+	asOf, err := parseDate("as-of", q.asOf)
+	if err != nil {
+		return nil, err
+	}
+	var data []gover.VersionData
+	if asOf.IsZero() {
+		data, err = gover.LoadFile(q.dataFile)
+	} else {
+		var ds *gover.Dataset
+		if ds, err = gover.LoadArchivedDataset(q.archive, asOf.AddDate(0, 0, 1).Add(-time.Nanosecond)); err == nil {
+			data = ds.Versions
+		}
+	}
+	if err != nil || q.overlay == "" {
+		return data, err
+	}
+	overlay, err := gover.LoadOverlay(q.overlay)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range gover.ApplyOverlay(data, overlay) {
+		log.Printf("Warning: overlay note for %s matches nothing", n)
+	}
+	return data, nil
+}
+
+// parseDate parses the value of a date flag, returning the zero time if it is unset.
+func parseDate(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(gover.DateLayout, value)
+	if err != nil {
+		return time.Time{}, usageError("invalid -%s date %q: want YYYY-MM-DD", name, value)
+	}
+	return t, nil
+}
+
+// changeTypes parses the -type flag into normalized change types.
+func (q *queryFlags) changeTypes() ([]gover.ChangeType, error) {
+	var types []gover.ChangeType
+	for _, s := range strings.Split(q.types, ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		t, err := gover.ParseChangeType(s)
+		if err != nil {
+			return nil, exitError{exitUsage, err}
+		}
+		types = append(types, t)
+	}
+	return types, nil
+}
+
+func runGet(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("get", flag.ContinueOnError)
+	q.register(fs, true)
+	q.registerOnlyUsed(fs)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover get [-data file] [-type types] <version>")
+	}
+
+	data, types, err := q.load()
+	if err != nil {
+		return err
+	}
+	vd, ok := store.Load(data).ByVersion(fs.Arg(0))
+	if !ok {
+		return fmt.Errorf("version %s not found", fs.Arg(0))
+	}
+	if len(types) > 0 {
+		changes := vd.Changes
+		vd.Changes = []gover.ChangeCategory{}
+		if filtered := gover.FilterByType([]gover.VersionData{{Changes: changes}}, types...); len(filtered) > 0 {
+			vd.Changes = filtered[0].Changes
+		}
+	}
+	return q.print(vd)
+}
+
+func runDiff(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	q.register(fs, true)
+	q.registerProfile(fs)
+	q.registerOnlyUsed(fs)
+	minImpact := fs.String("impact", "", "Only show changes with at least this impact (additive|behavioral|breaking-ish)")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 2 {
+		return usageError("usage: gover diff [-data file] [-type types] [-impact level] <from> <to>")
+	}
+
+	data, types, err := q.load()
+	if err != nil {
+		return err
+	}
+	diff, err := gover.Diff(data, fs.Arg(0), fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	if *minImpact != "" {
+		switch *minImpact {
+		case gover.ImpactAdditive, gover.ImpactBehavioral, gover.ImpactBreaking:
+			diff = gover.FilterByImpact(diff, *minImpact)
+		default:
+			return usageError("unknown impact level %q", *minImpact)
+		}
+	}
+	return q.print(gover.FilterByType(diff, types...))
+}
+
+func runPackage(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("package", flag.ContinueOnError)
+	q.register(fs, true)
+	q.registerDates(fs)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover package [-data file] [-type types] <import path>")
+	}
+
+	data, types, err := q.load()
+	if err != nil {
+		return err
+	}
+	return q.print(gover.FilterByType(store.Load(data).ByPackage(fs.Arg(0)), types...))
+}
+
+func runSearch(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	q.register(fs, true)
+	q.registerProfile(fs)
+	q.registerOnlyUsed(fs)
+	q.registerDates(fs)
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 && q.after == "" && q.before == "" {
+		return usageError("usage: gover search [-data file] [-type types] [-after date] [-before date] <words...>")
+	}
+
+	data, types, err := q.load()
+	if err != nil {
+		return err
+	}
+	results := store.Load(data).Search(strings.Join(fs.Args(), " "))
+	if len(types) > 0 {
+		results = slices.DeleteFunc(results, func(r gover.SearchResult) bool {
+			return !slices.Contains(types, r.Type)
+		})
+	}
+	return q.print(results)
+}
+
+// print writes v, or the value -query selects from it, in the -format format. A string
+// selected by -query is written as plain text in JSON format, for use in scripts.
+func (q *queryFlags) print(v any) error {
+	if q.query != "" {
+		path, err := gover.ParseJSONPath(q.query)
+		if err != nil {
+			return usageError("invalid -query: %v", err)
+		}
+		value, err := path.Eval(v)
+		if err != nil {
+			return fmt.Errorf("-query %s: %w", q.query, err)
+		}
+		var s string
+		if q.format == "json" && json.Unmarshal(value, &s) == nil {
+			return writeOutput(q.output, func(w io.Writer) error {
+				_, err := fmt.Fprintln(w, s)
+				return err
+			})
+		}
+		v = value
+	}
+	return writeOutput(q.output, func(w io.Writer) error {
+		return gover.Encode(w, q.format, v)
+	})
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/paulstuart/gover"
+)
+
+// Redis keys used by serve mode.
+const (
+	redisDatasetKey = "gover:dataset"
+	redisUpdatedKey = "gover:updated" // when the published dataset was scraped, read without decoding it
+	redisLockKey    = "gover:refresh-lock"
+)
+
+// refreshSlack is how much earlier than a refresh interval a dataset published by this
+// replica may be due again, so that the ticks of the replica that scraped it are not
+// skipped for arriving a moment too soon.
+const refreshSlack = time.Minute
+
+// redisSource shares one dataset between serve replicas. Only the replica holding the
+// refresh lock scrapes go.dev, and only once the published dataset is a refresh interval
+// old; the others pick up its result when they poll.
+type redisSource struct {
+	client  *redis.Client
+	lockTTL time.Duration
+	refresh time.Duration
+}
+
+func newRedisSource(url string, refresh time.Duration) (*redisSource, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	// Hold the lock long enough for a full scrape, but never longer than a refresh interval.
+	lockTTL := 30 * time.Minute
+	if refresh > 0 {
+		lockTTL = min(lockTTL, refresh)
+	}
+	return &redisSource{client: redis.NewClient(opts), lockTTL: lockTTL, refresh: refresh}, nil
+}
+
+func (rs *redisSource) Close() error {
+	return rs.client.Close()
+}
+
+func (rs *redisSource) Fetch(ctx context.Context) ([]gover.VersionData, time.Time, error) {
+	b, err := rs.client.Get(ctx, redisDatasetKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, time.Time{}, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("reading dataset from Redis: %w", err)
+	}
+	return decodeShared(b)
+}
+
+func (rs *redisSource) Refresh(ctx context.Context) ([]gover.VersionData, time.Time, error) {
+	token := fmt.Sprint(time.Now().UnixNano())
+	ok, err := rs.client.SetNX(ctx, redisLockKey, token, rs.lockTTL).Result()
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("acquiring refresh lock: %w", err)
+	}
+	if !ok {
+		return nil, time.Time{}, nil
+	}
+	defer rs.unlock(token)
+
+	if fresh, err := rs.fresh(ctx); err != nil || fresh {
+		return nil, time.Time{}, err
+	}
+	// Date the dataset by the start of the scrape, so that the next tick of this replica
+	// finds it a refresh interval old however long the scrape took.
+	updated := time.Now()
+	data, err := newClient().Scrape(ctx)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	b, err := encodeShared(data, updated)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	_, err = rs.client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.Set(ctx, redisDatasetKey, b, 0)
+		p.Set(ctx, redisUpdatedKey, updated.Format(time.RFC3339Nano), 0)
+		return nil
+	})
+	if err != nil {
+		return nil, time.Time{}, fmt.Errorf("publishing dataset to Redis: %w", err)
+	}
+	return data, updated, nil
+}
+
+// fresh reports whether the published dataset was scraped less than a refresh interval
+// ago, by this replica or another, so that it need not be scraped again yet.
+func (rs *redisSource) fresh(ctx context.Context) (bool, error) {
+	if rs.refresh <= 0 {
+		return false, nil
+	}
+	s, err := rs.client.Get(ctx, redisUpdatedKey).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("reading dataset time from Redis: %w", err)
+	}
+	updated, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return false, nil // scrape over a malformed time
+	}
+	return time.Since(updated)+refreshSlack < rs.refresh, nil
+}
+
+// unlockScript releases the refresh lock only if it is still held by this replica.
+var unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+func (rs *redisSource) unlock(token string) {
+	unlockScript.Run(context.Background(), rs.client, []string{redisLockKey}, token)
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/paulstuart/gover"
+)
+
+func runReleases(args []string) error {
+	fs := flag.NewFlagSet("releases", flag.ContinueOnError)
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	format := fs.String("format", "json", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	pinned := fs.String("pinned", "", "Highlight this release, e.g. go1.21.3, in the timeline drawn by the mermaid and dot formats (ignored by the others)")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	releases, err := newClient().ReleaseHistory(context.Background())
+	if err != nil {
+		return err
+	}
+	var v any = releases
+	if *pinned != "" && (*format == "mermaid" || *format == "dot") {
+		v = gover.NewTimeline(releases, *pinned, time.Now())
+	}
+	return writeOutput(*output, func(w io.Writer) error {
+		return gover.Encode(w, *format, v)
+	})
+}
@@ -0,0 +1,299 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/paulstuart/gover"
+)
+
+func runScrape(args []string) error {
+	fs := flag.NewFlagSet("scrape", flag.ContinueOnError)
+	outputFile := fs.String("output", "go_version_data.json", "Output file path, or - for stdout")
+	format := fs.String("format", "json", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	boilerplate := fs.String("boilerplate", "mark", "What to do with boilerplate sections (keep|mark|drop)")
+	allowSections := fs.String("allow-sections", "", "Comma-separated category name patterns never treated as boilerplate")
+	denySections := fs.String("deny-sections", "", "Comma-separated category name patterns treated as boilerplate, in addition to the defaults")
+	rawHTML := fs.Bool("raw-html", false, "Store the source HTML of each section in the rawHTML field")
+	provenance := fs.Bool("provenance", false, "Record the page, CSS selector and time each category and symbol change was scraped from")
+	compact := fs.Bool("compact", false, "Drop the Overview and heading-only categories, and merge duplicated and fragmented ones")
+	previous := fs.String("previous", "", "Earlier dataset whose unchanged versions are reused (default: the existing -output file)")
+	force := fs.Bool("force", false, "Re-parse every version, even if its page is unchanged since the previous scrape")
+	checkpointFile := fs.String("checkpoint", "", "File recording the versions scraped so far, removed once done (default: <output>.checkpoint, none when writing to stdout)")
+	dryRun := fs.Bool("dry-run", false, "Estimate the requests, cache hit ratio and duration of the scrape instead of scraping")
+	resume := fs.Bool("resume", false, "Continue an interrupted scrape from its checkpoint instead of fetching every page again")
+	strict := fs.Bool("strict", false, "Fail if any version cannot be fully scraped, instead of recording its errors")
+	announcements := fs.Bool("announcements", false, "Link each version to its Go blog announcement post")
+	highlights := fs.Bool("highlights", false, "Also store the opening paragraph of each announcement post (implies -announcements)")
+	contributions := fs.Bool("contributions", false, "Collect resolved issue and contributor counts per release (implies -announcements)")
+	milestoneIssues := fs.Bool("milestone-issues", false, "List the issues closed in each release's GitHub milestone (needs GITHUB_TOKEN)")
+	githubAPI := fs.String("github-api", "https://api.github.com", "GitHub REST API release dates, milestones and their issues are fetched from")
+	apiExceptions := fs.Bool("api-exceptions", false, "Record changes made under exceptions to the compatibility promise, from the Go repository's api files")
+	gerrit := fs.Bool("gerrit", false, "Fetch the subject, status and touched files of each CL linked from the release notes from Gerrit")
+	drafts := fs.Bool("drafts", false, "Add the draft release notes of the next release from tip.golang.org, if any")
+	golangOrg := fs.Bool("golang-org", false, "Fall back to the release notes golang.org served before go.dev, from the Wayback Machine, for versions go.dev lacks")
+	baseURL := fs.String("base-url", "https://go.dev", "Site to scrape release notes from, or a file URL of a directory written by gover mirror")
+	cacheDir := fs.String("cache-dir", "", "Directory caching fetched pages between runs")
+	cacheTTL := fs.Duration("cache-ttl", 24*time.Hour, "How long cached pages are reused (0 keeps them forever)")
+	permFlag := fs.String("perm", "", "Permissions of the output file, in octal (default: those of the file replaced, or 0644)")
+	backup := fs.Bool("backup", false, "Keep the file being replaced as <output>.bak")
+	fast := fs.Bool("fast", false, "Preset: fetch 4 pages at once, 250ms apart, retrying failed requests once")
+	defaultPreset := fs.Bool("default", false, "Preset: fetch 2 pages at once, 1s apart, retrying failed requests twice (the default settings)")
+	polite := fs.Bool("polite", false, "Preset: fetch 1 page at a time, honor robots.txt, wait 1-3s between requests, retry failed ones 3 times and stop after 500 requests")
+	parallelism := fs.Int("parallelism", 0, "Release notes pages to fetch at once")
+	pageDelay := fs.Duration("page-delay", 0, "Wait this long between starting to fetch one release notes page and the next")
+	retries := fs.Int("retries", 0, "Times to retry a request failing with a network error or a 429 or 5xx status")
+	robots := fs.Bool("robots", false, "Honor robots.txt")
+	maxRequests := fs.Int("max-requests", 0, "Stop after this many requests, not counting cached pages (0 for no limit)")
+	delay := fs.Duration("delay", 0, "Wait this long between requests")
+	jitter := fs.Duration("jitter", 0, "Wait up to this much longer between requests, at random")
+	selectorsFile := fs.String("selectors", "", "YAML file overriding the selectors used to parse go.dev pages")
+	debugDump := fs.String("debug-dump", "", "Save fetched pages, selector matches and parsed data per version in this directory")
+	bench := fs.Bool("bench", false, "Report pages/sec, bytes processed and allocations once the scrape is done")
+	overlayFile := fs.String("overlay", "", "YAML file of local notes to merge into the changes they concern")
+	var allowDomains listFlag
+	fs.Var(&allowDomains, "allow-domain", "Host, besides that of -base-url, that release notes may be fetched from or redirect to, such as web.archive.org (repeatable, * for any)")
+	var enrichers listFlag
+	fs.Var(&enrichers, "enricher", "Command, with space-separated arguments, reading the dataset's versions as JSON on stdin and writing them back enriched on stdout (repeatable, run in order)")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 0 {
+		return usageError("unexpected arguments %q; usage: gover [scrape] [flags]", fs.Args())
+	}
+
+	perm, err := parsePerm(*permFlag)
+	if err != nil {
+		return err
+	}
+
+	mode, err := gover.ParseBoilerplateMode(*boilerplate)
+	if err != nil {
+		return exitError{exitUsage, err}
+	}
+	filter := gover.SectionFilter{
+		Allow: splitList(*allowSections),
+		Deny:  append(slices.Clone(gover.DefaultSectionDeny), splitList(*denySections)...),
+	}
+
+	opts := []gover.Option{
+		gover.WithBoilerplate(mode),
+		gover.WithSectionFilter(filter),
+		gover.WithRawHTML(*rawHTML),
+		gover.WithCompaction(*compact),
+		gover.WithProvenance(*provenance),
+		gover.WithBestEffort(!*strict),
+		gover.WithAnnouncements(*announcements),
+		gover.WithHighlights(*highlights),
+		gover.WithContributions(*contributions),
+		gover.WithAPIExceptions(*apiExceptions),
+		gover.WithGerritCLs(*gerrit),
+		gover.WithMilestoneIssues(*milestoneIssues),
+		gover.WithGitHubToken(os.Getenv("GITHUB_TOKEN")),
+		gover.WithGitHubAPIURL(*githubAPI),
+		gover.WithBaseURL(*baseURL),
+		gover.WithAllowedDomains(allowDomains...),
+		gover.WithGolangOrgArchive(*golangOrg),
+		gover.WithDrafts(*drafts),
+	}
+	// Explicit politeness flags override the preset.
+	var preset gover.Preset
+	for name, set := range map[string]bool{"fast": *fast, "default": *defaultPreset, "polite": *polite} {
+		if !set {
+			continue
+		}
+		if preset.Name != "" {
+			return usageError("-fast, -default and -polite are exclusive")
+		}
+		if preset, err = gover.ParsePreset(name); err != nil {
+			return err
+		}
+		opts = append(opts, gover.WithPreset(preset))
+	}
+	delayFlags := false
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "robots":
+			opts = append(opts, gover.WithRobotsTxt(*robots))
+		case "max-requests":
+			opts = append(opts, gover.WithMaxRequests(*maxRequests))
+		case "retries":
+			opts = append(opts, gover.WithRetries(*retries))
+		case "parallelism":
+			opts = append(opts, gover.WithParallelism(*parallelism))
+		case "page-delay":
+			opts = append(opts, gover.WithPageDelay(*pageDelay))
+		case "delay", "jitter":
+			delayFlags = true
+		}
+	})
+	if delayFlags {
+		// Either flag keeps the preset's value of the other, and -delay 0 removes its delay.
+		d, j := preset.Delay, preset.Jitter
+		fs.Visit(func(f *flag.Flag) {
+			switch f.Name {
+			case "delay":
+				d = *delay
+			case "jitter":
+				j = *jitter
+			}
+		})
+		opts = append(opts, gover.WithDelay(d, j))
+	}
+	if *selectorsFile != "" {
+		sel, err := gover.LoadSelectors(*selectorsFile)
+		if err != nil {
+			return exitError{exitUsage, err}
+		}
+		opts = append(opts, gover.WithSelectors(sel))
+	}
+	if *debugDump != "" {
+		opts = append(opts, gover.WithDebugDump(*debugDump))
+	}
+	if *cacheDir != "" {
+		cache, err := gover.NewDirCache(*cacheDir, *cacheTTL)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, gover.WithCache(cache))
+	}
+	if *overlayFile != "" {
+		overlay, err := gover.LoadOverlay(*overlayFile)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, gover.WithOverlay(overlay))
+	}
+	if *checkpointFile == "" && *outputFile != "-" {
+		*checkpointFile = *outputFile + ".checkpoint"
+	}
+	if *checkpointFile != "" {
+		opts = append(opts, gover.WithCheckpoint(*checkpointFile, *resume))
+	} else if *resume {
+		return usageError("-resume needs -checkpoint when writing to stdout")
+	}
+	for _, e := range enrichers {
+		args := strings.Fields(e)
+		if len(args) == 0 {
+			return usageError("-enricher must name a command")
+		}
+		opts = append(opts, gover.WithTransformers(gover.ExecStage(args[0], args[1:]...)))
+	}
+	if !*force {
+		if prev, err := loadPrevious(*previous, *outputFile); err != nil {
+			return err
+		} else if prev != nil {
+			opts = append(opts, gover.WithPrevious(prev))
+		}
+	}
+
+	if *dryRun {
+		return estimate(opts)
+	}
+
+	var metrics gover.ScrapeMetrics
+	if *bench {
+		opts = append(opts, gover.WithMetrics(&metrics))
+	}
+
+	dataset, err := newClient(opts...).ScrapeDataset(context.Background())
+	if err != nil {
+		return fmt.Errorf("scraping: %w", err)
+	}
+	if *bench {
+		logMetrics(metrics)
+	}
+	if c := dataset.Counters; c != nil && (c.PagesSkipped > 0 || len(c.EmptySelectors) > 0 || c.UnresolvedSymbols > 0) {
+		log.Printf("Soft failures: %d pages skipped, selectors matching nothing %v, %d unresolved symbols", c.PagesSkipped, c.EmptySelectors, c.UnresolvedSymbols)
+	}
+
+	var buf bytes.Buffer
+	if err := gover.Encode(&buf, *format, dataset); err != nil {
+		return fmt.Errorf("encoding %s: %w", *format, err)
+	}
+
+	err = writeFile(*outputFile, perm, *backup, func(w io.Writer) error {
+		_, err := w.Write(buf.Bytes())
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("writing %s to file %s: %w", *format, *outputFile, err)
+	}
+
+	log.Printf("Successfully wrote scraped data to %s", *outputFile)
+	if n := len(dataset.Summary.Partial) + len(dataset.Summary.Missing); n > 0 {
+		return exitError{exitPartial, fmt.Errorf("%d versions are incomplete: partial %v, missing %v", n, dataset.Summary.Partial, dataset.Summary.Missing)}
+	}
+	return nil
+}
+
+// estimate prints what a scrape with opts would take.
+func estimate(opts []gover.Option) error {
+	e, err := newClient(opts...).Estimate(context.Background())
+	if err != nil {
+		return fmt.Errorf("estimating: %w", err)
+	}
+	if err := gover.Encode(os.Stdout, "table", e); err != nil {
+		return err
+	}
+	if e.ExceedsBudget() {
+		log.Printf("Warning: the scrape would exceed -max-requests %d; use -cache-dir, -resume or a higher limit", e.MaxRequests)
+	}
+	return nil
+}
+
+// logMetrics reports the performance of a scrape.
+func logMetrics(m gover.ScrapeMetrics) {
+	log.Printf("Parsed %d pages in %s: %.2f pages/sec, %s parsing", m.Pages, m.Duration.Round(time.Millisecond), m.PagesPerSecond(), m.ParseTime.Round(time.Microsecond))
+	log.Printf("Fetched %d pages, %d bytes", m.Requests, m.Bytes)
+	log.Printf("Allocated %d bytes in %d allocations", m.AllocBytes, m.Allocs)
+	if m.Pages > 0 {
+		log.Printf("Per page: %d bytes fetched, %d bytes allocated, %d allocations", m.Bytes/int64(m.Pages), m.AllocBytes/uint64(m.Pages), m.Allocs/uint64(m.Pages))
+	}
+}
+
+// loadPrevious loads the dataset named by -previous or, if that is unset, the existing
+// output file unless writing to stdout. A missing output file is not an error; there is simply nothing to reuse.
+func loadPrevious(previous, outputFile string) ([]gover.VersionData, error) {
+	path := previous
+	if path == "" {
+		if outputFile == "-" {
+			return nil, nil
+		}
+		if _, err := os.Stat(outputFile); err != nil {
+			return nil, nil
+		}
+		path = outputFile
+	}
+	data, err := gover.LoadFile(path)
+	if err != nil && previous == "" {
+		log.Printf("Warning: not reusing %s: %v", path, err)
+		return nil, nil
+	}
+	return data, err
+}
+
+// listFlag collects the values of a flag given more than once.
+type listFlag []string
+
+func (l *listFlag) String() string     { return strings.Join(*l, ", ") }
+func (l *listFlag) Set(s string) error { *l = append(*l, s); return nil }
+
+// splitList splits a comma-separated flag value, dropping empty elements.
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
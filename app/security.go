@@ -0,0 +1,34 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runSecurity(args []string) error {
+	fs := flag.NewFlagSet("security", flag.ContinueOnError)
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	format := fs.String("format", "table", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover security [-format f] <version>")
+	}
+
+	releases, err := newClient().ReleaseHistory(context.Background())
+	if err != nil {
+		return err
+	}
+	posture, err := gover.SummarizeSecurity(releases, fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	return writeOutput(*output, func(w io.Writer) error {
+		return gover.Encode(w, *format, posture)
+	})
+}
@@ -0,0 +1,294 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/paulstuart/gover"
+	"github.com/paulstuart/gover/store"
+)
+
+// datasetSource supplies the dataset served by gover serve.
+type datasetSource interface {
+	// Fetch returns the most recently published dataset and when it was produced,
+	// or a nil dataset if none has been published yet.
+	Fetch(ctx context.Context) ([]gover.VersionData, time.Time, error)
+	// Refresh scrapes go.dev and publishes the result. It returns a nil dataset
+	// without error if another replica is already refreshing, or has published a
+	// dataset recently enough.
+	Refresh(ctx context.Context) ([]gover.VersionData, time.Time, error)
+}
+
+// fileSource serves a dataset file and re-scrapes into memory.
+type fileSource struct {
+	path string
+}
+
+func (f fileSource) Fetch(ctx context.Context) ([]gover.VersionData, time.Time, error) {
+	info, err := os.Stat(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, time.Time{}, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	data, err := gover.LoadFile(f.path)
+	return data, info.ModTime(), err
+}
+
+func (f fileSource) Refresh(ctx context.Context) ([]gover.VersionData, time.Time, error) {
+	data, err := newClient().Scrape(ctx)
+	return data, time.Now(), err
+}
+
+// server answers dataset queries over HTTP.
+type server struct {
+	mu      sync.RWMutex
+	store   *store.Store
+	updated time.Time
+}
+
+func runServe(args []string) error {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := flags.String("addr", ":8080", "Address to listen on")
+	dataFile := flags.String("data", "go_version_data.json", "Dataset JSON file to serve until the first refresh")
+	refresh := flags.Duration("refresh", 24*time.Hour, "How often to re-scrape go.dev (0 disables refreshing)")
+	redisURL := flags.String("redis", "", "Redis URL (redis://host:6379/0) used to share one dataset between replicas")
+	poll := flags.Duration("poll", time.Minute, "How often to check for a dataset published by another replica or process")
+	if err := flags.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	var src datasetSource = fileSource{path: *dataFile}
+	if *redisURL != "" {
+		rs, err := newRedisSource(*redisURL, *refresh)
+		if err != nil {
+			return err
+		}
+		defer rs.Close()
+		src = rs
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	s := &server{store: store.Load(nil)}
+	if err := s.fetch(ctx, src); err != nil {
+		return err
+	}
+	if len(s.current().Versions()) == 0 {
+		log.Printf("No dataset available, scraping go.dev")
+		s.refresh(ctx, src)
+	}
+	go s.maintain(ctx, src, *refresh, *poll)
+
+	httpServer := &http.Server{Addr: *addr, Handler: s.routes()}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdown)
+	}()
+
+	log.Printf("Serving on %s", *addr)
+	if err := httpServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// maintain periodically refreshes the dataset and picks up datasets published elsewhere.
+func (s *server) maintain(ctx context.Context, src datasetSource, refresh, poll time.Duration) {
+	var refreshC, pollC <-chan time.Time
+	if refresh > 0 {
+		t := time.NewTicker(refresh)
+		defer t.Stop()
+		refreshC = t.C
+	}
+	if poll > 0 {
+		t := time.NewTicker(poll)
+		defer t.Stop()
+		pollC = t.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-refreshC:
+			s.refresh(ctx, src)
+		case <-pollC:
+			if err := s.fetch(ctx, src); err != nil {
+				log.Printf("Error checking for a newer dataset: %v", err)
+			}
+		}
+	}
+}
+
+// fetch installs the source's published dataset if it is newer than the one being served.
+func (s *server) fetch(ctx context.Context, src datasetSource) error {
+	data, updated, err := src.Fetch(ctx)
+	if err != nil || data == nil {
+		return err
+	}
+	s.set(data, updated)
+	return nil
+}
+
+// refresh re-scrapes through the source, keeping the current dataset on failure.
+func (s *server) refresh(ctx context.Context, src datasetSource) {
+	data, updated, err := src.Refresh(ctx)
+	switch {
+	case err != nil:
+		log.Printf("Error refreshing dataset: %v", err)
+	case data == nil:
+		log.Printf("Another replica is refreshing the dataset, or has recently")
+	default:
+		s.set(data, updated)
+	}
+}
+
+func (s *server) set(data []gover.VersionData, updated time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !updated.After(s.updated) {
+		return
+	}
+	s.store, s.updated = store.Load(data), updated
+	log.Printf("Serving dataset of %d versions from %s", len(data), updated.Format(time.RFC3339))
+}
+
+// current returns the store of the dataset being served, or nil before one is available.
+func (s *server) current() *store.Store {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.store
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		writeResult(w, r, map[string]any{"versions": len(s.store.Versions()), "updated": s.updated}, nil)
+	})
+	mux.HandleFunc("GET /versions", func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, r, s.current().Versions(), nil)
+	})
+	mux.HandleFunc("GET /versions/{version}", func(w http.ResponseWriter, r *http.Request) {
+		vd, ok := s.current().ByVersion(r.PathValue("version"))
+		if !ok {
+			http.Error(w, "version not found", http.StatusNotFound)
+			return
+		}
+		writeResult(w, r, vd, nil)
+	})
+	mux.HandleFunc("GET /diff", func(w http.ResponseWriter, r *http.Request) {
+		diff, err := gover.Diff(s.current().Versions(), r.FormValue("from"), r.FormValue("to"))
+		if err == nil {
+			diff, err = filterTypes(diff, r)
+		}
+		writeResult(w, r, diff, err)
+	})
+	mux.HandleFunc("GET /packages/{path...}", func(w http.ResponseWriter, r *http.Request) {
+		data, err := filterTypes(s.current().ByPackage(r.PathValue("path")), r)
+		writeResult(w, r, data, err)
+	})
+	mux.HandleFunc("GET /search", func(w http.ResponseWriter, r *http.Request) {
+		after, err := parseDate("after", r.FormValue("after"))
+		if err != nil {
+			writeResult(w, r, nil, err)
+			return
+		}
+		before, err := parseDate("before", r.FormValue("before"))
+		if err != nil {
+			writeResult(w, r, nil, err)
+			return
+		}
+		st := s.current()
+		if after.IsZero() && before.IsZero() && r.FormValue("type") == "" {
+			writeResult(w, r, st.Search(r.FormValue("q")), nil)
+			return
+		}
+		// Filtered searches scan the filtered dataset instead of the index.
+		data, err := filterTypes(gover.FilterByDate(st.Versions(), after, before), r)
+		writeResult(w, r, gover.Search(data, r.FormValue("q")), err)
+	})
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		versions, updated := len(s.store.Versions()), s.updated
+		s.mu.RUnlock()
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		gover.ReadCounters().WritePrometheus(w)
+		fmt.Fprintf(w, "# HELP gover_dataset_versions Versions in the dataset being served.\n# TYPE gover_dataset_versions gauge\ngover_dataset_versions %d\n", versions)
+		fmt.Fprintf(w, "# HELP gover_dataset_updated_seconds When the dataset being served was produced, as a Unix time.\n# TYPE gover_dataset_updated_seconds gauge\ngover_dataset_updated_seconds %d\n", updated.Unix())
+	})
+	mux.HandleFunc("GET /stats", func(w http.ResponseWriter, r *http.Request) {
+		writeResult(w, r, gover.ComputeStats(s.current().Versions()), nil)
+	})
+	return mux
+}
+
+// filterTypes applies the request's comma-separated "type" parameter.
+func filterTypes(data []gover.VersionData, r *http.Request) ([]gover.VersionData, error) {
+	var types []gover.ChangeType
+	for _, s := range strings.Split(r.FormValue("type"), ",") {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		t, err := gover.ParseChangeType(s)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return gover.FilterByType(data, types...), nil
+}
+
+// writeResult writes v in the format named by the "format" parameter (JSON by default),
+// or err as a 400 response.
+func writeResult(w http.ResponseWriter, r *http.Request, v any, err error) {
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format := r.FormValue("format")
+	if format == "" {
+		format = "json"
+	}
+	if format == "json" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	if err := gover.Encode(w, format, v); err != nil {
+		http.Error(w, fmt.Sprintf("encoding response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// sharedDataset is the value stored in Redis: the dataset and when it was scraped.
+type sharedDataset struct {
+	Updated  time.Time           `json:"updated"`
+	Versions []gover.VersionData `json:"versions"`
+}
+
+// encodeShared and decodeShared convert a sharedDataset to and from its stored form.
+func encodeShared(data []gover.VersionData, updated time.Time) ([]byte, error) {
+	return json.Marshal(sharedDataset{Updated: updated, Versions: data})
+}
+
+func decodeShared(b []byte) ([]gover.VersionData, time.Time, error) {
+	var sd sharedDataset
+	if err := json.Unmarshal(b, &sd); err != nil {
+		return nil, time.Time{}, fmt.Errorf("decoding shared dataset: %w", err)
+	}
+	return sd.Versions, sd.Updated, nil
+}
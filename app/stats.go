@@ -0,0 +1,46 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/paulstuart/gover"
+)
+
+func runStats(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	q.register(fs, false)
+	withAPI := fs.Bool("api", false, "Include exported API growth per release, computed from the Go repository's api/go1.N.txt files")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	stats := gover.ComputeStats(data)
+
+	if *withAPI {
+		// The totals need the original API, even if the dataset predates go1 coverage.
+		versions := []string{"go1"}
+		for _, vd := range data {
+			if vd.Version != "go1" {
+				versions = append(versions, vd.Version)
+			}
+		}
+		c := newClient()
+		features := make(map[string][]gover.APIFeature, len(versions))
+		for _, v := range versions {
+			f, err := c.APIFeatures(context.Background(), v)
+			if err != nil {
+				return fmt.Errorf("fetching api file for %s: %w", v, err)
+			}
+			features[v] = f
+		}
+		stats.API = gover.ComputeAPIGrowth(versions, features)
+	}
+	return q.print(stats)
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	"flag"
+
+	"github.com/paulstuart/gover"
+)
+
+func runSymbol(args []string) error {
+	var q queryFlags
+	fs := flag.NewFlagSet("symbol", flag.ContinueOnError)
+	q.register(fs, false)
+	deprecations := fs.String("deprecations", "", "Deprecation dataset from gover deprecations, for deprecations the release notes do not mention")
+	if err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover symbol [-data file] [-deprecations file] <symbol>")
+	}
+
+	data, _, err := q.load()
+	if err != nil {
+		return err
+	}
+	var deps []gover.Deprecation
+	if *deprecations != "" {
+		if deps, err = gover.LoadDeprecations(*deprecations); err != nil {
+			return err
+		}
+	}
+	h, err := newClient().SymbolHistory(context.Background(), fs.Arg(0), data, deps)
+	if err != nil {
+		return err
+	}
+	return q.print(h)
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runToolchains(args []string) error {
+	fs := flag.NewFlagSet("toolchains", flag.ContinueOnError)
+	goos := fs.String("goos", runtime.GOOS, "Operating system of the toolchains")
+	goarch := fs.String("goarch", runtime.GOARCH, "Architecture of the toolchains")
+	kind := fs.String("kind", "archive", "Kind of file (archive|installer|source)")
+	versions := fs.String("versions", "", "Only list versions matching this constraint, e.g. \">=1.21\"")
+	unstable := fs.Bool("unstable", false, "Also list pre-releases")
+	latest := fs.Bool("latest", false, "Only list the newest patch release of each minor version")
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	format := fs.String("format", "json", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 0 {
+		return usageError("usage: gover toolchains [-goos os] [-goarch arch] [-kind kind] [-versions constraint] [-latest]")
+	}
+	var constraint gover.Constraint
+	if *versions != "" {
+		var err error
+		if constraint, err = gover.ParseConstraint(*versions); err != nil {
+			return usageError("invalid -versions: %v", err)
+		}
+	}
+
+	releases, err := newClient().Downloads(context.Background())
+	if err != nil {
+		return err
+	}
+	releases = slices.DeleteFunc(releases, func(r gover.DownloadRelease) bool {
+		return !r.Stable && !*unstable || *versions != "" && !constraint.Match(r.Version)
+	})
+	if *latest {
+		var all []string
+		for _, r := range releases {
+			all = append(all, r.Version)
+		}
+		keep := gover.LatestPatches(all)
+		releases = slices.DeleteFunc(releases, func(r gover.DownloadRelease) bool {
+			return !slices.Contains(keep, r.Version)
+		})
+	}
+	files := gover.PlatformToolchains(releases, *goos, *goarch, *kind)
+	if len(files) == 0 {
+		return usageError("no %s downloads for %s/%s", *kind, *goos, *goarch)
+	}
+	return writeOutput(*output, func(w io.Writer) error {
+		return gover.Encode(w, *format, files)
+	})
+}
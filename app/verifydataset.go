@@ -0,0 +1,45 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/paulstuart/gover"
+)
+
+func runVerifyDataset(args []string) error {
+	fs := flag.NewFlagSet("verify-dataset", flag.ContinueOnError)
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	format := fs.String("format", "table", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 1 {
+		return usageError("usage: gover verify-dataset [-format f] <file>")
+	}
+
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("failed to read dataset: %w", err)
+	}
+	problems, err := gover.VerifyDataset(data, time.Now())
+	if err != nil {
+		return err
+	}
+	errs := problems.Errors()
+	log.Printf("Verified %s: %d error(s), %d warning(s)", fs.Arg(0), errs, len(problems)-errs)
+	if err := writeOutput(*output, func(w io.Writer) error {
+		return gover.Encode(w, *format, problems)
+	}); err != nil {
+		return err
+	}
+	if errs > 0 {
+		return violationError("%d problem(s) in %s", errs, fs.Arg(0))
+	}
+	return nil
+}
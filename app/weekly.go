@@ -0,0 +1,30 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"io"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runWeekly(args []string) error {
+	fs := flag.NewFlagSet("weekly", flag.ContinueOnError)
+	output := fs.String("output", "-", "Output file path, or - for stdout")
+	format := fs.String("format", "json", "Output format ("+strings.Join(gover.Formats(), "|")+")")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+	if fs.NArg() != 0 {
+		return usageError("usage: gover weekly [-format format] [-output file]")
+	}
+
+	snapshots, err := newClient().WeeklySnapshots(context.Background())
+	if err != nil {
+		return err
+	}
+	return writeOutput(*output, func(w io.Writer) error {
+		return gover.Encode(w, *format, snapshots)
+	})
+}
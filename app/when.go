@@ -0,0 +1,47 @@
+package app
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+func runWhen(args []string) error {
+	fs := flag.NewFlagSet("when", flag.ContinueOnError)
+	dataFile := fs.String("data", "go_version_data.json", "Dataset JSON file path")
+	if err := fs.Parse(args); err != nil {
+		return flagError(err)
+	}
+
+	if fs.NArg() != 1 {
+		return usageError("usage: gover when [-data file] <symbol>")
+	}
+	query := fs.Arg(0)
+
+	data, err := gover.LoadFile(*dataFile)
+	if err != nil {
+		return err
+	}
+
+	matches, suggestions := gover.LookupSymbol(data, query)
+	if len(matches) == 0 {
+		if len(suggestions) > 0 {
+			fmt.Printf("No symbol matching %q. Did you mean:\n", query)
+			for _, s := range suggestions {
+				fmt.Printf("  %s\n", s)
+			}
+		}
+		return fmt.Errorf("no symbol matching %q", query)
+	}
+
+	for _, m := range matches {
+		line := fmt.Sprintf("%s: %s in %s", m.Change.Symbol, m.Change.Type, m.Version)
+		if desc := strings.TrimSpace(m.Change.Description); desc != "" {
+			line += " - " + desc
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,238 @@
+package gover
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// archiveIndexFile is the name of the index of an archive directory.
+const archiveIndexFile = "index.json"
+
+// snapshotLayout names snapshot files by the time their dataset was generated, in UTC.
+const snapshotLayout = "2006-01-02T150405Z"
+
+// Snapshot describes a dataset stored in an archive by ArchiveDataset.
+type Snapshot struct {
+	File        string    `json:"file"` // relative to the archive directory
+	GeneratedAt time.Time `json:"generatedAt"`
+	Versions    int       `json:"versions"`
+	Latest      string    `json:"latest,omitempty"`  // the newest version in the dataset
+	Changed     []string  `json:"changed,omitempty"` // versions added or edited since the previous snapshot
+}
+
+// ArchiveIndex lists the snapshots of an archive, oldest first.
+type ArchiveIndex struct {
+	Snapshots []Snapshot `json:"snapshots"`
+}
+
+// ArchiveDataset stores ds in the archive directory dir as a new snapshot named after the
+// time it was generated, and adds it to the index of the archive. Snapshots are written
+// read-only and never replaced, so an archive records what every scrape saw: archiving two
+// datasets generated at the same second fails.
+func ArchiveDataset(dir string, ds *Dataset) (Snapshot, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to create archive: %w", err)
+	}
+	if ds.GeneratedAt.IsZero() {
+		ds.GeneratedAt = time.Now().UTC()
+	}
+	name := ds.GeneratedAt.UTC().Format(snapshotLayout) + ".json"
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, ds); err != nil {
+		return Snapshot{}, err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o444)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return Snapshot{}, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+
+	return addToIndex(dir, name, ds)
+}
+
+// addToIndex adds the snapshot name of ds, just stored in the archive directory dir, to
+// the index of the archive, decoding only the snapshot before it, so that archiving costs
+// the same however many snapshots there are. The whole archive is indexed again, see
+// IndexArchive, if the index is missing or does not list every other snapshot, or if ds
+// is not newer than all of them.
+func addToIndex(dir, name string, ds *Dataset) (Snapshot, error) {
+	names, err := snapshotFiles(dir)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	index, err := readArchiveIndex(dir)
+	n := 0
+	if err == nil {
+		n = len(index.Snapshots)
+	}
+	if err != nil || n != len(names)-1 || n > 0 && !ds.GeneratedAt.After(index.Snapshots[n-1].GeneratedAt) {
+		if index, err = IndexArchive(dir); err != nil {
+			return Snapshot{}, err
+		}
+		i := slices.IndexFunc(index.Snapshots, func(s Snapshot) bool { return s.File == name })
+		return index.Snapshots[i], nil
+	}
+
+	var previous map[string]string
+	if n > 0 {
+		prev, err := LoadDataset(filepath.Join(dir, index.Snapshots[n-1].File))
+		if err != nil {
+			return Snapshot{}, err
+		}
+		previous = fingerprints(prev)
+	}
+	s := newSnapshot(name, ds, previous)
+	index.Snapshots = append(index.Snapshots, s)
+	if err := writeArchiveIndex(dir, index); err != nil {
+		return Snapshot{}, err
+	}
+	return s, nil
+}
+
+// IndexArchive reads every snapshot in the archive directory dir and writes its index,
+// recording which versions each snapshot added or changed since the one before it.
+// ArchiveDataset updates the index itself; this rebuilds it, such as after snapshots
+// were copied into the archive.
+func IndexArchive(dir string) (*ArchiveIndex, error) {
+	names, err := snapshotFiles(dir)
+	if err != nil {
+		return nil, err
+	}
+	datasets := make(map[string]*Dataset, len(names))
+	for _, name := range names {
+		if datasets[name], err = LoadDataset(filepath.Join(dir, name)); err != nil {
+			return nil, err
+		}
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(datasets[a].GeneratedAt.Compare(datasets[b].GeneratedAt), cmp.Compare(a, b))
+	})
+
+	index := &ArchiveIndex{Snapshots: []Snapshot{}}
+	var previous map[string]string // the fingerprints of the versions of the previous snapshot
+	for _, name := range names {
+		index.Snapshots = append(index.Snapshots, newSnapshot(name, datasets[name], previous))
+		previous = fingerprints(datasets[name])
+	}
+	if err := writeArchiveIndex(dir, index); err != nil {
+		return nil, err
+	}
+	return index, nil
+}
+
+// snapshotFiles returns the names of the snapshots in the archive directory dir.
+func snapshotFiles(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range paths {
+		base := filepath.Base(p)
+		if _, err := time.Parse(snapshotLayout, strings.TrimSuffix(base, ".json")); err == nil {
+			names = append(names, base) // not the index
+		}
+	}
+	return names, nil
+}
+
+// newSnapshot describes the snapshot name of ds, given the fingerprints of the versions of
+// the snapshot before it.
+func newSnapshot(name string, ds *Dataset, previous map[string]string) Snapshot {
+	s := Snapshot{File: name, GeneratedAt: ds.GeneratedAt, Versions: len(ds.Versions)}
+	for _, vd := range ds.Versions {
+		if CompareVersions(vd.Version, s.Latest) > 0 {
+			s.Latest = vd.Version
+		}
+		if fp, ok := previous[vd.Version]; !ok || fp == "" || fp != vd.Fingerprint {
+			s.Changed = append(s.Changed, vd.Version)
+		}
+	}
+	return s
+}
+
+// fingerprints returns the fingerprints of the versions of ds, keyed by version.
+func fingerprints(ds *Dataset) map[string]string {
+	m := make(map[string]string, len(ds.Versions))
+	for _, vd := range ds.Versions {
+		m[vd.Version] = vd.Fingerprint
+	}
+	return m
+}
+
+// writeArchiveIndex writes the index of the archive directory dir.
+func writeArchiveIndex(dir string, index *ArchiveIndex) error {
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, index); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, archiveIndexFile), buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write archive index: %w", err)
+	}
+	return nil
+}
+
+// LoadArchiveIndex reads the index of the archive directory dir, generating it if it is missing.
+func LoadArchiveIndex(dir string) (*ArchiveIndex, error) {
+	index, err := readArchiveIndex(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		return IndexArchive(dir)
+	}
+	return index, err
+}
+
+// readArchiveIndex reads the index of the archive directory dir, failing with an error
+// matching fs.ErrNotExist if there is none.
+func readArchiveIndex(dir string) (*ArchiveIndex, error) {
+	b, err := os.ReadFile(filepath.Join(dir, archiveIndexFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive index: %w", err)
+	}
+	var index ArchiveIndex
+	if err := json.Unmarshal(b, &index); err != nil {
+		return nil, parseError(fmt.Errorf("failed to decode archive index: %w", err))
+	}
+	return &index, nil
+}
+
+// AsOf returns the newest snapshot generated at or before t.
+func (idx *ArchiveIndex) AsOf(t time.Time) (Snapshot, bool) {
+	for _, s := range slices.Backward(idx.Snapshots) {
+		if !s.GeneratedAt.After(t) {
+			return s, true
+		}
+	}
+	return Snapshot{}, false
+}
+
+// LoadArchivedDataset returns the dataset of the archive directory dir as it was at t: that
+// of the newest snapshot generated at or before t.
+func LoadArchivedDataset(dir string, t time.Time) (*Dataset, error) {
+	index, err := LoadArchiveIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := index.AsOf(t)
+	if !ok {
+		return nil, fmt.Errorf("no snapshot in %s as of %s", dir, t.Format(time.RFC3339))
+	}
+	return LoadDataset(filepath.Join(dir, s.File))
+}
@@ -0,0 +1,198 @@
+package gover
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Sources of pinned Go versions found by ScanPins.
+const (
+	PinDockerfile = "dockerfile"
+	PinWorkflow   = "workflow"
+	PinAsdf       = "asdf"
+	PinMise       = "mise"
+)
+
+// PinnedVersion is a Go version pinned in a build or CI configuration file.
+type PinnedVersion struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Source  string `json:"source"`
+	Version string `json:"version"` // as written, normalized to "go1.N" or "go1.N.P"
+}
+
+// AuditFinding is a pinned version that should be upgraded.
+type AuditFinding struct {
+	Pin     PinnedVersion `json:"pin"`
+	Latest  string        `json:"latest"`           // latest patch release of the pinned minor version
+	EOL     bool          `json:"eol"`              // the minor version is no longer supported
+	Behind  int           `json:"behind,omitempty"` // patch releases between the pin and Latest
+	Message string        `json:"message"`
+}
+
+var (
+	// golang:1.21.3-alpine, golang:1.21
+	dockerImageRe = regexp.MustCompile(`\bgolang:(\d+\.\d+(?:\.\d+)?)`)
+	// ARG GO_VERSION=1.21.3, ENV GOLANG_VERSION 1.21.3
+	dockerArgRe = regexp.MustCompile(`\bGO(?:LANG)?_VERSION[=\s]+["']?(\d+\.\d+(?:\.\d+)?)`)
+	// go-version: '1.21.x', go-version: [1.20, 1.21], go: ["1.21", "1.22"]
+	workflowKeyRe = regexp.MustCompile(`\bgo(?:-version)?:\s*(.*)`)
+	versionRe     = regexp.MustCompile(`\b(1\.\d+(?:\.\d+)?)\b`)
+	// golang 1.21.3
+	asdfRe = regexp.MustCompile(`^\s*(?:golang|go)\s+(\d+\.\d+(?:\.\d+)?)`)
+	// go = "1.21", golang = ["1.21.3"]
+	miseRe = regexp.MustCompile(`^\s*(?:golang|go)\s*=\s*\[?\s*["']?(\d+\.\d+(?:\.\d+)?)`)
+)
+
+// ScanPins walks root looking for Go versions pinned in Dockerfiles, GitHub Actions
+// workflows, asdf .tool-versions files and mise configuration.
+func ScanPins(root string) ([]PinnedVersion, error) {
+	var pins []PinnedVersion
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (name == "vendor" || name == "node_modules" || name == ".git") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		source := pinSource(path)
+		if source == "" {
+			return nil
+		}
+		found, err := scanPinFile(path, source)
+		if err != nil {
+			return err
+		}
+		pins = append(pins, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return pins, nil
+}
+
+// pinSource returns the kind of configuration file at path, or "" if it is not one ScanPins understands.
+func pinSource(path string) string {
+	name := filepath.Base(path)
+	slashed := filepath.ToSlash(path)
+	switch {
+	case name == "Dockerfile" || strings.HasPrefix(name, "Dockerfile.") || strings.HasSuffix(name, ".Dockerfile") || strings.HasSuffix(name, ".dockerfile"):
+		return PinDockerfile
+	case strings.Contains(slashed, ".github/workflows/") && (strings.HasSuffix(name, ".yml") || strings.HasSuffix(name, ".yaml")):
+		return PinWorkflow
+	case name == ".tool-versions":
+		return PinAsdf
+	case name == "mise.toml" || name == ".mise.toml" || name == ".rtx.toml" || strings.HasSuffix(slashed, ".mise/config.toml"):
+		return PinMise
+	}
+	return ""
+}
+
+// scanPinFile extracts the pinned versions from a single file.
+func scanPinFile(path, source string) ([]PinnedVersion, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pins []PinnedVersion
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(text), "#") {
+			continue
+		}
+		for _, v := range pinnedVersions(source, text) {
+			pins = append(pins, PinnedVersion{File: path, Line: line, Source: source, Version: "go" + v})
+		}
+	}
+	return pins, scanner.Err()
+}
+
+// pinnedVersions returns the versions pinned on one line of a configuration file.
+func pinnedVersions(source, line string) []string {
+	var versions []string
+	switch source {
+	case PinDockerfile:
+		for _, re := range []*regexp.Regexp{dockerImageRe, dockerArgRe} {
+			for _, m := range re.FindAllStringSubmatch(line, -1) {
+				versions = append(versions, m[1])
+			}
+		}
+	case PinWorkflow:
+		for _, m := range dockerImageRe.FindAllStringSubmatch(line, -1) {
+			versions = append(versions, m[1])
+		}
+		if m := workflowKeyRe.FindStringSubmatch(line); m != nil {
+			versions = append(versions, versionRe.FindAllString(m[1], -1)...)
+		}
+	case PinAsdf:
+		if m := asdfRe.FindStringSubmatch(line); m != nil {
+			versions = append(versions, m[1])
+		}
+	case PinMise:
+		if m := miseRe.FindStringSubmatch(line); m != nil {
+			versions = append(versions, m[1])
+		}
+	}
+	return versions
+}
+
+// AuditPins reports the pins that are end-of-life or more than maxBehind patch releases
+// behind the latest patch of their minor version. releases lists the stable Go releases,
+// in any order. A pin without a patch level (e.g. "1.21") floats to the latest patch and
+// is only checked for end-of-life.
+func AuditPins(pins []PinnedVersion, releases []string, maxBehind int) []AuditFinding {
+	latest := LatestPatches(releases)
+	if len(latest) == 0 {
+		return nil
+	}
+	newest, _ := parseGoVersion(latest[len(latest)-1])
+	latestByMinor := make(map[int]string, len(latest))
+	for _, v := range latest {
+		gv, _ := parseGoVersion(v)
+		latestByMinor[gv.minor] = v
+	}
+
+	var findings []AuditFinding
+	for _, pin := range pins {
+		gv, ok := parseGoVersion(pin.Version)
+		if !ok {
+			continue
+		}
+		finding := AuditFinding{Pin: pin, Latest: latestByMinor[gv.minor]}
+		// The two most recent minor versions are supported.
+		finding.EOL = gv.minor < newest.minor-1
+
+		hasPatch := strings.Count(pin.Version, ".") == 2
+		if hasPatch {
+			for _, r := range releases {
+				rv, ok := parseGoVersion(r)
+				if ok && rv.pre == "" && rv.minor == gv.minor && rv.patch > gv.patch {
+					finding.Behind++
+				}
+			}
+		}
+
+		switch {
+		case finding.EOL:
+			finding.Message = fmt.Sprintf("%s is end-of-life; the supported versions are go1.%d and go1.%d", pin.Version, newest.minor-1, newest.minor)
+		case finding.Behind > maxBehind:
+			finding.Message = fmt.Sprintf("%s is %d patch releases behind %s", pin.Version, finding.Behind, finding.Latest)
+		default:
+			continue
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
@@ -0,0 +1,144 @@
+package gover
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// benchPackages is the number of packages with minor changes on a benchmark page, about
+// as many as recent release notes list.
+const benchPackages = 60
+
+// benchPage returns a release notes page in the markup of version's era, with packages
+// entries for the changes to individual packages.
+func benchPage(version string, packages int) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<html><body><main><h1>Go %s Release Notes</h1>\n", strings.TrimPrefix(version, "go"))
+	fmt.Fprintf(&b, `<h2 id="introduction">Introduction to Go %s</h2>
+<p>The latest Go release arrives six months after the previous one. Most of its changes are in the
+implementation of the toolchain, runtime, and libraries. It requires Go 1.20 or later for bootstrap,
+and drops support for macOS 10.15 Catalina. On Linux, it requires kernel version 3.2 or later.</p>
+<h2 id="language">Changes to the language</h2>
+<p>The <a href="/ref/spec#For_range">"for" range</a> clause now accepts integers, and loops now
+create new variables per iteration, see the <a href="/ref/spec#For_statements">spec</a>.</p>
+<h2 id="ports">Ports</h2>
+<h3 id="windows">Windows</h3>
+<p>On windows/arm64, internal linking is now supported for cgo programs, and the linker
+no longer requires -linkmode=external.</p>
+<h2 id="tools">Tools</h2>
+<h3 id="go-command">Go command</h3>
+<p>Commands in workspaces can now use a vendor directory, and go test -cover now prints coverage summaries.</p>
+<h3 id="cgo">Cgo</h3>
+<p>Setting CGO_ENABLED=0 is now the default when no C compiler is found.</p>
+<h2 id="runtime">Runtime</h2>
+<p>The runtime now keeps type-based garbage collection metadata nearer to each heap object,
+improving CPU performance by 1-3%% and reducing memory overhead by around 1%%.</p>
+<h2 id="library">Standard library</h2>
+<h3 id="minor_library_changes">Minor changes to the library</h3>
+<p>As always, there are various minor changes and updates to the library.</p>
+`, strings.TrimPrefix(version, "go"))
+	for i := range packages {
+		pkg := fmt.Sprintf("example/pkg%d", i)
+		body := fmt.Sprintf(`<p>The new <a href="/pkg/%[1]s/#Func%[2]d"><code>Func%[2]d</code></a> function and
+<a href="/pkg/%[1]s/#Type%[2]d.Method"><code>Type%[2]d.Method</code></a> method report errors. The
+<code>Old%[2]d</code> function is now deprecated; use <code>Func%[2]d</code> instead.</p>`, pkg, i)
+		if CompareVersions(version, "go1.21") >= 0 {
+			fmt.Fprintf(&b, "<h4 id=\"%[1]s\"><a href=\"/pkg/%[1]s/\">%[1]s</a></h4>\n%s\n", pkg, body)
+		} else {
+			fmt.Fprintf(&b, "<dl id=\"%[1]s\"><dt><a href=\"/pkg/%[1]s/\">%[1]s</a></dt><dd>%s</dd></dl>\n", pkg, body)
+		}
+	}
+	b.WriteString("</main></body></html>\n")
+	return []byte(b.String())
+}
+
+// benchClient returns a client that logs nothing.
+func benchClient() *Client {
+	return NewClient(WithLogger(log.New(io.Discard, "", 0)))
+}
+
+// benchDocument parses page, failing the benchmark on error.
+func benchDocument(b *testing.B, page []byte) *goquery.Selection {
+	b.Helper()
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err != nil {
+		b.Fatal(err)
+	}
+	return doc.Selection
+}
+
+// BenchmarkParsePage measures the parsing pipeline from the bytes of a release notes page
+// to its VersionData, for the markup of each era.
+func BenchmarkParsePage(b *testing.B) {
+	for _, version := range []string{"go1.8", "go1.16", "go1.23"} {
+		b.Run(version, func(b *testing.B) {
+			c := benchClient()
+			page := benchPage(version, benchPackages)
+			b.SetBytes(int64(len(page)))
+			b.ReportAllocs()
+			for b.Loop() {
+				doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+				if err != nil {
+					b.Fatal(err)
+				}
+				c.parseVersionPage(version, "2024-08-13", doc.Selection)
+			}
+		})
+	}
+}
+
+// BenchmarkParseVersionPage measures parseVersionPage alone, on an already parsed document.
+func BenchmarkParseVersionPage(b *testing.B) {
+	c := benchClient()
+	page := benchDocument(b, benchPage("go1.23", benchPackages))
+	b.ReportAllocs()
+	for b.Loop() {
+		c.parseVersionPage("go1.23", "2024-08-13", page)
+	}
+}
+
+// BenchmarkExtract measures the extractors run on every page.
+func BenchmarkExtract(b *testing.B) {
+	page := benchDocument(b, benchPage("go1.23", benchPackages))
+	extractors := []struct {
+		name string
+		fn   func(*goquery.Selection)
+	}{
+		{"requirements", func(s *goquery.Selection) { extractRequirements(s) }},
+		{"perf", func(s *goquery.Selection) { extractPerfClaims(s) }},
+		{"linking", func(s *goquery.Selection) { extractLinking(s) }},
+		{"fingerprint", func(s *goquery.Selection) { pageFingerprint(s, defaultCompiled.content) }},
+	}
+	for _, e := range extractors {
+		b.Run(e.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				e.fn(page)
+			}
+		})
+	}
+}
+
+// BenchmarkClassify measures the classification of change descriptions.
+func BenchmarkClassify(b *testing.B) {
+	desc := "The new Func function and Type.Method method report errors. The Old function is now deprecated; use Func instead."
+	b.ReportAllocs()
+	for b.Loop() {
+		classifyImpactPtr(classifyChange(desc), desc)
+	}
+}
+
+// BenchmarkCountTokens measures the token counts added to every category.
+func BenchmarkCountTokens(b *testing.B) {
+	vd := benchClient().parseVersionPage("go1.23", "2024-08-13", benchDocument(b, benchPage("go1.23", benchPackages)))
+	b.ReportAllocs()
+	for b.Loop() {
+		countCategoryTokens(vd.Changes)
+	}
+}
@@ -0,0 +1,92 @@
+package gover
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// BoilerplateMode controls how sections matched by a SectionFilter are handled.
+type BoilerplateMode string
+
+const (
+	BoilerplateKeep BoilerplateMode = "keep" // leave boilerplate sections untouched
+	BoilerplateMark BoilerplateMode = "mark" // set ChangeCategory.Boilerplate
+	BoilerplateDrop BoilerplateMode = "drop" // remove boilerplate sections
+)
+
+// ParseBoilerplateMode parses "keep", "mark" or "drop".
+func ParseBoilerplateMode(s string) (BoilerplateMode, error) {
+	switch m := BoilerplateMode(strings.ToLower(s)); m {
+	case BoilerplateKeep, BoilerplateMark, BoilerplateDrop:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown boilerplate mode %q (want keep, mark or drop)", s)
+}
+
+// DefaultSectionDeny lists the category names that carry no release information,
+// such as the introduction every release notes page opens with.
+var DefaultSectionDeny = []string{
+	"introduction to go*",
+	"introduction",
+	"table of contents",
+	"contents",
+	"footer",
+	"related articles",
+	"see also",
+}
+
+// SectionFilter recognizes boilerplate sections by category name. Names are compared
+// case-insensitively against glob patterns as understood by path.Match, so
+// "introduction to go*" matches "Introduction to Go 1.22". A section is boilerplate
+// if it matches a Deny pattern and no Allow pattern.
+type SectionFilter struct {
+	Allow []string `json:"allow,omitempty"`
+	Deny  []string `json:"deny,omitempty"`
+}
+
+// IsBoilerplate reports whether the category name is filtered out by f.
+func (f SectionFilter) IsBoilerplate(category string) bool {
+	name := strings.ToLower(collapseSpace(category))
+	return matchesAny(name, f.Deny) && !matchesAny(name, f.Allow)
+}
+
+// matchesAny reports whether name matches any of the glob patterns.
+func matchesAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(strings.ToLower(p), name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterBoilerplate applies f to the categories of data according to mode, returning the result.
+// It is what Scrape does while parsing, and can be used to re-filter an existing dataset.
+func FilterBoilerplate(data []VersionData, f SectionFilter, mode BoilerplateMode) []VersionData {
+	if mode == BoilerplateKeep {
+		return data
+	}
+	out := make([]VersionData, 0, len(data))
+	for _, vd := range data {
+		vd.Changes = filterSections(vd.Changes, f, mode)
+		out = append(out, vd)
+	}
+	return out
+}
+
+// filterSections applies f to categories according to mode.
+func filterSections(categories []ChangeCategory, f SectionFilter, mode BoilerplateMode) []ChangeCategory {
+	out := make([]ChangeCategory, 0, len(categories))
+	for _, cat := range categories {
+		boilerplate := f.IsBoilerplate(cat.Category)
+		switch {
+		case boilerplate && mode == BoilerplateDrop:
+			continue
+		case mode == BoilerplateMark:
+			cat.Boilerplate = boilerplate
+		}
+		out = append(out, cat)
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+package gover
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ChangeType classifies a change, in SymbolChange.Type and ChangeCategory.Type. Decoding
+// normalizes the spellings of older datasets, such as "new", and keeps values it does not
+// recognize as they are, so that they survive a round trip; see Known.
+type ChangeType string
+
+// Normalized change types.
+const (
+	ChangeAdded      ChangeType = "added"
+	ChangeChanged    ChangeType = "changed"
+	ChangeDeprecated ChangeType = "deprecated"
+	ChangeRemoved    ChangeType = "removed"
+	ChangeExcepted   ChangeType = "excepted" // changed under an exception to the Go 1 compatibility promise
+)
+
+// ChangeTypes lists the normalized change types.
+var ChangeTypes = []ChangeType{ChangeAdded, ChangeChanged, ChangeDeprecated, ChangeRemoved, ChangeExcepted}
+
+// changeTypeAliases maps the spellings seen in release notes and older datasets to normalized change types.
+var changeTypeAliases = map[string]ChangeType{
+	"add":        ChangeAdded,
+	"added":      ChangeAdded,
+	"new":        ChangeAdded,
+	"change":     ChangeChanged,
+	"changed":    ChangeChanged,
+	"modified":   ChangeChanged,
+	"updated":    ChangeChanged,
+	"deprecate":  ChangeDeprecated,
+	"deprecated": ChangeDeprecated,
+	"obsoleted":  ChangeDeprecated,
+	"remove":     ChangeRemoved,
+	"removed":    ChangeRemoved,
+	"deleted":    ChangeRemoved,
+	"dropped":    ChangeRemoved,
+	"excepted":   ChangeExcepted,
+}
+
+// ParseChangeType returns the normalized form of a change type name, accepting common synonyms.
+func ParseChangeType(s string) (ChangeType, error) {
+	if t, ok := changeTypeAliases[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return t, nil
+	}
+	return "", fmt.Errorf("unknown change type %q (want one of %s)", s, JoinNames(ChangeTypes))
+}
+
+// NormalizeChangeType returns the normalized form of a change type, or ChangeChanged if it is not recognized.
+func NormalizeChangeType(t ChangeType) ChangeType {
+	if n, err := ParseChangeType(string(t)); err == nil {
+		return n
+	}
+	return ChangeChanged
+}
+
+// Known reports whether t is one of ChangeTypes.
+func (t ChangeType) Known() bool {
+	return slices.Contains(ChangeTypes, t)
+}
+
+// UnmarshalText normalizes known spellings of a change type and keeps others verbatim.
+func (t *ChangeType) UnmarshalText(b []byte) error {
+	if n, err := ParseChangeType(string(b)); err == nil {
+		*t = n
+	} else {
+		*t = ChangeType(b)
+	}
+	return nil
+}
+
+// JoinNames joins the names of enum values, such as ChangeTypes, for messages and flag help.
+func JoinNames[T ~string](values []T) string {
+	names := make([]string, len(values))
+	for i, v := range values {
+		names[i] = string(v)
+	}
+	return strings.Join(names, ", ")
+}
+
+// classifyChange infers a normalized change type from the prose describing a change.
+// Removals and deprecations are checked first, since their descriptions frequently
+// also mention the new API that replaces them.
+func classifyChange(text string) ChangeType {
+	t := strings.ToLower(text)
+	switch {
+	case containsAny(t, "is deprecated", "are deprecated", "now deprecated", "been deprecated", "deprecated in favor", "deprecates"):
+		return ChangeDeprecated
+	case containsAny(t, "removed", "no longer supported", "no longer support", "dropped support", "drops support", "is no longer available"):
+		return ChangeRemoved
+	case containsAny(t, "the new ", "new function", "new method", "new type", "new package", "adds ", "added", "introduces", "now supports", "now provides"):
+		return ChangeAdded
+	}
+	return ChangeChanged
+}
+
+// containsAny reports whether s contains any of the substrings.
+func containsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package gover
+
+import "testing"
+
+func TestClassifyChange(t *testing.T) {
+	tests := []struct {
+		text string
+		want ChangeType
+	}{
+		{"The Seed function is deprecated; use the new rand.New instead.", ChangeDeprecated},
+		{"ReadDir and ReadFile are deprecated in favor of os.ReadDir.", ChangeDeprecated},
+		{"CloseNotifier has been deprecated since Go 1.11.", ChangeDeprecated},
+		{"This release deprecates the io/ioutil package.", ChangeDeprecated},
+		{"The x509sha1 setting has been removed.", ChangeRemoved},
+		{"Go 1.21 no longer supports Windows 7.", ChangeRemoved},
+		{"Windows 7 is no longer supported.", ChangeRemoved},
+		{"This release drops support for macOS 10.13.", ChangeRemoved},
+		{"The GOROOT_FINAL variable is no longer available.", ChangeRemoved},
+		{"The new Clear function removes all elements.", ChangeAdded},
+		{"The new Clear function resets a map.", ChangeAdded},
+		{"A new method, Request.PathValue, returns a wildcard.", ChangeAdded},
+		{"This release adds an Unwrap method to the error type.", ChangeAdded},
+		{"The go command now supports workspaces.", ChangeAdded},
+		{"Encode now escapes <, > and & in strings.", ChangeChanged},
+		{"IS DEPRECATED", ChangeDeprecated},
+		{"", ChangeChanged},
+	}
+	for _, tt := range tests {
+		if got := classifyChange(tt.text); got != tt.want {
+			t.Errorf("classifyChange(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseChangeType(t *testing.T) {
+	tests := []struct {
+		name string
+		want ChangeType
+	}{
+		{"added", ChangeAdded},
+		{" New ", ChangeAdded},
+		{"modified", ChangeChanged},
+		{"Obsoleted", ChangeDeprecated},
+		{"deleted", ChangeRemoved},
+		{"excepted", ChangeExcepted},
+	}
+	for _, tt := range tests {
+		got, err := ParseChangeType(tt.name)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseChangeType(%q) = %q, %v, want %q", tt.name, got, err, tt.want)
+		}
+	}
+	if _, err := ParseChangeType("renamed"); err == nil {
+		t.Error(`ParseChangeType("renamed") succeeded, want an error`)
+	}
+	if got := NormalizeChangeType("renamed"); got != ChangeChanged {
+		t.Errorf(`NormalizeChangeType("renamed") = %q, want %q`, got, ChangeChanged)
+	}
+}
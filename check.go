@@ -0,0 +1,136 @@
+package gover
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Finding levels.
+const (
+	LevelWarning = "warning"
+	LevelError   = "error"
+)
+
+// Finding is a use of a symbol affected by a change in the release notes.
+type Finding struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Symbol  string `json:"symbol"`
+	Version string `json:"version"` // the release that made the change
+	Rule    string `json:"rule"`    // "deprecated", "removed" or the impact level of the change
+	Level   string `json:"level"`   // LevelWarning or LevelError
+	Message string `json:"message"`
+	Module  string `json:"module,omitempty"` // the module of File in a workspace, see Workspace
+}
+
+// affected is a symbol, or a whole package if pkg is set, changed by a release.
+type affected struct {
+	version string
+	pkg     bool
+	rule    string
+	text    string
+}
+
+// FindDeprecatedUses reports the uses of symbols and packages that data records as deprecated.
+// Whole packages only match if deprecated themselves, see AddDeprecations: a deprecation
+// mentioned in the notes of a package concerns its symbol changes, not the replacements
+// the notes recommend.
+func FindDeprecatedUses(uses []SymbolUse, data []VersionData) []Finding {
+	changes := make(map[string]affected)
+	for _, vd := range data {
+		for _, cat := range vd.Changes {
+			if isPackageDeprecation(cat) {
+				changes[cat.Package] = affected{vd.Version, true, string(ChangeDeprecated), cat.Description}
+			}
+			for _, sc := range cat.Changes {
+				if NormalizeChangeType(sc.Type) == ChangeDeprecated {
+					changes[sc.Symbol] = affected{vd.Version, false, string(ChangeDeprecated), sc.Description}
+				}
+			}
+		}
+	}
+	return matchUses(uses, changes)
+}
+
+// CheckUpgrade reports the uses of symbols and packages changed between the from and to
+// releases in ways likely to affect existing code: removals, deprecations, compatibility
+// exceptions, and changes whose impact is behavioral or breaking-ish. There are none if
+// from is already at the release line of to, or a newer one.
+func CheckUpgrade(uses []SymbolUse, data []VersionData, from, to string) ([]Finding, error) {
+	fv, okFrom := parseGoVersion(NormalizeVersion(from))
+	tv, okTo := parseGoVersion(NormalizeVersion(to))
+	if okFrom && okTo && fv.minor >= tv.minor {
+		return nil, nil
+	}
+	diff, err := Diff(data, from, to)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(map[string]affected)
+	for _, vd := range diff {
+		for _, cat := range vd.Changes {
+			if isPackageDeprecation(cat) {
+				changes[cat.Package] = affected{vd.Version, true, string(ChangeDeprecated), cat.Description}
+			}
+			for _, sc := range cat.Changes {
+				t := NormalizeChangeType(sc.Type)
+				if rule := upgradeRule(t, impactLevel(sc.Impact, t, sc.Description)); rule != "" {
+					changes[sc.Symbol] = affected{vd.Version, false, rule, sc.Description}
+				}
+			}
+		}
+	}
+	return matchUses(uses, changes), nil
+}
+
+// isPackageDeprecation reports whether cat records the deprecation of a whole package, as
+// added by AddDeprecations.
+func isPackageDeprecation(cat ChangeCategory) bool {
+	return cat.Category == deprecationsCategory && cat.Type == ChangeDeprecated && cat.Package != "" && len(cat.Changes) == 0
+}
+
+// upgradeRule returns the rule a change of the given type and impact level falls under,
+// or "" if it is unlikely to affect existing code.
+func upgradeRule(changeType ChangeType, level string) string {
+	switch {
+	case changeType == ChangeRemoved || changeType == ChangeDeprecated || changeType == ChangeExcepted:
+		return string(changeType)
+	case level == ImpactBehavioral || level == ImpactBreaking:
+		return level
+	}
+	return ""
+}
+
+// matchUses returns a finding for each use of a changed symbol, or of a symbol of a changed package.
+func matchUses(uses []SymbolUse, changes map[string]affected) []Finding {
+	var findings []Finding
+	for _, u := range uses {
+		a, ok := changes[u.Symbol]
+		if !ok {
+			pkg := u.Symbol[:strings.LastIndex(u.Symbol, ".")]
+			if a, ok = changes[pkg]; !ok || !a.pkg {
+				continue
+			}
+		}
+		level := LevelWarning
+		if a.rule == string(ChangeRemoved) || a.rule == string(ChangeExcepted) || a.rule == ImpactBreaking {
+			level = LevelError
+		}
+		msg := fmt.Sprintf("%s: %s in %s", u.Symbol, a.rule, a.version)
+		if text := strings.Join(strings.Fields(a.text), " "); text != "" {
+			msg += ": " + text
+		}
+		findings = append(findings, Finding{
+			File:    u.File,
+			Line:    u.Line,
+			Column:  u.Column,
+			Symbol:  u.Symbol,
+			Version: a.version,
+			Rule:    a.rule,
+			Level:   level,
+			Message: msg,
+		})
+	}
+	return findings
+}
@@ -0,0 +1,56 @@
+package gover
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// checkpoint records the versions a scrape has completed in a file, as a list of versions
+// LoadFile reads, so that an interrupted scrape can resume where it stopped instead of
+// fetching every page again, see WithCheckpoint.
+type checkpoint struct {
+	path string
+	done []VersionData
+}
+
+// loadCheckpoint returns the versions completed by the scrape that wrote the checkpoint
+// file at path, or none if there is no such file.
+func loadCheckpoint(path string) ([]VersionData, error) {
+	data, err := LoadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+	return data, nil
+}
+
+// add records vd as completed, replacing the file so that it is never left half-written.
+func (cp *checkpoint) add(vd VersionData) error {
+	cp.done = append(cp.done, vd)
+	b, err := json.Marshal(cp.done)
+	if err != nil {
+		return err
+	}
+	dir, base := filepath.Split(cp.path)
+	f, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	_, err = f.Write(b)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(f.Name(), cp.path)
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
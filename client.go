@@ -0,0 +1,275 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gocolly/colly/v2"
+)
+
+// defaultBaseURL is the site release notes are scraped from unless WithBaseURL is given.
+const defaultBaseURL = "https://go.dev"
+
+// userAgent identifies gover to the sites it scrapes.
+const userAgent = "gover-scraper/1.0 (+https://github.com/paulstuart/gover)"
+
+// maxConnsPerHost is the number of connections opened to each site, and kept open while
+// idle: enough for every worker fetching from it at once.
+const maxConnsPerHost = 8
+
+// sharedTransport is the transport of the default HTTP client. The client's own requests
+// and its collectors share it, so that a scrape reuses its connections to go.dev, over
+// HTTP/2 where the site supports it, instead of opening a new one per page.
+var sharedTransport = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ForceAttemptHTTP2 = true
+	t.MaxIdleConns = 100
+	t.MaxConnsPerHost = maxConnsPerHost
+	t.MaxIdleConnsPerHost = maxConnsPerHost
+	t.IdleConnTimeout = 90 * time.Second
+	return t
+}()
+
+// defaultHTTPClient is the HTTP client used unless WithHTTPClient is given.
+var defaultHTTPClient = &http.Client{Transport: sharedTransport}
+
+// Client scrapes Go release information. Its configuration is fixed by NewClient, so a
+// Client is safe for concurrent use and differently configured clients can coexist.
+type Client struct {
+	opts   options
+	polite *politeness
+	dump   *debugDump
+	sel    *selectors
+	meter  *meter
+
+	counters counters // soft failures, see ScrapeCounters
+}
+
+// NewClient returns a Client configured by opts.
+func NewClient(opts ...Option) *Client {
+	o := newOptions(opts)
+	c := &Client{opts: o, polite: newPoliteness(o), sel: defaultCompiled}
+	if o.selectors != nil {
+		if sel, err := o.selectors.compile(); err != nil {
+			o.logger.Printf("Warning: using the default selectors: %v", err)
+		} else {
+			c.sel = sel
+		}
+	}
+	if o.debugDir != "" {
+		c.dump = &debugDump{dir: o.debugDir, log: o.logger, sel: c.sel}
+	}
+	if o.metrics != nil {
+		c.meter = &meter{}
+	}
+	return c
+}
+
+// url returns the absolute URL of path on the client's base URL.
+func (c *Client) url(path string) string {
+	return c.opts.baseURL + path
+}
+
+// httpClient returns the configured HTTP client, wrapped to apply the politeness and
+// retry settings, to use the cache, to save pages for the debug dump and to record metrics
+// if these are set.
+// Cached pages bypass the politeness settings.
+func (c *Client) httpClient() *http.Client {
+	hc := *c.opts.httpClient
+	mirror := c.opts.baseURL != defaultBaseURL
+	if c.polite == nil && c.opts.retries == 0 && c.opts.cache == nil && c.dump == nil && c.meter == nil && !mirror {
+		return &hc
+	}
+	if hc.Transport == nil {
+		hc.Transport = sharedTransport
+	}
+	if strings.HasPrefix(c.opts.baseURL, "file:") {
+		hc.Transport = fileTransport{next: hc.Transport}
+	}
+	if mirror {
+		hc.Transport = sniffTransport{next: hc.Transport}
+	}
+	if c.polite != nil {
+		hc.Transport = politeTransport{p: c.polite, next: hc.Transport}
+	}
+	if c.opts.retries > 0 {
+		hc.Transport = retryTransport{retries: c.opts.retries, next: hc.Transport}
+	}
+	if c.opts.cache != nil {
+		hc.Transport = cachingTransport{cache: c.opts.cache, next: hc.Transport}
+	}
+	if c.dump != nil {
+		hc.Transport = dumpTransport{dump: c.dump, next: hc.Transport}
+	}
+	if c.meter != nil {
+		hc.Transport = meterTransport{meter: c.meter, next: hc.Transport}
+	}
+	return &hc
+}
+
+// newCollector returns a collector that stops when ctx is done. It only visits, following
+// redirects too, the base URL's host and the allowed domains; a base URL without a host,
+// as of a mirror in a local directory, places no restriction.
+func (c *Client) newCollector(ctx context.Context) *colly.Collector {
+	var domains []string
+	if u, err := url.Parse(c.opts.baseURL); err == nil && u.Hostname() != "" && !slices.Contains(c.opts.allowedDomains, "*") {
+		domains = append([]string{u.Hostname()}, c.opts.allowedDomains...)
+	}
+	col := colly.NewCollector(
+		colly.AllowedDomains(domains...),
+		colly.StdlibContext(ctx),
+	)
+	col.UserAgent = userAgent
+	hc := c.httpClient()
+	if len(domains) > 0 {
+		// SetClient drops the collector's own redirect check.
+		next := hc.CheckRedirect
+		hc.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			if !slices.Contains(domains, req.URL.Hostname()) {
+				return fmt.Errorf("not following redirect to %s: %w", req.URL, colly.ErrForbiddenDomain)
+			}
+			if next != nil {
+				return next(req, via)
+			}
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return nil
+		}
+	}
+	col.SetClient(hc)
+	return col
+}
+
+// get fetches url and returns its body.
+func (c *Client) get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	if c.opts.githubToken != "" && c.isGitHubAPI(req.URL) {
+		req.Header.Set("Authorization", "Bearer "+c.opts.githubToken)
+	}
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, networkError(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(io.Discard, resp.Body) // so that the connection can be reused
+		return nil, networkError(fmt.Errorf("unexpected status code: %d", resp.StatusCode))
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, networkError(err)
+	}
+	return body, nil
+}
+
+// Cache stores fetched pages by URL.
+type Cache interface {
+	Get(url string) ([]byte, bool)
+	Set(url string, body []byte)
+}
+
+// DirCache is a Cache keeping one file per URL in a directory.
+type DirCache struct {
+	dir    string
+	maxAge time.Duration
+}
+
+// NewDirCache returns a Cache storing pages in dir, which is created if needed.
+// Entries older than maxAge are refetched; a maxAge of 0 keeps entries forever.
+func NewDirCache(dir string, maxAge time.Duration) (*DirCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &DirCache{dir: dir, maxAge: maxAge}, nil
+}
+
+func (d *DirCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:]))
+}
+
+// Get returns the cached body of url, if present and not expired.
+func (d *DirCache) Get(url string) ([]byte, bool) {
+	name := d.path(url)
+	info, err := os.Stat(name)
+	if err != nil || (d.maxAge > 0 && time.Since(info.ModTime()) > d.maxAge) {
+		return nil, false
+	}
+	body, err := os.ReadFile(name)
+	return body, err == nil
+}
+
+// Set stores body as the cached page for url. Failures are ignored, leaving the page uncached.
+func (d *DirCache) Set(url string, body []byte) {
+	f, err := os.CreateTemp(d.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = f.Write(body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return
+	}
+	if err := os.Rename(f.Name(), d.path(url)); err != nil {
+		os.Remove(f.Name())
+	}
+}
+
+// cachingTransport answers GET requests from a Cache, storing successful responses in it.
+type cachingTransport struct {
+	cache Cache
+	next  http.RoundTripper
+}
+
+func (t cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+	key := req.URL.String()
+	if body, ok := t.cache.Get(key); ok {
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": {http.DetectContentType(body)}},
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	t.cache.Set(key, body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
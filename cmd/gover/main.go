@@ -1,34 +1,9 @@
+// Command gover scrapes the Go release notes and answers questions about them. See the
+// app package, which implements it.
 package main
 
-import (
-	"encoding/json"
-	"flag"
-	"log"
-	"os"
-
-	"github.com/paulstuart/gover"
-)
+import "github.com/paulstuart/gover/app"
 
 func main() {
-	outputFile := flag.String("output", "go_version_data.json", "Output JSON file path")
-	flag.Parse()
-
-	log.SetOutput(os.Stdout)
-	log.SetFlags(log.LstdFlags | log.Lshortfile)
-
-	versionData, err := gover.Scrape()
-	if err != nil {
-		log.Fatalf("Error scraping: %v", err)
-	}
-
-	jsonData, err := json.MarshalIndent(versionData, "", "  ")
-	if err != nil {
-		log.Fatalf("Error marshaling JSON: %v", err)
-	}
-
-	if err := os.WriteFile(*outputFile, jsonData, 0644); err != nil {
-		log.Fatalf("Error writing JSON to file %s: %v", *outputFile, err)
-	}
-
-	log.Printf("Successfully wrote scraped data to %s", *outputFile)
+	app.Main()
 }
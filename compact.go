@@ -0,0 +1,99 @@
+package gover
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Compact returns a copy of data without redundant categories: the Overview, which only
+// repeats the page title, headings with no text of their own, and categories captured more
+// than once. The paragraphs about one package, and those of a section split into adjacent
+// categories, are merged into one category, and duplicate symbol changes are dropped. Scrape
+// does this already with WithCompaction; use it for datasets scraped without it.
+func Compact(data []VersionData) []VersionData {
+	out := make([]VersionData, 0, len(data))
+	for _, vd := range data {
+		vd.Changes = compactCategories(vd.Changes)
+		out = append(out, vd)
+	}
+	return out
+}
+
+// categoryKey identifies the categories compactCategories may merge.
+type categoryKey struct {
+	category, pkg string
+}
+
+// compactCategories implements Compact for the categories of one version. Compacting
+// compacted categories leaves them unchanged.
+func compactCategories(categories []ChangeCategory) []ChangeCategory {
+	out := make([]ChangeCategory, 0, len(categories))
+	seen := make(map[categoryKey]int)
+	for _, cat := range categories {
+		cat.Changes = uniqueChanges(cat.Changes)
+		if cat.Category == overviewCategory || headingOnly(cat) {
+			continue
+		}
+		key := categoryKey{cat.Category, cat.Package}
+		if i, ok := seen[key]; ok && (cat.Package != "" || i == len(out)-1 && out[i].URL == cat.URL || sameCategory(out[i], cat)) {
+			out[i] = mergeCategories(out[i], cat)
+			continue
+		}
+		seen[key] = len(out)
+		out = append(out, cat)
+	}
+	return countCategoryTokens(out)
+}
+
+// headingOnly reports whether cat has nothing but its heading, as sections whose text is
+// all in their subsections do.
+func headingOnly(cat ChangeCategory) bool {
+	return strings.TrimSpace(cat.Description) == "" && cat.Title == "" && len(cat.Examples) == 0 && len(cat.Changes) == 0
+}
+
+// sameCategory reports whether a and b were captured from the same element.
+func sameCategory(a, b ChangeCategory) bool {
+	return a.URL == b.URL && collapseSpace(a.Description) == collapseSpace(b.Description)
+}
+
+// mergeCategories appends the paragraphs, examples and symbol changes of b that a lacks to
+// a. a keeps its type and impact.
+func mergeCategories(a, b ChangeCategory) ChangeCategory {
+	if desc := strings.TrimSpace(b.Description); desc != "" && !strings.Contains(collapseSpace(a.Description), collapseSpace(desc)) {
+		a.Description = joinNonEmpty([]string{a.Description, desc})
+	}
+	a.Examples = slices.Clone(a.Examples)
+	for _, e := range b.Examples {
+		if !slices.Contains(a.Examples, e) {
+			a.Examples = append(a.Examples, e)
+		}
+	}
+	a.Changes = uniqueChanges(append(a.Changes, b.Changes...))
+	if a.RawHTML != "" && b.RawHTML != "" && a.RawHTML != b.RawHTML {
+		a.RawHTML += "\n" + b.RawHTML
+	}
+	a.Title = cmp.Or(a.Title, b.Title)
+	a.Kind = cmp.Or(a.Kind, b.Kind)
+	a.Type = cmp.Or(a.Type, b.Type)
+	if a.Impact == nil {
+		a.Impact = b.Impact
+	}
+	a.Boilerplate = a.Boilerplate && b.Boilerplate
+	return a
+}
+
+// uniqueChanges returns a copy of changes without those repeating an earlier one's symbol
+// and description.
+func uniqueChanges(changes []SymbolChange) []SymbolChange {
+	type key struct{ symbol, desc string }
+	seen := make(map[key]bool, len(changes))
+	return slices.DeleteFunc(slices.Clone(changes), func(sc SymbolChange) bool {
+		k := key{sc.Symbol, collapseSpace(sc.Description)}
+		if seen[k] {
+			return true
+		}
+		seen[k] = true
+		return false
+	})
+}
@@ -0,0 +1,101 @@
+package gover
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Groupings of changes in a Comparison.
+const (
+	CompareByCategory = "category" // the section of the release notes
+	CompareByPackage  = "package"  // the import path, for library changes
+	CompareByKind     = "kind"     // see CategoryKind
+)
+
+// Comparison counts the changes of several releases side by side, for upgrade summaries.
+// It renders as a matrix in the table, csv and html formats, with a row per category,
+// package or kind and a column per version.
+type Comparison struct {
+	By       string          `json:"by"`
+	Versions []string        `json:"versions"`
+	Rows     []ComparisonRow `json:"rows"`
+	Presence bool            `json:"presence,omitempty"` // render whether there are changes rather than how many
+}
+
+// ComparisonRow is the number of changes of each version of a Comparison in one category,
+// package or kind.
+type ComparisonRow struct {
+	Key    string `json:"key"`
+	Counts []int  `json:"counts"` // in the order of Comparison.Versions
+}
+
+// Compare counts the changes of each of versions in data, grouped by category, package or
+// kind (see CompareByCategory). Each category counts as one change, plus one per symbol
+// change it lists; the Overview and boilerplate sections are left out, and so are categories
+// without a package when grouping by package. Rows are ordered by total count, largest first.
+func Compare(data []VersionData, versions []string, by string) (*Comparison, error) {
+	key := map[string]func(ChangeCategory) string{
+		CompareByCategory: func(cat ChangeCategory) string { return collapseSpace(cat.Category) },
+		CompareByPackage:  func(cat ChangeCategory) string { return cat.Package },
+		CompareByKind:     func(cat ChangeCategory) string { return string(categoryKind(cat)) },
+	}[by]
+	if key == nil {
+		return nil, fmt.Errorf("unknown grouping %q (want %s, %s or %s)", by, CompareByCategory, CompareByPackage, CompareByKind)
+	}
+
+	c := &Comparison{By: by}
+	filter := SectionFilter{Deny: DefaultSectionDeny}
+	index := make(map[string]int)
+	for i, v := range versions {
+		vd, ok := FindVersion(data, v)
+		if !ok {
+			return nil, fmt.Errorf("version %s not found", v)
+		}
+		c.Versions = append(c.Versions, vd.Version)
+		for _, cat := range vd.Changes {
+			k := key(cat)
+			if k == "" || cat.Category == overviewCategory || cat.Boilerplate || filter.IsBoilerplate(cat.Category) {
+				continue
+			}
+			j, ok := index[k]
+			if !ok {
+				j = len(c.Rows)
+				index[k] = j
+				c.Rows = append(c.Rows, ComparisonRow{Key: k, Counts: make([]int, len(versions))})
+			}
+			c.Rows[j].Counts[i] += 1 + len(cat.Changes)
+		}
+	}
+	total := func(r ComparisonRow) int {
+		n := 0
+		for _, count := range r.Counts {
+			n += count
+		}
+		return n
+	}
+	slices.SortStableFunc(c.Rows, func(a, b ComparisonRow) int { return cmp.Compare(total(b), total(a)) })
+	return c, nil
+}
+
+// Table returns the matrix of c, headed by the versions, with a check mark for versions
+// with changes if c.Presence is set and otherwise their count.
+func (c *Comparison) Table() [][]string {
+	table := [][]string{append([]string{c.By}, c.Versions...)}
+	for _, r := range c.Rows {
+		row := []string{r.Key}
+		for _, n := range r.Counts {
+			switch {
+			case !c.Presence:
+				row = append(row, strconv.Itoa(n))
+			case n > 0:
+				row = append(row, "✓")
+			default:
+				row = append(row, "")
+			}
+		}
+		table = append(table, row)
+	}
+	return table
+}
@@ -0,0 +1,96 @@
+package gover
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// milestonesPath lists the milestones of the Go issue tracker on the GitHub API.
+const milestonesPath = "/repos/golang/go/milestones?state=all&per_page=100"
+
+// ContributionStats describes the work that went into a release.
+type ContributionStats struct {
+	ResolvedIssues int `json:"resolvedIssues,omitempty"` // closed issues in the release's GitHub milestone
+	Contributors   int `json:"contributors,omitempty"`   // as stated by the announcement post
+}
+
+// milestoneRe matches the GitHub milestone of a major release, e.g. "Go1.22".
+var milestoneRe = regexp.MustCompile(`^Go(1\.\d+)$`)
+
+// ResolvedIssues fetches the number of closed issues in each release's GitHub milestone,
+// keyed by version. Releases predating the move to GitHub have no milestone.
+func (c *Client) ResolvedIssues(ctx context.Context) (map[string]int, error) {
+	milestones, err := c.milestones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[string]int, len(milestones))
+	for version, m := range milestones {
+		resolved[version] = m.ClosedIssues
+	}
+	return resolved, nil
+}
+
+// milestone is a GitHub milestone of the Go issue tracker.
+type milestone struct {
+	Number       int    `json:"number"`
+	Title        string `json:"title"`
+	ClosedIssues int    `json:"closed_issues"`
+}
+
+// milestones fetches the GitHub milestones of the major releases, keyed by version.
+func (c *Client) milestones(ctx context.Context) (map[string]milestone, error) {
+	found := make(map[string]milestone)
+	for page := 1; ; page++ {
+		body, err := c.get(ctx, fmt.Sprintf("%s&page=%d", c.githubURL(milestonesPath), page))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch milestones: %w", err)
+		}
+		var milestones []milestone
+		if err := json.Unmarshal(body, &milestones); err != nil {
+			return nil, parseError(fmt.Errorf("failed to decode milestones: %w", err))
+		}
+		if len(milestones) == 0 {
+			return found, nil
+		}
+		for _, m := range milestones {
+			if v := milestoneRe.FindStringSubmatch(m.Title); v != nil {
+				found["go"+v[1]] = m
+			}
+		}
+	}
+}
+
+// addResolvedIssues fills in the resolved issue counts of data. Failing to fetch them only
+// loses the counts, so it is logged rather than returned.
+func (c *Client) addResolvedIssues(ctx context.Context, data []VersionData) {
+	resolved, err := c.ResolvedIssues(ctx)
+	if err != nil {
+		c.opts.logger.Printf("Warning: resolved issue counts not collected: %v", err)
+		return
+	}
+	for i := range data {
+		if n, ok := resolved[data[i].Version]; ok {
+			data[i].Contributions = cmp.Or(data[i].Contributions, &ContributionStats{})
+			data[i].Contributions.ResolvedIssues = n
+		}
+	}
+}
+
+// contributorsRe matches statements such as "contributions from 106 contributors".
+var contributorsRe = regexp.MustCompile(`(?i)(\d[\d,]*)\s+contributors`)
+
+// countContributors returns the contributor count stated in an announcement post, or 0.
+func countContributors(text string) int {
+	m := contributorsRe.FindStringSubmatch(text)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.ReplaceAll(m[1], ",", ""))
+	return n
+}
@@ -0,0 +1,129 @@
+package gover
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ScrapeCounters count the soft failures of scraping: problems that degrade the data
+// without failing the scrape, and would otherwise go unnoticed.
+type ScrapeCounters struct {
+	PagesSkipped      int64            `json:"pagesSkipped"`             // release notes pages not parsed: unreachable, empty or not in English
+	EmptySelectors    map[string]int64 `json:"emptySelectors,omitempty"` // pages on which each selector of Selectors matched nothing, by name
+	UnresolvedSymbols int64            `json:"unresolvedSymbols"`        // code spans of package entries naming no symbol of the package
+}
+
+// counters accumulate ScrapeCounters safely for concurrent use.
+type counters struct {
+	pagesSkipped, unresolvedSymbols atomic.Int64
+
+	mu             sync.Mutex
+	emptySelectors map[string]int64
+}
+
+// processCounters accumulate the soft failures of every client in the process, see
+// ReadCounters.
+var processCounters counters
+
+// ReadCounters returns the soft failures of every scrape run by this process so far, for
+// exporting as metrics.
+func ReadCounters() ScrapeCounters {
+	return processCounters.read()
+}
+
+func (c *counters) read() ScrapeCounters {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return ScrapeCounters{
+		PagesSkipped:      c.pagesSkipped.Load(),
+		EmptySelectors:    maps.Clone(c.emptySelectors),
+		UnresolvedSymbols: c.unresolvedSymbols.Load(),
+	}
+}
+
+func (c *counters) emptySelector(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.emptySelectors == nil {
+		c.emptySelectors = make(map[string]int64)
+	}
+	c.emptySelectors[name]++
+}
+
+// WritePrometheus writes s as counters in the Prometheus text exposition format.
+func (s ScrapeCounters) WritePrometheus(w io.Writer) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# HELP gover_pages_skipped_total Release notes pages that could not be parsed.\n")
+	fmt.Fprintf(&buf, "# TYPE gover_pages_skipped_total counter\ngover_pages_skipped_total %d\n", s.PagesSkipped)
+	fmt.Fprintf(&buf, "# HELP gover_empty_selector_pages_total Release notes pages on which a selector matched nothing.\n")
+	fmt.Fprintf(&buf, "# TYPE gover_empty_selector_pages_total counter\n")
+	for _, name := range slices.Sorted(maps.Keys(s.EmptySelectors)) {
+		fmt.Fprintf(&buf, "gover_empty_selector_pages_total{selector=%q} %d\n", name, s.EmptySelectors[name])
+	}
+	fmt.Fprintf(&buf, "# HELP gover_unresolved_symbols_total Code spans of package entries naming no symbol of the package.\n")
+	fmt.Fprintf(&buf, "# TYPE gover_unresolved_symbols_total counter\ngover_unresolved_symbols_total %d\n", s.UnresolvedSymbols)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// since returns the counts added since before was read.
+func (s ScrapeCounters) since(before ScrapeCounters) ScrapeCounters {
+	d := ScrapeCounters{
+		PagesSkipped:      s.PagesSkipped - before.PagesSkipped,
+		UnresolvedSymbols: s.UnresolvedSymbols - before.UnresolvedSymbols,
+	}
+	for name, n := range s.EmptySelectors {
+		if n -= before.EmptySelectors[name]; n > 0 {
+			if d.EmptySelectors == nil {
+				d.EmptySelectors = make(map[string]int64)
+			}
+			d.EmptySelectors[name] = n
+		}
+	}
+	return d
+}
+
+// pageSkipped counts a release notes page that could not be parsed.
+func (c *Client) pageSkipped() {
+	c.counters.pagesSkipped.Add(1)
+	processCounters.pagesSkipped.Add(1)
+}
+
+// selectorEmpty counts a page on which the selector with the given name matched nothing.
+func (c *Client) selectorEmpty(name string) {
+	c.counters.emptySelector(name)
+	processCounters.emptySelector(name)
+}
+
+// symbolsUnresolved counts code spans naming no symbol.
+func (c *Client) symbolsUnresolved(n int) {
+	c.counters.unresolvedSymbols.Add(int64(n))
+	processCounters.unresolvedSymbols.Add(int64(n))
+}
+
+// identifierRe matches code spans that look like they name a Go identifier, such as
+// "Request.PathValue", "(*Client).Do" or "Cut()", as opposed to expressions, flags and
+// commands.
+var identifierRe = regexp.MustCompile(`^(?:\(\*?\w+\)\.)?[A-Za-z_]\w*(?:\.\w+)*(?:\(\))?$`)
+
+// countEmptySelectors counts the selectors matching nothing on the release notes page of
+// version.
+func (c *Client) countEmptySelectors(version string, page *goquery.Selection) {
+	selectors := map[string]goquery.Matcher{"title": c.sel.title, "section": c.sel.section, "content": c.sel.content}
+	if prof := c.sel.profile(version); prof != nil && prof.pkg != nil {
+		selectors["package"] = prof.pkg
+	}
+	for name, m := range selectors {
+		if page.FindMatcher(m).Length() == 0 {
+			c.selectorEmpty(name)
+		}
+	}
+}
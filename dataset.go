@@ -0,0 +1,128 @@
+package gover
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// Dataset is the envelope written by the scraper: the versions plus a summary of
+// how complete they are.
+type Dataset struct {
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Checksum    string          `json:"checksum,omitempty"` // of the versions, see ContentChecksum
+	Summary     ScrapeSummary   `json:"summary"`
+	Counters    *ScrapeCounters `json:"counters,omitempty"` // soft failures of the scrape that produced it
+	Versions    []VersionData   `json:"versions"`
+}
+
+// ScrapeSummary tells consumers which entries of a dataset are incomplete.
+type ScrapeSummary struct {
+	Versions int              `json:"versions"`          // number of entries
+	Complete int              `json:"complete"`          // entries without errors
+	Partial  []string         `json:"partial,omitempty"` // versions that were scraped with errors
+	Missing  []string         `json:"missing,omitempty"` // versions that could not be scraped at all
+	Quality  []VersionQuality `json:"quality,omitempty"` // completeness of each entry, see Completeness
+}
+
+// NewDataset wraps versions in a Dataset, summarizing their errors.
+func NewDataset(versions []VersionData) *Dataset {
+	ds := &Dataset{GeneratedAt: time.Now().UTC(), Versions: versions}
+	ds.Summary = summarize(versions)
+	ds.Checksum = ds.ContentChecksum()
+	return ds
+}
+
+// ContentChecksum returns the SHA-256 checksum of the JSON encoding of the dataset's
+// versions, as "sha256:" followed by the hex digest, which NewDataset records in
+// Checksum so that changes to the versions since can be detected, see VerifyDataset.
+func (ds *Dataset) ContentChecksum() string {
+	b, err := json.Marshal(ds.Versions)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// summarize counts the complete, partial and missing entries of versions and scores their
+// completeness.
+func summarize(versions []VersionData) ScrapeSummary {
+	s := ScrapeSummary{Versions: len(versions)}
+	for _, vd := range versions {
+		s.Quality = append(s.Quality, Completeness(vd))
+		switch {
+		case len(vd.Errors) == 0:
+			s.Complete++
+		case len(vd.Changes) == 0:
+			s.Missing = append(s.Missing, vd.Version)
+		default:
+			s.Partial = append(s.Partial, vd.Version)
+		}
+	}
+	return s
+}
+
+// Err returns the errors recorded for the dataset's versions, or nil if every entry is complete.
+// A non-nil error matches ErrIncomplete.
+func (ds *Dataset) Err() error {
+	var errs []error
+	for _, vd := range ds.Versions {
+		for _, e := range vd.Errors {
+			errs = append(errs, fmt.Errorf("%s: %s", vd.Version, e))
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return classified{errors.Join(errs...), ErrIncomplete}
+}
+
+// LoadDataset reads a dataset file. Files written before the envelope was introduced,
+// which hold a bare list of versions, are wrapped in a Dataset.
+func LoadDataset(path string) (*Dataset, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset: %w", err)
+	}
+	ds, err := decodeDataset(b)
+	if err != nil {
+		return nil, parseError(fmt.Errorf("failed to decode dataset %s: %w", path, err))
+	}
+	return ds, nil
+}
+
+// LoadFile reads the versions of a dataset file, see LoadDataset.
+func LoadFile(path string) ([]VersionData, error) {
+	ds, err := LoadDataset(path)
+	if err != nil {
+		return nil, err
+	}
+	return ds.Versions, nil
+}
+
+// decodeDataset decodes either a Dataset envelope or a bare list of versions.
+func decodeDataset(b []byte) (*Dataset, error) {
+	if trimmed := bytes.TrimSpace(b); len(trimmed) > 0 && trimmed[0] == '[' {
+		var versions []VersionData
+		if err := json.Unmarshal(trimmed, &versions); err != nil {
+			return nil, err
+		}
+		ds := &Dataset{Versions: versions}
+		ds.Summary = summarize(versions)
+		return ds, nil
+	}
+	var ds Dataset
+	if err := json.Unmarshal(b, &ds); err != nil {
+		return nil, err
+	}
+	if ds.Summary.Quality == nil { // written before completeness was scored
+		ds.Summary.Quality = summarize(ds.Versions).Quality
+	}
+	return &ds, nil
+}
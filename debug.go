@@ -0,0 +1,127 @@
+package gover
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// debugDump saves what a client fetched and parsed to a directory, see WithDebugDump.
+type debugDump struct {
+	dir string
+	log *log.Logger
+	sel *selectors
+}
+
+// selectorMatch lists what a selector matched on a page.
+type selectorMatch struct {
+	Selector string   `json:"selector"`
+	Count    int      `json:"count"`
+	Matches  []string `json:"matches,omitempty"` // id and text of each match, shortened
+}
+
+// pageTrace records how a release notes page was parsed.
+type pageTrace struct {
+	Version   string          `json:"version"`
+	URL       string          `json:"url"`
+	Profile   string          `json:"profile,omitempty"` // the parsing profile applied, see ProfileSelectors
+	Selectors []selectorMatch `json:"selectors"`
+}
+
+// maxTraceText is the length at which matched text is cut in a trace.
+const maxTraceText = 120
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// page saves the body of a fetched URL under pages/.
+func (d *debugDump) page(url string, status int, body []byte) {
+	name := strings.Trim(unsafePathChars.ReplaceAllString(strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://"), "_"), "_")
+	if status != http.StatusOK {
+		name += "." + http.StatusText(status)
+	}
+	d.write(filepath.Join("pages", name), body)
+}
+
+// version saves the selector matches of a version's release notes page and the data parsed from it.
+func (d *debugDump) version(vd VersionData, page *goquery.Selection) {
+	trace := pageTrace{Version: vd.Version, URL: vd.URL}
+	rn := d.sel.ReleaseNotes
+	// The selectors in the order parseVersionPage applies them.
+	selectors := []string{rn.Title, rn.Section, rn.Section + " + " + rn.Description}
+	if prof := d.sel.profile(vd.Version); prof != nil {
+		trace.Profile = prof.Name
+		for _, sel := range []string{prof.Subsection, prof.Package} {
+			if sel != "" {
+				selectors = append(selectors, sel)
+			}
+		}
+	}
+	for _, sel := range append(selectors, rn.Content) {
+		m := selectorMatch{Selector: sel}
+		if page != nil {
+			found := page.Find(sel)
+			m.Count = found.Length()
+			found.Each(func(_ int, s *goquery.Selection) {
+				text := strings.Join(strings.Fields(s.Text()), " ")
+				if len(text) > maxTraceText {
+					text = text[:maxTraceText] + "..."
+				}
+				if id, ok := s.Attr("id"); ok {
+					text = "#" + id + " " + text
+				}
+				m.Matches = append(m.Matches, text)
+			})
+		}
+		trace.Selectors = append(trace.Selectors, m)
+	}
+	d.writeJSON(filepath.Join(vd.Version, "selectors.json"), trace)
+	d.writeJSON(filepath.Join(vd.Version, "parsed.json"), vd)
+}
+
+func (d *debugDump) writeJSON(name string, v any) {
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, v); err != nil {
+		d.log.Printf("Warning: debug dump of %s failed: %v", name, err)
+		return
+	}
+	d.write(name, buf.Bytes())
+}
+
+func (d *debugDump) write(name string, data []byte) {
+	path := filepath.Join(d.dir, name)
+	err := os.MkdirAll(filepath.Dir(path), 0o755)
+	if err == nil {
+		err = os.WriteFile(path, data, 0o644)
+	}
+	if err != nil {
+		d.log.Printf("Warning: debug dump of %s failed: %v", name, err)
+	}
+}
+
+// dumpTransport saves every response it receives from next to a debugDump.
+type dumpTransport struct {
+	dump *debugDump
+	next http.RoundTripper
+}
+
+func (t dumpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	t.dump.page(req.URL.String(), resp.StatusCode, body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
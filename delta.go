@@ -0,0 +1,53 @@
+package gover
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Kinds of VersionDelta.
+const (
+	DeltaAdded   = "added"
+	DeltaRemoved = "removed"
+	DeltaEdited  = "edited"
+)
+
+// VersionDelta is a difference in source content between two scrapes of the same version.
+type VersionDelta struct {
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+// Delta compares the page fingerprints of two scrapes and reports the versions that were
+// added, removed, or whose release notes were edited on go.dev in between. Versions
+// without a fingerprint in either scrape cannot be compared and are not reported as edited.
+func Delta(previous, current []VersionData) []VersionDelta {
+	prev := make(map[string]VersionData, len(previous))
+	for _, vd := range previous {
+		prev[vd.Version] = vd
+	}
+	cur := make(map[string]bool, len(current))
+
+	var deltas []VersionDelta
+	for _, vd := range current {
+		cur[vd.Version] = true
+		old, ok := prev[vd.Version]
+		switch {
+		case !ok:
+			deltas = append(deltas, VersionDelta{vd.Version, DeltaAdded, fmt.Sprintf("%s was added since the last scrape", vd.Version)})
+		case old.Fingerprint != "" && vd.Fingerprint != "" && old.Fingerprint != vd.Fingerprint:
+			deltas = append(deltas, VersionDelta{vd.Version, DeltaEdited, fmt.Sprintf("go.dev edited the %s notes since the last scrape", vd.Version)})
+		}
+	}
+	for _, vd := range previous {
+		if !cur[vd.Version] {
+			deltas = append(deltas, VersionDelta{vd.Version, DeltaRemoved, fmt.Sprintf("%s is no longer present", vd.Version)})
+		}
+	}
+
+	slices.SortFunc(deltas, func(a, b VersionDelta) int {
+		return CompareVersions(a.Version, b.Version)
+	})
+	return deltas
+}
@@ -0,0 +1,430 @@
+package gover
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// deprecationsCategory is the category holding a release's harvested deprecations.
+const deprecationsCategory = "Deprecations"
+
+// Deprecation is an exported standard library identifier, or a whole package, whose doc
+// comment has a "Deprecated:" paragraph.
+type Deprecation struct {
+	Symbol  string `json:"symbol"`            // e.g. "net/http.CloseNotifier", or the import path of a package
+	Kind    string `json:"kind"`              // "package", "const", "var", "func", "type", "method" or "field"
+	Version string `json:"version,omitempty"` // the first release whose source has the note
+	Note    string `json:"note"`              // the text of the Deprecated paragraph
+}
+
+// HarvestDeprecations parses the standard library source in fsys, rooted at GOROOT/src,
+// and returns the deprecations noted in the doc comments of its exported identifiers.
+// Commands, internal and vendored packages, tests and testdata are skipped. Version is
+// left empty.
+func HarvestDeprecations(fsys fs.FS) ([]Deprecation, error) {
+	files := make(map[string]map[string][]byte)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name != "." && !stdlibDir(name) {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !stdlibFile(name) {
+			return nil
+		}
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		addSourceFile(files, name, b)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read standard library source: %w", err)
+	}
+	return harvestDeprecations(files), nil
+}
+
+// LocalDeprecations harvests the deprecations of the Go installation at goroot. Their
+// version is read from GOROOT/VERSION, and left empty for development builds.
+func LocalDeprecations(goroot string) ([]Deprecation, error) {
+	deps, err := HarvestDeprecations(os.DirFS(filepath.Join(goroot, "src")))
+	if err != nil {
+		return nil, err
+	}
+	version := ""
+	if b, err := os.ReadFile(filepath.Join(goroot, "VERSION")); err == nil {
+		line, _, _ := strings.Cut(string(b), "\n")
+		version = minorVersion(line)
+	}
+	for i := range deps {
+		deps[i].Version = version
+	}
+	return deps, nil
+}
+
+// StdlibDeprecations downloads the source of the first release of each minor version
+// matching versions from go.dev/dl and returns the deprecations found in it, each
+// attributed to the first release whose source has it. Releases without a source download
+// are skipped.
+func (c *Client) StdlibDeprecations(ctx context.Context, versions Constraint) ([]Deprecation, error) {
+	releases, err := c.Downloads(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sources := sourceDownloads(releases)
+	minors := slices.Collect(maps.Keys(sources))
+	slices.SortFunc(minors, CompareVersions)
+
+	var all []Deprecation
+	for _, v := range minors {
+		if !versions.Match(v) {
+			continue
+		}
+		file := sources[v]
+		c.opts.logger.Printf("Harvesting deprecations from %s", file.Filename)
+		body, err := c.get(ctx, c.url("/dl/"+file.Filename))
+		if err != nil {
+			return nil, fmt.Errorf("failed to download %s: %w", file.Filename, err)
+		}
+		deps, err := harvestTarball(body)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Filename, err)
+		}
+		for i := range deps {
+			deps[i].Version = v
+		}
+		all = append(all, deps...)
+	}
+	return MergeDeprecations(all), nil
+}
+
+// StdlibDeprecations is a shorthand for NewClient().StdlibDeprecations.
+func StdlibDeprecations(versions Constraint) ([]Deprecation, error) {
+	return NewClient().StdlibDeprecations(context.Background(), versions)
+}
+
+// LoadDeprecations reads a deprecation dataset written by "gover deprecations".
+func LoadDeprecations(path string) ([]Deprecation, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deprecations: %w", err)
+	}
+	var deps []Deprecation
+	if err := json.Unmarshal(b, &deps); err != nil {
+		return nil, parseError(fmt.Errorf("failed to decode deprecations %s: %w", path, err))
+	}
+	return deps, nil
+}
+
+// MergeDeprecations keeps the earliest version of each deprecated symbol, sorted by version
+// and symbol. Deprecations without a version sort last.
+func MergeDeprecations(deps []Deprecation) []Deprecation {
+	earliest := make(map[string]Deprecation)
+	for _, d := range deps {
+		if prev, ok := earliest[d.Symbol]; !ok || earlierVersion(d.Version, prev.Version) {
+			earliest[d.Symbol] = d
+		}
+	}
+	merged := make([]Deprecation, 0, len(earliest))
+	for _, d := range earliest {
+		merged = append(merged, d)
+	}
+	slices.SortFunc(merged, func(a, b Deprecation) int {
+		switch {
+		case a.Version == b.Version:
+			return strings.Compare(a.Symbol, b.Symbol)
+		case earlierVersion(a.Version, b.Version):
+			return -1
+		default:
+			return 1
+		}
+	})
+	return merged
+}
+
+// earlierVersion reports whether version a is known and older than b.
+func earlierVersion(a, b string) bool {
+	return a != "" && (b == "" || CompareVersions(a, b) < 0)
+}
+
+// AddDeprecations records each deprecation in a "Deprecations" category of the release that
+// introduced it, replacing any such category already present. Symbols become SymbolChanges
+// of type ChangeDeprecated; deprecated packages get a category of their own, so that
+// FindDeprecatedUses reports them precisely.
+func AddDeprecations(data []VersionData, deps []Deprecation) {
+	byVersion := make(map[string][]Deprecation)
+	for _, d := range deps {
+		byVersion[d.Version] = append(byVersion[d.Version], d)
+	}
+	for i := range data {
+		vd := &data[i]
+		vd.Changes = slices.DeleteFunc(vd.Changes, func(cat ChangeCategory) bool {
+			return cat.Category == deprecationsCategory
+		})
+		var changes []SymbolChange
+		for _, d := range byVersion[vd.Version] {
+			desc := d.Note
+			if d.Kind == "package" {
+				vd.Changes = append(vd.Changes, ChangeCategory{
+					Category:    deprecationsCategory,
+					Kind:        KindLibrary,
+					Type:        ChangeDeprecated,
+					Package:     d.Symbol,
+					Description: desc,
+					Impact:      classifyImpactPtr(ChangeDeprecated, desc),
+				})
+				continue
+			}
+			changes = append(changes, SymbolChange{
+				Type:        ChangeDeprecated,
+				Symbol:      d.Symbol,
+				Description: desc,
+				Impact:      classifyImpactPtr(ChangeDeprecated, desc),
+			})
+		}
+		if len(changes) > 0 {
+			vd.Changes = append(vd.Changes, ChangeCategory{
+				Category: deprecationsCategory,
+				Kind:     KindLibrary,
+				Type:     ChangeDeprecated,
+				Changes:  changes,
+			})
+		}
+		if len(byVersion[vd.Version]) > 0 {
+			vd.Changes = countCategoryTokens(vd.Changes)
+		}
+	}
+}
+
+// sourceDownloads returns the source archive of the first release of each minor version,
+// keyed by minor version.
+func sourceDownloads(releases []DownloadRelease) map[string]DownloadFile {
+	sources := make(map[string]DownloadFile)
+	first := make(map[string]string)
+	for _, r := range releases {
+		v := minorVersion(r.Version)
+		if v == "" || (first[v] != "" && CompareVersions(r.Version, first[v]) >= 0) {
+			continue
+		}
+		for _, f := range r.Files {
+			if f.Kind == "source" {
+				sources[v], first[v] = f, r.Version
+			}
+		}
+	}
+	return sources
+}
+
+// harvestTarball harvests the deprecations of a source archive from go.dev/dl.
+func harvestTarball(body []byte) ([]Deprecation, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read source archive: %w", err)
+	}
+	files := make(map[string]map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read source archive: %w", err)
+		}
+		name, ok := strings.CutPrefix(hdr.Name, "go/src/")
+		if !ok || hdr.Typeflag != tar.TypeReg || !stdlibFile(name) || !stdlibDir(path.Dir(name)) {
+			continue
+		}
+		b, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read source archive: %w", err)
+		}
+		addSourceFile(files, name, b)
+	}
+	return harvestDeprecations(files), nil
+}
+
+// stdlibDir reports whether dir, relative to GOROOT/src, may hold public standard library
+// packages.
+func stdlibDir(dir string) bool {
+	if dir == "." {
+		return true
+	}
+	for _, elem := range strings.Split(dir, "/") {
+		if elem == "internal" || elem == "vendor" || elem == "testdata" || strings.HasPrefix(elem, ".") || strings.HasPrefix(elem, "_") {
+			return false
+		}
+	}
+	return dir != "cmd" && !strings.HasPrefix(dir, "cmd/")
+}
+
+// stdlibFile reports whether name is a non-test Go file.
+func stdlibFile(name string) bool {
+	return strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+}
+
+func addSourceFile(files map[string]map[string][]byte, name string, b []byte) {
+	dir := path.Dir(name)
+	if files[dir] == nil {
+		files[dir] = make(map[string][]byte)
+	}
+	files[dir][path.Base(name)] = b
+}
+
+// harvestDeprecations returns the deprecations in the source files of each package
+// directory, keyed by import path.
+func harvestDeprecations(files map[string]map[string][]byte) []Deprecation {
+	var deps []Deprecation
+	for dir, srcs := range files {
+		if dir == "." {
+			continue
+		}
+		deps = append(deps, packageDeprecations(dir, srcs)...)
+	}
+	return MergeDeprecations(deps)
+}
+
+// packageDeprecations parses the files of one package and returns its deprecations.
+// Files that do not parse, or that belong to a generator rather than to the package,
+// are ignored.
+func packageDeprecations(importPath string, srcs map[string][]byte) []Deprecation {
+	fset := token.NewFileSet()
+	want := path.Base(importPath)
+	var files []*ast.File
+	for _, name := range slices.Sorted(maps.Keys(srcs)) {
+		f, err := parser.ParseFile(fset, name, srcs[name], parser.ParseComments)
+		if err != nil || f.Name.Name == "main" || f.Name.Name == "documentation" || isIgnored(f) {
+			continue
+		}
+		if f.Name.Name != want && len(files) > 0 && files[0].Name.Name != f.Name.Name {
+			continue
+		}
+		files = append(files, f)
+	}
+	if len(files) == 0 {
+		return nil
+	}
+	p, err := doc.NewFromFiles(fset, files, importPath)
+	if err != nil {
+		return nil
+	}
+
+	var deps []Deprecation
+	add := func(symbol, kind, text string) {
+		if note := deprecationNote(text); note != "" {
+			deps = append(deps, Deprecation{Symbol: symbol, Kind: kind, Note: note})
+		}
+	}
+	values := func(kind string, vals []*doc.Value) {
+		for _, v := range vals {
+			for _, spec := range v.Decl.Specs {
+				vs := spec.(*ast.ValueSpec)
+				text := v.Doc
+				if vs.Doc != nil {
+					text = vs.Doc.Text()
+				}
+				for _, n := range vs.Names {
+					if n.IsExported() {
+						add(importPath+"."+n.Name, kind, text)
+					}
+				}
+			}
+		}
+	}
+
+	add(importPath, "package", p.Doc)
+	values("const", p.Consts)
+	values("var", p.Vars)
+	for _, f := range p.Funcs {
+		add(importPath+"."+f.Name, "func", f.Doc)
+	}
+	for _, t := range p.Types {
+		name := importPath + "." + t.Name
+		add(name, "type", t.Doc)
+		values("const", t.Consts)
+		values("var", t.Vars)
+		for _, f := range t.Funcs {
+			add(importPath+"."+f.Name, "func", f.Doc)
+		}
+		for _, m := range t.Methods {
+			add(name+"."+m.Name, "method", m.Doc)
+		}
+		for _, spec := range t.Decl.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != t.Name {
+				continue
+			}
+			var fields *ast.FieldList
+			kind := "field"
+			switch typ := ts.Type.(type) {
+			case *ast.StructType:
+				fields = typ.Fields
+			case *ast.InterfaceType:
+				fields, kind = typ.Methods, "method"
+			}
+			if fields == nil {
+				continue
+			}
+			for _, field := range fields.List {
+				text := field.Doc.Text()
+				if text == "" {
+					text = field.Comment.Text()
+				}
+				for _, n := range field.Names {
+					if n.IsExported() {
+						add(name+"."+n.Name, kind, text)
+					}
+				}
+			}
+		}
+	}
+	return deps
+}
+
+// isIgnored reports whether f is excluded from every build by a "//go:build ignore" line.
+func isIgnored(f *ast.File) bool {
+	for _, cg := range f.Comments {
+		if cg.Pos() > f.Package {
+			break
+		}
+		for _, c := range cg.List {
+			if strings.HasPrefix(c.Text, "//go:build") && strings.Contains(c.Text, "ignore") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deprecationNote returns the "Deprecated:" paragraph of a doc comment, joined onto one
+// line and without the prefix, or "" if it has none.
+func deprecationNote(text string) string {
+	for _, para := range strings.Split(text, "\n\n") {
+		if note, ok := strings.CutPrefix(strings.TrimSpace(para), "Deprecated:"); ok {
+			return strings.Join(strings.Fields(note), " ")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+package gover
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Digest is a short summary of a release for posting in team channels on release day, see
+// NewDigest. The "markdown" and "slack" output formats render it as a message.
+type Digest struct {
+	Version     string      `json:"version"`
+	ReleaseDate string      `json:"releaseDate,omitempty"`
+	URL         string      `json:"url,omitempty"`
+	Highlights  []Highlight `json:"highlights"`
+	Security    []Highlight `json:"security,omitempty"`    // changes mentioning security fixes or vulnerabilities
+	NewPackages []string    `json:"newPackages,omitempty"` // import paths
+	Ports       []Highlight `json:"ports,omitempty"`
+}
+
+// securityCues are phrases of changes that concern security.
+var securityCues = []string{"security", "cve-", "vulnerab"}
+
+// NewDigest summarizes vd: its n most notable changes (all if n <= 0), and every change
+// concerning security, new package and port change. Changes listed under Security or Ports
+// are left out of Highlights.
+func NewDigest(vd VersionData, n int) Digest {
+	d := Digest{Version: vd.Version, ReleaseDate: vd.ReleaseDate, URL: vd.URL, Highlights: []Highlight{}}
+	for _, h := range Highlights(vd, 0) {
+		switch {
+		case containsAny(strings.ToLower(h.Text), securityCues...):
+			d.Security = append(d.Security, h)
+		case h.Kind == KindPorts && h.Symbol == "":
+			d.Ports = append(d.Ports, h)
+		case n <= 0 || len(d.Highlights) < n:
+			d.Highlights = append(d.Highlights, h)
+		}
+	}
+	for _, cat := range vd.Changes {
+		if announcesNewPackage(cat) {
+			d.NewPackages = append(d.NewPackages, cat.Package)
+		}
+	}
+	return d
+}
+
+// digestOf returns the Digest v holds, for the digest encoders.
+func digestOf(v any) (Digest, error) {
+	switch v := v.(type) {
+	case Digest:
+		return v, nil
+	case *Digest:
+		return *v, nil
+	}
+	return Digest{}, fmt.Errorf("output of type %T is not a digest", v)
+}
+
+// encodeMarkdown writes a Digest as a Markdown message.
+func encodeMarkdown(w io.Writer, v any) error {
+	d, err := digestOf(v)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "## %s", "Go "+strings.TrimPrefix(d.Version, "go"))
+	if d.ReleaseDate != "" {
+		fmt.Fprintf(bw, " (released %s)", d.ReleaseDate)
+	}
+	fmt.Fprintln(bw)
+	if d.URL != "" {
+		fmt.Fprintf(bw, "\n[Release notes](%s)\n", d.URL)
+	}
+	item := func(h Highlight) {
+		label := "**" + h.Category + "**"
+		if name := cmp.Or(h.Symbol, h.Package); name != "" {
+			label = "`" + name + "`"
+		}
+		if h.URL != "" {
+			fmt.Fprintf(bw, "- %s: %s ([more](%s))\n", label, h.Text, h.URL)
+		} else {
+			fmt.Fprintf(bw, "- %s: %s\n", label, h.Text)
+		}
+	}
+	section := func(title string, hs []Highlight) {
+		if len(hs) == 0 {
+			return
+		}
+		fmt.Fprintf(bw, "\n### %s\n\n", title)
+		for _, h := range hs {
+			item(h)
+		}
+	}
+	section("Highlights", d.Highlights)
+	section("Security", d.Security)
+	if len(d.NewPackages) > 0 {
+		fmt.Fprint(bw, "\n### New packages\n\n")
+		for _, pkg := range d.NewPackages {
+			fmt.Fprintf(bw, "- `%s`\n", pkg)
+		}
+	}
+	section("Ports", d.Ports)
+	return bw.Flush()
+}
+
+// encodeSlack writes a Digest as a Slack message in its mrkdwn markup.
+func encodeSlack(w io.Writer, v any) error {
+	d, err := digestOf(v)
+	if err != nil {
+		return err
+	}
+	esc := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace
+	link := func(url, text string) string {
+		if url == "" {
+			return text
+		}
+		return "<" + url + "|" + text + ">"
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "*%s*", link(d.URL, esc("Go "+strings.TrimPrefix(d.Version, "go"))))
+	if d.ReleaseDate != "" {
+		fmt.Fprintf(bw, " released %s", d.ReleaseDate)
+	}
+	fmt.Fprintln(bw)
+	section := func(title string, hs []Highlight) {
+		if len(hs) == 0 {
+			return
+		}
+		fmt.Fprintf(bw, "\n*%s*\n", title)
+		for _, h := range hs {
+			label := link(h.URL, esc(h.Category))
+			if name := cmp.Or(h.Symbol, h.Package); name != "" {
+				label = link(h.URL, "`"+esc(name)+"`")
+			}
+			fmt.Fprintf(bw, "• %s: %s\n", label, esc(h.Text))
+		}
+	}
+	section("Highlights", d.Highlights)
+	section("Security", d.Security)
+	if len(d.NewPackages) > 0 {
+		fmt.Fprint(bw, "\n*New packages*\n")
+		for _, pkg := range d.NewPackages {
+			fmt.Fprintf(bw, "• `%s`\n", esc(pkg))
+		}
+	}
+	section("Ports", d.Ports)
+	return bw.Flush()
+}
@@ -0,0 +1,107 @@
+package gover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// downloadsPath lists every Go release with its downloadable files.
+const downloadsPath = "/dl/?mode=json&include=all"
+
+// DownloadRelease is a release listed on go.dev/dl.
+type DownloadRelease struct {
+	Version string         `json:"version"` // e.g. "go1.21.3"
+	Stable  bool           `json:"stable"`
+	Files   []DownloadFile `json:"files"`
+}
+
+// DownloadFile is a single downloadable artifact of a release.
+type DownloadFile struct {
+	Filename string `json:"filename"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+	Version  string `json:"version"`
+	SHA256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+	Kind     string `json:"kind"` // "archive", "installer" or "source"
+}
+
+// FetchDownloads returns every release listed on go.dev/dl, newest first.
+// It is a shorthand for NewClient().Downloads.
+func FetchDownloads() ([]DownloadRelease, error) {
+	return NewClient().Downloads(context.Background())
+}
+
+// Downloads returns every release listed on go.dev/dl, newest first.
+func (c *Client) Downloads(ctx context.Context) ([]DownloadRelease, error) {
+	body, err := c.get(ctx, c.url(downloadsPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Go downloads: %w", err)
+	}
+
+	var releases []DownloadRelease
+	if err := json.Unmarshal(body, &releases); err != nil {
+		return nil, parseError(fmt.Errorf("failed to decode Go downloads: %w", err))
+	}
+	slices.SortStableFunc(releases, func(a, b DownloadRelease) int {
+		return CompareVersions(b.Version, a.Version)
+	})
+	return releases, nil
+}
+
+// downloadArchs maps the GOARCH values whose downloads go.dev/dl names differently.
+var downloadArchs = map[string]string{
+	"arm": "armv6l",
+}
+
+// ToolchainDownload is a downloadable file of a release for one platform, with what a
+// provisioning script needs to fetch and verify it.
+type ToolchainDownload struct {
+	Version  string `json:"version"`
+	Stable   bool   `json:"stable"`
+	Filename string `json:"filename"`
+	Kind     string `json:"kind"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+	URL      string `json:"url"`
+}
+
+// PlatformToolchains returns the files of kind ("archive", "installer" or "source") that
+// releases offer for goos and goarch, in the order of releases. Source archives are not
+// platform-specific, so goos and goarch are ignored for them.
+func PlatformToolchains(releases []DownloadRelease, goos, goarch, kind string) []ToolchainDownload {
+	if arch, ok := downloadArchs[goarch]; ok {
+		goarch = arch
+	}
+	var out []ToolchainDownload
+	for _, r := range releases {
+		for _, f := range r.Files {
+			if f.Kind != kind || kind != "source" && (f.OS != goos || f.Arch != goarch) {
+				continue
+			}
+			out = append(out, ToolchainDownload{
+				Version:  r.Version,
+				Stable:   r.Stable,
+				Filename: f.Filename,
+				Kind:     f.Kind,
+				Size:     f.Size,
+				SHA256:   f.SHA256,
+				URL:      defaultBaseURL + "/dl/" + f.Filename,
+			})
+		}
+	}
+	return out
+}
+
+// StableVersions returns the versions of the stable releases, newest first.
+func StableVersions(releases []DownloadRelease) []string {
+	var versions []string
+	for _, r := range releases {
+		if r.Stable {
+			versions = append(versions, r.Version)
+		}
+	}
+	return versions
+}
@@ -0,0 +1,57 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// tipURL serves the Go documentation at tip, where the release notes of the next release
+// are drafted until it comes out.
+const tipURL = "https://tip.golang.org"
+
+// DraftVersion fetches the draft release notes of version, a release still in
+// development such as go1.24 while go1.23 is the latest, from tip.golang.org or, with a
+// mirror as base URL, from the mirror. The result has no release date and is marked
+// Draft.
+func (c *Client) DraftVersion(ctx context.Context, version string) (VersionData, error) {
+	version = NormalizeVersion(version)
+	url := tipURL + "/doc/" + version
+	if c.opts.baseURL != defaultBaseURL {
+		url = c.url("/doc/" + version)
+	}
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return VersionData{}, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return VersionData{}, parseError(fmt.Errorf("failed to parse %s: %w", url, err))
+	}
+	vd := c.parsePageAt(url, version, "", doc.Selection)
+	if slices.Contains(vd.Errors, errNoSections) {
+		return VersionData{}, parseError(fmt.Errorf("no sections found at %s", url))
+	}
+	vd.Errors = slices.DeleteFunc(vd.Errors, func(e string) bool { return e == errNoReleaseDate })
+	vd.Draft = true
+	return vd, nil
+}
+
+// withDraft adds the draft release notes of the release after the latest, major, to
+// versions, newest first, if WithDrafts is set and they can be read.
+func (c *Client) withDraft(ctx context.Context, versions []VersionData, major int) []VersionData {
+	if !c.opts.drafts {
+		return versions
+	}
+	next := fmt.Sprintf("go1.%d", major+1)
+	vd, err := c.DraftVersion(ctx, next)
+	if err != nil {
+		c.opts.logger.Printf("Warning: no draft release notes for %s: %v", next, err)
+		return versions
+	}
+	c.opts.logger.Printf("Found draft release notes for %s", next)
+	return append([]VersionData{vd}, versions...)
+}
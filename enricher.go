@@ -0,0 +1,46 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ExecStage returns a stage running an external enricher command: it writes the versions
+// to the command's stdin as a JSON array and reads them back, enriched, from its stdout in
+// the same form. Enrichers can change any field, drop versions, and attach their own data
+// in the Extra fields, which gover keeps as is. The command fails the stage if it exits
+// with an error; the last line it wrote to stderr is reported with it.
+func ExecStage(name string, args ...string) Stage {
+	return Stage{filepath.Base(name), TransformFunc(func(ctx context.Context, data []VersionData) ([]VersionData, error) {
+		in, err := json.Marshal(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode input: %w", err)
+		}
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdin = bytes.NewReader(in)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			msg := strings.TrimSpace(stderr.String())
+			if msg == "" {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %s", err, msg[strings.LastIndex(msg, "\n")+1:])
+		}
+
+		if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+			return nil, parseError(fmt.Errorf("%s wrote no output", name))
+		}
+		var out []VersionData
+		if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+			return nil, parseError(fmt.Errorf("failed to decode output of %s: %w", name, err))
+		}
+		return out, nil
+	})}
+}
@@ -0,0 +1,29 @@
+package gover
+
+// Support is the support status of a major release. Under the Go release policy each major
+// release is supported until there are two newer major releases.
+type Support struct {
+	Version     string `json:"version"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	Supported   bool   `json:"supported"`
+	EOLDate     string `json:"eolDate,omitempty"` // when the second newer release came out
+}
+
+// SupportStatus reports the support status of each version in data, preserving its order.
+func SupportStatus(data []VersionData) []Support {
+	dates := make(map[int]string, len(data))
+	for _, vd := range data {
+		dates[parseVersionMinor(vd.Version)] = vd.ReleaseDate
+	}
+	out := make([]Support, 0, len(data))
+	for _, vd := range data {
+		eol, ended := dates[parseVersionMinor(vd.Version)+2]
+		out = append(out, Support{
+			Version:     vd.Version,
+			ReleaseDate: vd.ReleaseDate,
+			Supported:   !ended,
+			EOLDate:     eol,
+		})
+	}
+	return out
+}
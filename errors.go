@@ -0,0 +1,23 @@
+package gover
+
+import "errors"
+
+// Error categories. Errors returned by gover can be classified with errors.Is, e.g.
+// errors.Is(err, ErrNetwork) when go.dev could not be reached.
+var (
+	ErrIncomplete = errors.New("incomplete dataset") // some versions could not be fully scraped
+	ErrNetwork    = errors.New("network failure")    // a request failed or returned an error status
+	ErrParse      = errors.New("parse failure")      // a page or file did not have the expected content
+)
+
+// classified assigns an error to a category without changing its message.
+type classified struct {
+	error
+	kind error
+}
+
+func (e classified) Is(target error) bool { return target == e.kind }
+func (e classified) Unwrap() error        { return e.error }
+
+func networkError(err error) error { return classified{err, ErrNetwork} }
+func parseError(err error) error   { return classified{err, ErrParse} }
@@ -0,0 +1,159 @@
+package gover
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScrapeEstimate is what a scrape with a client's settings would take, see Client.Estimate.
+type ScrapeEstimate struct {
+	Versions    int           `json:"versions"`
+	Resumed     int           `json:"resumed,omitempty"`     // versions recorded in the checkpoint, see WithCheckpoint
+	Requests    int           `json:"requests"`              // pages the scrape would fetch
+	Cached      int           `json:"cached"`                // of those, answered from the cache or local files
+	Duration    time.Duration `json:"duration"`              // expected under the rate limits, not counting response times
+	MaxRequests int           `json:"maxRequests,omitempty"` // the request budget, see WithMaxRequests
+	Unestimated []string      `json:"unestimated,omitempty"` // steps fetching more pages than counted, depending on what the others find
+}
+
+// CacheHitRatio returns the share of the requests answered from the cache or local files.
+func (e ScrapeEstimate) CacheHitRatio() float64 {
+	if e.Requests == 0 {
+		return 0
+	}
+	return float64(e.Cached) / float64(e.Requests)
+}
+
+// ExceedsBudget reports whether the requests that are not cached exceed the request budget.
+func (e ScrapeEstimate) ExceedsBudget() bool {
+	return e.MaxRequests > 0 && e.Requests-e.Cached > e.MaxRequests
+}
+
+// Table implements Tabular.
+func (e ScrapeEstimate) Table() [][]string {
+	budget := "none"
+	if e.MaxRequests > 0 {
+		budget = strconv.Itoa(e.MaxRequests)
+		if e.ExceedsBudget() {
+			budget += " (exceeded)"
+		}
+	}
+	table := [][]string{
+		{"Estimate", "Value"},
+		{"Versions", strconv.Itoa(e.Versions)},
+		{"Resumed", strconv.Itoa(e.Resumed)},
+		{"Requests", strconv.Itoa(e.Requests)},
+		{"Cached", strconv.Itoa(e.Cached)},
+		{"Network", strconv.Itoa(e.Requests - e.Cached)},
+		{"Cache hit ratio", fmt.Sprintf("%.0f%%", 100*e.CacheHitRatio())},
+		{"Duration", e.Duration.Round(time.Second).String()},
+		{"Request budget", budget},
+	}
+	if len(e.Unestimated) > 0 {
+		table = append(table, []string{"Unestimated", strings.Join(e.Unestimated, ", ")})
+	}
+	return table
+}
+
+// Estimate returns what ScrapeDataset would take, without scraping: how many requests it
+// would make, how many of them the cache or a file base URL would answer, and how long
+// the rest would take under the parallelism, page delay and politeness settings. Only the
+// current version is fetched, to know how many versions there are. The URLs of
+// announcement posts are assumed to be /blog/<version>; the pages found by following the
+// CLs of the release notes (WithGerritCLs) and the later pages of GitHub listings are
+// not counted, but named in Unestimated.
+func (c *Client) Estimate(ctx context.Context) (*ScrapeEstimate, error) {
+	latestVersion, err := c.LatestVersion(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest Go version: %w", err)
+	}
+	majorVersion, err := extractMajorVersion(latestVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract major version: %w", err)
+	}
+	versions := generateVersionStrings(majorVersion)
+	e := &ScrapeEstimate{Versions: len(versions), MaxRequests: c.opts.maxRequests}
+
+	var pages, posts int // fetched from the network, each under its own rate limit
+	fetch := func(url string) bool {
+		e.Requests++
+		if c.isLocal(url) {
+			e.Cached++
+			return false
+		}
+		return true
+	}
+	fetch(c.url(goVersionsPath))
+	fetch(c.url(releaseHistoryPath))
+	done := make(map[string]bool)
+	if c.opts.checkpoint != "" && c.opts.resume {
+		data, err := loadCheckpoint(c.opts.checkpoint)
+		if err != nil {
+			return nil, err
+		}
+		for _, vd := range data {
+			if len(vd.Errors) == 0 && !done[vd.Version] {
+				done[vd.Version] = true
+				e.Resumed++
+			}
+		}
+	}
+	for _, v := range versions {
+		if !done[v] && fetch(c.url("/doc/"+v)) {
+			pages++
+		}
+	}
+	fetch(c.url(specPath))
+	if c.opts.announcements {
+		fetch(c.url(blogIndexPath))
+		fetch(c.url(blogFeedPath))
+		if c.opts.highlights || c.opts.contributions {
+			for _, v := range versions {
+				if fetch(c.url("/blog/" + v)) {
+					posts++
+				}
+			}
+		}
+	}
+	if c.opts.contributions {
+		fetch(c.githubURL(milestonesPath) + "&page=1")
+		e.Unestimated = append(e.Unestimated, "contributions")
+	}
+	if c.opts.milestones && c.opts.githubToken != "" {
+		fetch(c.githubURL(milestonesPath) + "&page=1")
+		e.Requests += len(versions) // one page of issues per milestone, at least
+		e.Unestimated = append(e.Unestimated, "milestone-issues")
+	}
+	if c.opts.apiExceptions {
+		fetch(fmt.Sprintf(apiFileURL, "except"))
+		for _, v := range versions {
+			fetch(fmt.Sprintf(apiFileURL, v))
+		}
+	}
+	if c.opts.gerritCLs {
+		e.Unestimated = append(e.Unestimated, "gerrit")
+	}
+
+	parallelism := max(c.opts.parallelism, 1)
+	e.Duration = time.Duration((pages+parallelism-1)/parallelism)*c.opts.pageDelay + time.Duration(posts)*postDelay
+	if polite := time.Duration(e.Requests-e.Cached) * (c.opts.delay + c.opts.jitter/2); polite > e.Duration {
+		e.Duration = polite
+	}
+	return e, nil
+}
+
+// isLocal reports whether url can be read without network access: from the cache, or
+// from the local file system with a file base URL.
+func (c *Client) isLocal(url string) bool {
+	if strings.HasPrefix(url, "file:") {
+		return true
+	}
+	if c.opts.cache == nil {
+		return false
+	}
+	_, ok := c.opts.cache.Get(url)
+	return ok
+}
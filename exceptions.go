@@ -0,0 +1,142 @@
+package gover
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// exceptionsCategory is the category holding a release's compatibility exceptions.
+const exceptionsCategory = "Compatibility exceptions"
+
+// APIException is an API declaration listed in api/except.txt: a change to the standard
+// library made under an exception to the Go 1 compatibility promise.
+type APIException struct {
+	Feature APIFeature `json:"feature"`       // the declaration before the change
+	Version string     `json:"version"`       // the release that changed it
+	Decl    string     `json:"decl"`          // the declaration after the change
+	Symbol  string     `json:"symbol"`        // e.g. "syscall.TOKEN_ALL_ACCESS"
+	Was     string     `json:"was,omitempty"` // the release that introduced the old declaration, if known
+}
+
+// FindAPIExceptions matches the entries of api/except.txt against the api files of each
+// release, keyed by version, to find the release in which each excepted declaration was
+// replaced. Entries whose symbol has no later declaration, such as APIs removed with a
+// port, are not reported.
+func FindAPIExceptions(except []APIFeature, features map[string][]APIFeature) []APIException {
+	versions := make([]string, 0, len(features))
+	for v := range features {
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, func(a, b string) int {
+		return cmp.Compare(parseVersionMinor(a), parseVersionMinor(b))
+	})
+
+	var exceptions []APIException
+	for _, ex := range except {
+		e := APIException{Feature: ex, Symbol: apiSymbol(ex)}
+		start := 0
+		if i := slices.IndexFunc(versions, func(v string) bool { return findDecl(features[v], ex, true) >= 0 }); i >= 0 {
+			e.Was, start = versions[i], i+1
+		}
+		for _, v := range versions[start:] {
+			if i := findDecl(features[v], ex, false); i >= 0 {
+				e.Version, e.Decl = v, features[v][i].Decl
+				exceptions = append(exceptions, e)
+				break
+			}
+		}
+	}
+	return exceptions
+}
+
+// findDecl returns the index of the feature declaring the same symbol as f, on the same
+// platform, with the same declaration if same is set or a different one otherwise, or -1.
+func findDecl(features []APIFeature, f APIFeature, same bool) int {
+	return slices.IndexFunc(features, func(g APIFeature) bool {
+		return g.Package == f.Package && g.Platform == f.Platform && g.Name == f.Name && (g.Decl == f.Decl) == same
+	})
+}
+
+// apiSymbol returns the symbol name used in SymbolChange for an API feature, writing
+// methods as "net/http.Client.Do" rather than "net/http.(*Client).Do".
+func apiSymbol(f APIFeature) string {
+	name := f.Name
+	if strings.HasPrefix(name, "(") {
+		if recv, method, ok := strings.Cut(name[1:], ")."); ok {
+			name = strings.TrimPrefix(recv, "*") + "." + method
+		}
+	}
+	return f.Package + "." + name
+}
+
+// AddAPIExceptions records each exception as a SymbolChange of type ChangeExcepted in a
+// "Compatibility exceptions" category of the release that made it, replacing any such
+// category already present.
+func AddAPIExceptions(data []VersionData, exceptions []APIException) {
+	byVersion := make(map[string][]SymbolChange)
+	for _, e := range exceptions {
+		platform := ""
+		if e.Feature.Platform != "" {
+			platform = " on " + e.Feature.Platform
+		}
+		desc := fmt.Sprintf("%s%s changed from %q to %q under an exception to the Go 1 compatibility promise", e.Symbol, platform, e.Feature.Decl, e.Decl)
+		byVersion[e.Version] = append(byVersion[e.Version], SymbolChange{
+			Type:        ChangeExcepted,
+			Symbol:      e.Symbol,
+			Description: desc,
+			Impact:      classifyImpactPtr(ChangeExcepted, desc),
+		})
+	}
+	for i := range data {
+		vd := &data[i]
+		vd.Changes = slices.DeleteFunc(vd.Changes, func(cat ChangeCategory) bool {
+			return cat.Category == exceptionsCategory
+		})
+		if changes := byVersion[vd.Version]; len(changes) > 0 {
+			vd.Changes = append(vd.Changes, ChangeCategory{
+				Category: exceptionsCategory,
+				Kind:     KindLibrary,
+				Type:     ChangeExcepted,
+				Changes:  changes,
+			})
+			vd.Changes = countCategoryTokens(vd.Changes)
+		}
+	}
+}
+
+// APIExceptions fetches api/except.txt and the api files of versions (which should
+// include "go1") and returns the exceptions they record.
+func (c *Client) APIExceptions(ctx context.Context, versions []string) ([]APIException, error) {
+	except, err := c.APIFeatures(ctx, "except")
+	if err != nil {
+		return nil, err
+	}
+	features := make(map[string][]APIFeature, len(versions))
+	for _, v := range versions {
+		f, err := c.APIFeatures(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		features[v] = f
+	}
+	return FindAPIExceptions(except, features), nil
+}
+
+// addAPIExceptions records the compatibility exceptions in data. Failing to fetch the api
+// files only loses the exceptions, so it is logged rather than returned.
+func (c *Client) addAPIExceptions(ctx context.Context, data []VersionData) {
+	versions := make([]string, 0, len(data))
+	for _, vd := range data {
+		versions = append(versions, vd.Version)
+	}
+	exceptions, err := c.APIExceptions(ctx, versions)
+	if err != nil {
+		c.opts.logger.Printf("Warning: compatibility exceptions not recorded: %v", err)
+		return
+	}
+	c.opts.logger.Printf("Found %d compatibility exceptions", len(exceptions))
+	AddAPIExceptions(data, exceptions)
+}
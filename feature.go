@@ -0,0 +1,200 @@
+package gover
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Feature kinds.
+const (
+	FeatureGoMod           = "go.mod"
+	FeatureGoWork          = "go.work"
+	FeatureBuildConstraint = "build-constraint" // build constraint syntax
+	FeatureBuildTag        = "build-tag"        // predefined build tags, including GOOS and GOARCH values
+	FeatureLanguage        = "language"         // language syntax and predeclared identifiers
+	FeatureGoCommand       = "go-command"       // subcommands and flags of the go command
+)
+
+// Feature is a piece of syntax, configuration or build tag and the release that introduced it,
+// answering "which Go do I need for this?".
+type Feature struct {
+	Name        string `json:"name"`    // e.g. "toolchain-directive"
+	Kind        string `json:"kind"`    // e.g. FeatureGoMod
+	Version     string `json:"version"` // the release that introduced the feature
+	Description string `json:"description"`
+}
+
+// Features lists the known features, oldest first. The release notes describe these in
+// prose that does not parse reliably, so the list is maintained by hand.
+var Features = []Feature{
+	{"plus-build-constraint", FeatureBuildConstraint, "go1", "\"// +build\" lines restrict the files included in a package"},
+	{"gc", FeatureBuildTag, "go1", "set when building with the gc toolchain"},
+	{"gccgo", FeatureBuildTag, "go1", "set when building with gccgo"},
+	{"cgo", FeatureBuildTag, "go1", "set when cgo is enabled"},
+	{"darwin", FeatureBuildTag, "go1", "GOOS=darwin"},
+	{"freebsd", FeatureBuildTag, "go1", "GOOS=freebsd"},
+	{"linux", FeatureBuildTag, "go1", "GOOS=linux"},
+	{"netbsd", FeatureBuildTag, "go1", "GOOS=netbsd"},
+	{"openbsd", FeatureBuildTag, "go1", "GOOS=openbsd"},
+	{"windows", FeatureBuildTag, "go1", "GOOS=windows"},
+	{"386", FeatureBuildTag, "go1", "GOARCH=386"},
+	{"amd64", FeatureBuildTag, "go1", "GOARCH=amd64"},
+	{"arm", FeatureBuildTag, "go1", "GOARCH=arm"},
+	{"go1.N-release-tags", FeatureBuildTag, "go1.1", "go1.1 and later release tags are set for the current and all earlier releases"},
+	{"go-race", FeatureGoCommand, "go1.1", "-race builds with the data race detector (go build, go test, go run, go install)"},
+	{"go-cover", FeatureGoCommand, "go1.2", "go test -cover reports test coverage"},
+	{"dragonfly", FeatureBuildTag, "go1.3", "GOOS=dragonfly"},
+	{"plan9", FeatureBuildTag, "go1.3", "GOOS=plan9"},
+	{"solaris", FeatureBuildTag, "go1.3", "GOOS=solaris"},
+	{"android", FeatureBuildTag, "go1.4", "GOOS=android; also satisfies the linux tag"},
+	{"go-generate", FeatureGoCommand, "go1.4", "go generate runs commands described by //go:generate directives"},
+	{"arm64", FeatureBuildTag, "go1.5", "GOARCH=arm64"},
+	{"ppc64", FeatureBuildTag, "go1.5", "GOARCH=ppc64"},
+	{"ppc64le", FeatureBuildTag, "go1.5", "GOARCH=ppc64le"},
+	{"go-buildmode", FeatureGoCommand, "go1.5", "-buildmode selects the kind of object file to build, such as c-shared or plugin"},
+	{"go-tool-trace", FeatureGoCommand, "go1.5", "go tool trace views execution traces"},
+	{"mips64", FeatureBuildTag, "go1.6", "GOARCH=mips64"},
+	{"mips64le", FeatureBuildTag, "go1.6", "GOARCH=mips64le"},
+	{"s390x", FeatureBuildTag, "go1.7", "GOARCH=s390x"},
+	{"mips", FeatureBuildTag, "go1.8", "GOARCH=mips"},
+	{"mipsle", FeatureBuildTag, "go1.8", "GOARCH=mipsle"},
+	{"type-alias", FeatureLanguage, "go1.9", "\"type T = U\" declares an alias for a type"},
+	{"go-build-cache", FeatureGoCommand, "go1.10", "build results are cached and reused; go clean -cache removes them"},
+	{"go-test-json", FeatureGoCommand, "go1.10", "go test -json writes test output as JSON events"},
+	{"go-test-failfast", FeatureGoCommand, "go1.10", "go test -failfast stops after the first failing test"},
+	{"module-directive", FeatureGoMod, "go1.11", "module declares the module path; go.mod files were introduced with modules"},
+	{"require-directive", FeatureGoMod, "go1.11", "require declares a minimum required version of a dependency"},
+	{"replace-directive", FeatureGoMod, "go1.11", "replace substitutes a module version or directory for a dependency"},
+	{"exclude-directive", FeatureGoMod, "go1.11", "exclude prevents a module version from being loaded"},
+	{"js", FeatureBuildTag, "go1.11", "GOOS=js"},
+	{"wasm", FeatureBuildTag, "go1.11", "GOARCH=wasm"},
+	{"go-mod", FeatureGoCommand, "go1.11", "go mod manages modules: init, tidy, vendor, download, graph, why, edit, verify"},
+	{"go-directive", FeatureGoMod, "go1.12", "go sets the language version the module is written for"},
+	{"aix", FeatureBuildTag, "go1.12", "GOOS=aix"},
+	{"illumos", FeatureBuildTag, "go1.13", "GOOS=illumos; also satisfies the solaris tag"},
+	{"binary-literal", FeatureLanguage, "go1.13", "integer literals with a 0b prefix"},
+	{"octal-literal", FeatureLanguage, "go1.13", "integer literals with a 0o prefix"},
+	{"hex-float-literal", FeatureLanguage, "go1.13", "floating-point literals in hexadecimal, such as 0x1p-2"},
+	{"digit-separator", FeatureLanguage, "go1.13", "underscores separating the digits of number literals"},
+	{"go-env-w", FeatureGoCommand, "go1.13", "go env -w sets default values of environment variables"},
+	{"go-trimpath", FeatureGoCommand, "go1.13", "-trimpath removes file system paths from the compiled binary"},
+	{"riscv64", FeatureBuildTag, "go1.14", "GOARCH=riscv64"},
+	{"go-modfile", FeatureGoCommand, "go1.14", "-modfile reads and writes an alternate go.mod file"},
+	{"retract-directive", FeatureGoMod, "go1.16", "retract marks versions of the module as not to be used"},
+	{"ios", FeatureBuildTag, "go1.16", "GOOS=ios; also satisfies the darwin tag"},
+	{"go-install-version", FeatureGoCommand, "go1.16", "go install pkg@version installs a command ignoring the current module"},
+	{"go-overlay", FeatureGoCommand, "go1.16", "-overlay replaces file paths used by the build with other files"},
+	{"deprecated-comment", FeatureGoMod, "go1.17", "a \"// Deprecated:\" comment on the module directive marks the module deprecated"},
+	{"go-build-constraint", FeatureBuildConstraint, "go1.17", "\"//go:build\" lines with boolean expressions, kept in sync with \"// +build\" lines by gofmt"},
+	{"go-run-version", FeatureGoCommand, "go1.17", "go run pkg@version runs a command ignoring the current module"},
+	{"go.work", FeatureGoWork, "go1.18", "go.work files define a multi-module workspace"},
+	{"use-directive", FeatureGoWork, "go1.18", "use adds a module directory to the workspace"},
+	{"go.work-replace-directive", FeatureGoWork, "go1.18", "replace in go.work overrides replacements of the workspace modules"},
+	{"type-parameters", FeatureLanguage, "go1.18", "generic functions and types"},
+	{"any", FeatureLanguage, "go1.18", "the predeclared alias any for interface{}"},
+	{"comparable", FeatureLanguage, "go1.18", "the predeclared comparable constraint"},
+	{"go-work", FeatureGoCommand, "go1.18", "go work manages workspaces: init, use, edit, sync"},
+	{"go-fuzz", FeatureGoCommand, "go1.18", "go test -fuzz runs fuzz tests"},
+	{"unix", FeatureBuildTag, "go1.19", "set for Unix-like GOOS values"},
+	{"loong64", FeatureBuildTag, "go1.19", "GOARCH=loong64"},
+	{"go-build-cover", FeatureGoCommand, "go1.20", "go build -cover builds coverage-instrumented binaries"},
+	{"go-pgo", FeatureGoCommand, "go1.20", "-pgo enables profile-guided optimization from a CPU profile; default.pgo is used automatically from go1.21"},
+	{"go-directive-patch-version", FeatureGoMod, "go1.21", "the go directive accepts release versions such as 1.21.0 and is a minimum requirement"},
+	{"toolchain-directive", FeatureGoMod, "go1.21", "toolchain suggests a Go toolchain to use; also allowed in go.work"},
+	{"wasip1", FeatureBuildTag, "go1.21", "GOOS=wasip1"},
+	{"min-max-builtins", FeatureLanguage, "go1.21", "the min and max built-in functions"},
+	{"clear-builtin", FeatureLanguage, "go1.21", "the clear built-in function"},
+	{"go-toolchain-switching", FeatureGoCommand, "go1.21", "GOTOOLCHAIN selects the toolchain, downloading newer ones a module requires"},
+	{"range-over-int", FeatureLanguage, "go1.22", "for range over an integer"},
+	{"go-work-vendor", FeatureGoCommand, "go1.22", "go work vendor vendors the dependencies of a workspace"},
+	{"godebug-directive", FeatureGoMod, "go1.23", "godebug sets GODEBUG defaults for the main module; also allowed in go.work"},
+	{"range-over-func", FeatureLanguage, "go1.23", "for range over an iterator function"},
+	{"go-telemetry", FeatureGoCommand, "go1.23", "go telemetry views and sets the telemetry mode"},
+	{"go-mod-tidy-diff", FeatureGoCommand, "go1.23", "go mod tidy -diff prints the changes it would make instead of making them"},
+	{"go-env-changed", FeatureGoCommand, "go1.23", "go env -changed prints only settings differing from their defaults"},
+	{"tool-directive", FeatureGoMod, "go1.24", "tool records executable dependencies run with go tool"},
+	{"generic-type-alias", FeatureLanguage, "go1.24", "type aliases with type parameters"},
+	{"go-tool", FeatureGoCommand, "go1.24", "go tool runs the tools a module records with tool directives"},
+	{"go-build-json", FeatureGoCommand, "go1.24", "go build -json writes build output and failures as JSON"},
+	{"ignore-directive", FeatureGoMod, "go1.25", "ignore excludes directories from package patterns"},
+}
+
+// AvailableIn reports whether the feature can be used with the given release, e.g. "go1.20".
+func (f Feature) AvailableIn(version string) bool {
+	return CompareVersions(NormalizeVersion(version), f.Version) >= 0
+}
+
+// FeaturesOfKind returns the features of the given kind, oldest first.
+func FeaturesOfKind(kind string) []Feature {
+	var out []Feature
+	for _, f := range Features {
+		if f.Kind == kind {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// LookupFeature finds the features named by query, which may be a full name such as
+// "toolchain-directive", part of one such as "toolchain", or a go command line such as
+// "go build -pgo". When nothing matches, the
+// closest feature names are returned as did-you-mean suggestions.
+func LookupFeature(query string) ([]Feature, []string) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return nil, nil
+	}
+	for _, name := range commandFeatureNames(q) {
+		for _, f := range Features {
+			if f.Name == name {
+				return []Feature{f}, nil
+			}
+		}
+	}
+
+	var matches []Feature
+	for _, f := range Features {
+		if strings.Contains(f.Name, q) {
+			matches = append(matches, f)
+		}
+	}
+	if len(matches) > 0 {
+		return matches, nil
+	}
+
+	type suggestion struct {
+		name string
+		dist int
+	}
+	var close []suggestion
+	for _, f := range Features {
+		d := levenshtein(q, f.Name)
+		for _, part := range strings.Split(f.Name, "-") {
+			d = min(d, levenshtein(q, part))
+		}
+		if d <= max(2, len(q)/3) {
+			close = append(close, suggestion{f.Name, d})
+		}
+	}
+	slices.SortStableFunc(close, func(a, b suggestion) int { return cmp.Compare(a.dist, b.dist) })
+	var suggestions []string
+	for _, s := range close[:min(len(close), maxSuggestions)] {
+		suggestions = append(suggestions, s.name)
+	}
+	return nil, suggestions
+}
+
+// commandFeatureNames returns the feature names a query may stand for: the query itself and,
+// for a go command line such as "go build -pgo", "go-build-pgo" and "go-pgo".
+func commandFeatureNames(q string) []string {
+	names := []string{q}
+	words := strings.Fields(q)
+	if len(words) < 2 || words[0] != "go" {
+		return names
+	}
+	for i, w := range words {
+		words[i] = strings.TrimLeft(w, "-")
+	}
+	return append(names, strings.Join(words, "-"), "go-"+words[len(words)-1])
+}
@@ -0,0 +1,134 @@
+package gover
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// Encoder writes v to w in a particular output format.
+type Encoder func(w io.Writer, v any) error
+
+// The built-in formats. Formats needing heavy dependencies, such as protobuf, register
+// themselves with RegisterFormat from a file built only with a gover_<format> build tag,
+// so that only those who need them compile them in.
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]Encoder{
+		"csv":      encodeCSV,
+		"dot":      encodeDot,
+		"finetune": encodeFinetune,
+		"github":   encodeGitHub,
+		"html":     encodeHTML,
+		"json":     encodeJSON,
+		"markdown": encodeMarkdown,
+		"mermaid":  encodeMermaid,
+		"sarif":    encodeSARIF,
+		"slack":    encodeSlack,
+		"table":    encodeTable,
+		"toml":     encodeTOML,
+		"xml":      encodeXML,
+	}
+)
+
+// Tabular is implemented by values that are a single table, such as a Comparison. The
+// table, csv and html formats render them as such; csv and html render nothing else.
+type Tabular interface {
+	// Table returns the rows of the table, the header first.
+	Table() [][]string
+}
+
+// RegisterFormat makes an encoder available under name, replacing any existing encoder with that name.
+func RegisterFormat(name string, enc Encoder) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[name] = enc
+}
+
+// Formats returns the names of the registered output formats, sorted.
+func Formats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Encode writes v to w using the encoder registered for format.
+func Encode(w io.Writer, format string, v any) error {
+	formatsMu.RLock()
+	enc, ok := formats[format]
+	formatsMu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown output format %q (want one of %v)", format, Formats())
+	}
+	return enc(w, v)
+}
+
+func encodeJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// field is a member of an object in a tree built by jsonTree.
+type field struct {
+	key   string
+	value any
+}
+
+// jsonTree converts v into a generic tree that keeps the field names and order of its
+// JSON encoding, so that other encoders honor the same struct tags. Objects become
+// []field, arrays []any, numbers json.Number, and strings, booleans and null their Go
+// equivalents.
+func jsonTree(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return readTree(dec)
+}
+
+func readTree(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := []field{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := readTree(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, field{key: key.(string), value: value})
+		}
+		_, err := dec.Token() // '}'
+		return obj, err
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			value, err := readTree(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err := dec.Token() // ']'
+		return arr, err
+	}
+	return tok, nil
+}
@@ -0,0 +1,20 @@
+package gover
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// encodeCSV writes a Tabular value as CSV, header first.
+func encodeCSV(w io.Writer, v any) error {
+	t, ok := v.(Tabular)
+	if !ok {
+		return fmt.Errorf("output of type %T is not a table", v)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(t.Table()); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
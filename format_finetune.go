@@ -0,0 +1,135 @@
+package gover
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// FinetuneExample is an instruction and response pair generated from release notes, for
+// fine-tuning language models. Version and URL cite the release notes it comes from.
+type FinetuneExample struct {
+	Instruction string `json:"instruction"`
+	Response    string `json:"response"`
+	Version     string `json:"version"`
+	URL         string `json:"url,omitempty"`
+}
+
+// finetuneVerbs phrase the change types in responses about symbols.
+var finetuneVerbs = map[ChangeType]string{
+	ChangeAdded:      "was added",
+	ChangeChanged:    "changed",
+	ChangeDeprecated: "was deprecated",
+	ChangeRemoved:    "was removed",
+	ChangeExcepted:   "was exempted from the compatibility promise",
+}
+
+// FinetuneExamples generates instruction and response pairs from data: for each version an
+// overview of its release notes, and for each section, package and symbol change the
+// question it answers, such as "What changed in net/http in Go 1.22?". The pairs are taken
+// verbatim from the release notes, in the order of data, so the same data always yields the
+// same examples. Boilerplate sections are left out.
+func FinetuneExamples(data []VersionData) []FinetuneExample {
+	filter := SectionFilter{Deny: DefaultSectionDeny}
+	var examples []FinetuneExample
+	for _, vd := range data {
+		name := "Go " + strings.TrimPrefix(vd.Version, "go")
+		var sections []string
+		packages := 0
+		for _, cat := range vd.Changes {
+			if cat.Boilerplate || cat.Category == overviewCategory || filter.IsBoilerplate(cat.Category) {
+				continue
+			}
+			var question string
+			if cat.Package != "" {
+				packages++
+				question = fmt.Sprintf("What changed in %s in %s?", cat.Package, name)
+			} else {
+				sections = append(sections, cat.Category)
+				question = fmt.Sprintf("What do the %s release notes say about %q?", name, cat.Category)
+			}
+			lines := []string{collapseSpace(cat.Description)}
+			for _, sc := range cat.Changes {
+				if sc.Description != "" {
+					lines = append(lines, fmt.Sprintf("- %s: %s", sc.Symbol, collapseSpace(sc.Description)))
+				}
+			}
+			if response := joinNonEmpty(lines); response != "" {
+				examples = append(examples, FinetuneExample{Instruction: question, Response: response, Version: vd.Version, URL: cat.URL})
+			}
+			for _, sc := range cat.Changes {
+				if sc.Symbol == "" || sc.Description == "" {
+					continue
+				}
+				verb, ok := finetuneVerbs[NormalizeChangeType(sc.Type)]
+				if !ok {
+					verb = finetuneVerbs[ChangeChanged]
+				}
+				examples = append(examples, FinetuneExample{
+					Instruction: fmt.Sprintf("What changed about %s in %s?", sc.Symbol, name),
+					Response:    fmt.Sprintf("In %s, %s %s. %s", name, sc.Symbol, verb, collapseSpace(sc.Description)),
+					Version:     vd.Version,
+					URL:         cat.URL,
+				})
+			}
+		}
+		topics := dedupe(sections)
+		if packages > 0 {
+			topics = append(topics, fmt.Sprintf("changes to %d packages", packages))
+		}
+		if len(topics) == 0 {
+			continue
+		}
+		overview := name + " release notes cover "
+		if vd.ReleaseDate != "" {
+			overview = name + " was released on " + vd.ReleaseDate + ". Its release notes cover "
+		}
+		examples = append(examples, FinetuneExample{
+			Instruction: fmt.Sprintf("What changed in %s?", name),
+			Response:    overview + strings.Join(topics, ", ") + ".",
+			Version:     vd.Version,
+			URL:         vd.URL,
+		})
+	}
+	return examples
+}
+
+// dedupe returns the distinct strings of s, in order of first appearance.
+func dedupe(s []string) []string {
+	seen := make(map[string]bool, len(s))
+	out := s[:0:0]
+	for _, v := range s {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// encodeFinetune writes release notes as JSON Lines of FinetuneExamples. v must be a
+// dataset or a slice of versions.
+func encodeFinetune(w io.Writer, v any) error {
+	var data []VersionData
+	switch v := v.(type) {
+	case []VersionData:
+		data = v
+	case *Dataset:
+		data = v.Versions
+	case VersionData:
+		data = []VersionData{v}
+	case *VersionData:
+		data = []VersionData{*v}
+	default:
+		return fmt.Errorf("output of type %T is not release notes", v)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, e := range FinetuneExamples(data) {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package gover
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// encodeGitHub writes findings as GitHub Actions workflow commands, which GitHub shows as
+// annotations on the lines concerned. v must be Annotated or a slice of Annotated values.
+func encodeGitHub(w io.Writer, v any) error {
+	anns, err := annotations(v)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	for _, a := range anns {
+		props := []string{}
+		if a.File != "" {
+			props = append(props, "file="+escapeGitHubProperty(a.File))
+		}
+		if a.Line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", a.Line))
+		}
+		if a.Column > 0 {
+			props = append(props, fmt.Sprintf("col=%d", a.Column))
+		}
+		if a.Title != "" {
+			props = append(props, "title="+escapeGitHubProperty(a.Title))
+		}
+		level := a.Level
+		if level != LevelError {
+			level = LevelWarning
+		}
+		fmt.Fprintf(bw, "::%s %s::%s\n", level, strings.Join(props, ","), escapeGitHubData(a.Message))
+	}
+	return bw.Flush()
+}
+
+// escapeGitHubData escapes the message of a workflow command.
+func escapeGitHubData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// escapeGitHubProperty escapes a property value of a workflow command.
+func escapeGitHubProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
@@ -0,0 +1,36 @@
+package gover
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+)
+
+// encodeHTML writes a Tabular value as a standalone HTML page holding the table, for
+// pasting into documents or serving as is.
+func encodeHTML(w io.Writer, v any) error {
+	t, ok := v.(Tabular)
+	if !ok {
+		return fmt.Errorf("output of type %T is not a table", v)
+	}
+	rows := t.Table()
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "<!DOCTYPE html>")
+	fmt.Fprintln(bw, `<html><head><meta charset="utf-8"><style>table{border-collapse:collapse}th,td{border:1px solid #ccc;padding:2px 8px}td+td{text-align:center}</style></head><body>`)
+	fmt.Fprintln(bw, "<table>")
+	for i, row := range rows {
+		cell := "td"
+		if i == 0 {
+			cell = "th"
+		}
+		fmt.Fprint(bw, "<tr>")
+		for _, s := range row {
+			fmt.Fprintf(bw, "<%s>%s</%s>", cell, html.EscapeString(s), cell)
+		}
+		fmt.Fprintln(bw, "</tr>")
+	}
+	fmt.Fprintln(bw, "</table>")
+	fmt.Fprintln(bw, "</body></html>")
+	return bw.Flush()
+}
@@ -0,0 +1,44 @@
+//go:build gover_protobuf
+
+package gover
+
+import (
+	"encoding/json"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// The protobuf format is only compiled in with the gover_protobuf build tag, so that the
+// default binary does not carry the protobuf runtime:
+//
+//	go build -tags gover_protobuf ./cmd/gover
+func init() {
+	RegisterFormat("protobuf", encodeProtobuf)
+}
+
+// encodeProtobuf writes the JSON form of v as a google.protobuf.Value message in the
+// protobuf binary format, which any protobuf library reads with the well-known
+// google/protobuf/struct.proto, without a schema of gover's own. Map entries are written
+// in a deterministic order, so that equal data gives equal bytes.
+func encodeProtobuf(w io.Writer, v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var tree any
+	if err := json.Unmarshal(b, &tree); err != nil {
+		return err
+	}
+	pv, err := structpb.NewValue(tree)
+	if err != nil {
+		return err
+	}
+	out, err := proto.MarshalOptions{Deterministic: true}.Marshal(pv)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
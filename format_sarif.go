@@ -0,0 +1,127 @@
+package gover
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolURI = "https://github.com/paulstuart/gover"
+)
+
+// sarifLog is the subset of the SARIF 2.1.0 log format written by encodeSARIF.
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// encodeSARIF writes findings as a SARIF log, the format read by code scanning dashboards
+// such as GitHub's. v must be Annotated or a slice of Annotated values.
+func encodeSARIF(w io.Writer, v any) error {
+	anns, err := annotations(v)
+	if err != nil {
+		return err
+	}
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "gover",
+			InformationURI: sarifToolURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+	ruleIndex := map[string]int{}
+	for _, a := range anns {
+		i, ok := ruleIndex[a.Rule]
+		if !ok {
+			i = len(run.Tool.Driver.Rules)
+			ruleIndex[a.Rule] = i
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: a.Rule})
+		}
+		level := a.Level
+		if level != LevelError {
+			level = LevelWarning
+		}
+		r := sarifResult{RuleID: a.Rule, RuleIndex: i, Level: level, Message: sarifMessage{Text: a.Message}}
+		if a.File != "" {
+			loc := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifURI(a.File)}}
+			if a.Line > 0 {
+				loc.Region = &sarifRegion{StartLine: a.Line, StartColumn: a.Column}
+			}
+			r.Locations = []sarifLocation{{PhysicalLocation: loc}}
+		}
+		run.Results = append(run.Results, r)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{Version: sarifVersion, Schema: sarifSchema, Runs: []sarifRun{run}})
+}
+
+// sarifURI returns the artifact URI of a file: a relative reference for relative paths,
+// which code scanning resolves against the repository root, or a file URI otherwise.
+func sarifURI(path string) string {
+	slashed := filepath.ToSlash(filepath.Clean(path))
+	if filepath.IsAbs(path) {
+		if !strings.HasPrefix(slashed, "/") {
+			slashed = "/" + slashed
+		}
+		return (&url.URL{Scheme: "file", Path: slashed}).String()
+	}
+	return (&url.URL{Path: slashed}).String()
+}
@@ -0,0 +1,179 @@
+package gover
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// maxCellWidth caps the width of table cells; longer values are cut with an ellipsis.
+const maxCellWidth = 60
+
+// encodeTable writes v as aligned text tables for reading in a terminal. An array of objects
+// becomes one table with a column per field; an object lists its scalar fields and then a
+// table per array field. Within a table, arrays are shown as their length and objects as
+// their first field. A Tabular value is written as its table. Headers are highlighted when w
+// is a terminal, unless NO_COLOR is set.
+func encodeTable(w io.Writer, v any) error {
+	if t, ok := v.(Tabular); ok {
+		bw := bufio.NewWriter(w)
+		if rows := t.Table(); len(rows) > 0 {
+			writeRows(bw, rows[0], rows[1:], isTerminal(w))
+		}
+		return bw.Flush()
+	}
+	tree, err := jsonTree(v)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	color := isTerminal(w)
+	switch t := tree.(type) {
+	case []any:
+		writeTable(bw, t, color)
+	case []field:
+		var props [][]string
+		var tables []field
+		for _, f := range t {
+			if arr, ok := f.value.([]any); ok {
+				tables = append(tables, field{key: f.key, value: arr})
+				continue
+			}
+			props = append(props, []string{f.key + ":", cellText(f.value)})
+		}
+		writeRows(bw, nil, props, false)
+		for _, f := range tables {
+			fmt.Fprintf(bw, "\n%s\n", highlight(f.key, color))
+			writeTable(bw, f.value.([]any), color)
+		}
+	default:
+		fmt.Fprintln(bw, cellText(tree))
+	}
+	return bw.Flush()
+}
+
+// writeTable writes the elements of arr as table rows, with a column per object field.
+func writeTable(w *bufio.Writer, arr []any, color bool) {
+	if len(arr) == 0 {
+		fmt.Fprintln(w, "(none)")
+		return
+	}
+	// Fields missing from earlier rows are placed after the field that precedes them.
+	var columns []string
+	for _, el := range arr {
+		obj, ok := el.([]field)
+		if !ok {
+			obj = []field{{key: "value", value: el}}
+		}
+		at := 0
+		for _, f := range obj {
+			if i := slices.Index(columns, f.key); i >= 0 {
+				at = i + 1
+				continue
+			}
+			columns = slices.Insert(columns, at, f.key)
+			at++
+		}
+	}
+	index := make(map[string]int, len(columns))
+	for i, c := range columns {
+		index[c] = i
+	}
+	rows := make([][]string, 0, len(arr))
+	for _, el := range arr {
+		obj, ok := el.([]field)
+		if !ok {
+			obj = []field{{key: "value", value: el}}
+		}
+		row := make([]string, len(columns))
+		for _, f := range obj {
+			row[index[f.key]] = cellText(f.value)
+		}
+		rows = append(rows, row)
+	}
+	writeRows(w, columns, rows, color)
+}
+
+// writeRows writes rows as space-aligned columns, preceded by the header if there is one.
+func writeRows(w *bufio.Writer, header []string, rows [][]string, color bool) {
+	widths := make([]int, 0)
+	measure := func(row []string) {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	measure(header)
+	for _, row := range rows {
+		measure(row)
+	}
+	write := func(row []string, bold bool) {
+		var b strings.Builder
+		for i, cell := range row {
+			if i < len(row)-1 {
+				cell += strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2)
+			}
+			b.WriteString(cell)
+		}
+		line := strings.TrimRight(b.String(), " ")
+		if bold {
+			line = highlight(line, color)
+		}
+		fmt.Fprintln(w, line)
+	}
+	if header != nil {
+		write(header, true)
+	}
+	for _, row := range rows {
+		write(row, false)
+	}
+}
+
+// cellText renders a tree value as a single-line table cell.
+func cellText(v any) string {
+	var s string
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case []any:
+		return fmt.Sprintf("[%d]", len(t))
+	case []field:
+		if len(t) == 0 {
+			return ""
+		}
+		return cellText(t[0].value)
+	default:
+		s = strings.Join(strings.Fields(fmt.Sprint(t)), " ")
+	}
+	if utf8.RuneCountInString(s) > maxCellWidth {
+		s = string([]rune(s)[:maxCellWidth-1]) + "…"
+	}
+	return s
+}
+
+// highlight makes s bold if color is enabled.
+func highlight(s string, color bool) string {
+	if !color {
+		return s
+	}
+	return "\x1b[1m" + s + "\x1b[0m"
+}
+
+// isTerminal reports whether w is a terminal that should receive colored output.
+func isTerminal(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,115 @@
+package gover
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// encodeMermaid writes a Timeline, or the releases of a release history, as a Mermaid
+// Gantt chart: a section per release line, its support window as a bar and its patch
+// releases as milestones. The pinned release is marked critical.
+func encodeMermaid(w io.Writer, v any) error {
+	t, ok := timelineOf(v)
+	if !ok {
+		return fmt.Errorf("output of type %T is not a release timeline", v)
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "gantt")
+	fmt.Fprintln(bw, "    title Go release lines")
+	fmt.Fprintln(bw, "    dateFormat YYYY-MM-DD")
+	fmt.Fprintln(bw, "    axisFormat %Y")
+	for _, l := range t.Lines {
+		fmt.Fprintf(bw, "    section %s\n", l.Version)
+		tags := "done"
+		if l.Supported() {
+			tags = "active"
+		}
+		if t.pinsLine(l) {
+			tags = "crit, " + tags
+		}
+		fmt.Fprintf(bw, "    %s :%s, %s, %s, %s\n", supportLabel(l), tags, releaseID(l.Version), l.Date, l.End(t))
+		for _, p := range l.Patches {
+			tags := "milestone"
+			if p.Version == t.Pinned {
+				tags = "crit, " + tags
+			}
+			fmt.Fprintf(bw, "    %s :%s, %s, %s, 0d\n", patchLabel(p), tags, releaseID(p.Version), p.Date)
+		}
+	}
+	return bw.Flush()
+}
+
+// encodeDot writes a Timeline, or the releases of a release history, as a Graphviz graph:
+// a cluster per release line, labeled with its support window, chaining the line to its
+// patch releases, with security releases outlined in red and the pinned release filled.
+func encodeDot(w io.Writer, v any) error {
+	t, ok := timelineOf(v)
+	if !ok {
+		return fmt.Errorf("output of type %T is not a release timeline", v)
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph releases {")
+	fmt.Fprintln(bw, "  rankdir=LR;")
+	fmt.Fprintln(bw, "  node [shape=box, fontname=\"Helvetica\"];")
+	for i, l := range t.Lines {
+		fmt.Fprintf(bw, "  subgraph cluster_%s {\n", releaseID(l.Version))
+		fmt.Fprintf(bw, "    label=%q;\n", l.Version+": "+supportLabel(l)+" "+l.Date+" to "+l.End(t))
+		if !l.Supported() {
+			fmt.Fprintln(bw, "    color=gray; fontcolor=gray;")
+		}
+		fmt.Fprintf(bw, "    %q [label=%q%s];\n", l.Version, l.Version+"\n"+l.Date, dotStyle(t.pinsLine(l), false))
+		prev := l.Version
+		for _, p := range l.Patches {
+			fmt.Fprintf(bw, "    %q [label=%q%s];\n", p.Version, p.Version+"\n"+p.Date, dotStyle(p.Version == t.Pinned, p.Security))
+			fmt.Fprintf(bw, "    %q -> %q;\n", prev, p.Version)
+			prev = p.Version
+		}
+		fmt.Fprintln(bw, "  }")
+		if i > 0 {
+			fmt.Fprintf(bw, "  %q -> %q [style=bold];\n", t.Lines[i-1].Version, l.Version)
+		}
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// pinsLine reports whether the pinned release is the initial release of l.
+func (t *Timeline) pinsLine(l TimelineLine) bool {
+	return t.Pinned == l.Version || t.Pinned == l.Version+".0"
+}
+
+// supportLabel describes the support window of l.
+func supportLabel(l TimelineLine) string {
+	if l.Supported() {
+		return "supported"
+	}
+	return "ended"
+}
+
+// patchLabel names a patch release, flagging security fixes.
+func patchLabel(p Patch) string {
+	if p.Security {
+		return p.Version + " security"
+	}
+	return p.Version
+}
+
+// releaseID turns a release name into an identifier for Mermaid tasks and Graphviz
+// clusters, "go1_22_1" for "go1.22.1".
+func releaseID(version string) string {
+	return strings.ReplaceAll(version, ".", "_")
+}
+
+// dotStyle returns the attributes of a release node.
+func dotStyle(pinned, security bool) string {
+	var attrs string
+	if security {
+		attrs += ", color=red"
+	}
+	if pinned {
+		attrs += ", style=filled, fillcolor=gold"
+	}
+	return attrs
+}
@@ -0,0 +1,154 @@
+package gover
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// tomlBareKeyRe matches keys that need no quoting in TOML.
+var tomlBareKeyRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// encodeTOML writes v as a TOML document, using the JSON field names as keys. TOML
+// documents are tables, so a top-level array is written as an array of tables named
+// "items". Null values are omitted, as TOML has no null.
+func encodeTOML(w io.Writer, v any) error {
+	tree, err := jsonTree(v)
+	if err != nil {
+		return err
+	}
+	obj, ok := tree.([]field)
+	if !ok {
+		obj = []field{{key: "items", value: tree}}
+	}
+	bw := bufio.NewWriter(w)
+	writeTOMLTable(bw, nil, obj)
+	return bw.Flush()
+}
+
+// writeTOMLTable writes the key/value pairs of obj, then its sub-tables and arrays of tables.
+func writeTOMLTable(w *bufio.Writer, path []string, obj []field) {
+	var tables []field
+	for _, f := range obj {
+		switch v := f.value.(type) {
+		case nil:
+			continue
+		case []field:
+			tables = append(tables, f)
+			continue
+		case []any:
+			if isTableArray(v) {
+				tables = append(tables, f)
+				continue
+			}
+		}
+		fmt.Fprintf(w, "%s = ", tomlKey(f.key))
+		writeTOMLValue(w, f.value)
+		w.WriteString("\n")
+	}
+
+	for _, f := range tables {
+		sub := append(path[:len(path):len(path)], tomlKey(f.key))
+		header := strings.Join(sub, ".")
+		switch v := f.value.(type) {
+		case []field:
+			fmt.Fprintf(w, "\n[%s]\n", header)
+			writeTOMLTable(w, sub, v)
+		case []any:
+			for _, el := range v {
+				fmt.Fprintf(w, "\n[[%s]]\n", header)
+				writeTOMLTable(w, sub, el.([]field))
+			}
+		}
+	}
+}
+
+// isTableArray reports whether arr is a non-empty array of objects, which TOML writes as [[tables]].
+func isTableArray(arr []any) bool {
+	for _, el := range arr {
+		if _, ok := el.([]field); !ok {
+			return false
+		}
+	}
+	return len(arr) > 0
+}
+
+// writeTOMLValue writes an inline value: a string, number, boolean, array or inline table.
+func writeTOMLValue(w *bufio.Writer, value any) {
+	switch v := value.(type) {
+	case string:
+		w.WriteString(tomlString(v))
+	case json.Number:
+		w.WriteString(v.String())
+	case bool:
+		fmt.Fprint(w, v)
+	case []any:
+		w.WriteString("[")
+		first := true
+		for _, el := range v {
+			if el == nil {
+				continue
+			}
+			if !first {
+				w.WriteString(", ")
+			}
+			first = false
+			writeTOMLValue(w, el)
+		}
+		w.WriteString("]")
+	case []field:
+		w.WriteString("{")
+		first := true
+		for _, f := range v {
+			if f.value == nil {
+				continue
+			}
+			if !first {
+				w.WriteString(",")
+			}
+			first = false
+			fmt.Fprintf(w, " %s = ", tomlKey(f.key))
+			writeTOMLValue(w, f.value)
+		}
+		w.WriteString(" }")
+	}
+}
+
+// tomlKey quotes key unless it is a valid bare key.
+func tomlKey(key string) string {
+	if tomlBareKeyRe.MatchString(key) {
+		return key
+	}
+	return tomlString(key)
+}
+
+// tomlString returns s as a TOML basic string.
+func tomlString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package gover
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// xmlNameRe matches the element names encodeXML can use as-is.
+var xmlNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// encodeXML writes v as XML under a <gover> root element, using the JSON field names as
+// element names. Array items are named after the singular of their parent (<changes>
+// holds <change> elements), and map keys that are not valid element names, such as
+// import paths, are written as <entry key="...">.
+func encodeXML(w io.Writer, v any) error {
+	tree, err := jsonTree(v)
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if arr, ok := tree.([]any); ok {
+		tree = []field{{key: "items", value: arr}}
+	}
+	if err := writeXML(enc, "gover", tree); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+func writeXML(enc *xml.Encoder, name string, value any) error {
+	if value == nil {
+		return nil
+	}
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if !xmlNameRe.MatchString(name) || strings.HasPrefix(strings.ToLower(name), "xml") {
+		start = xml.StartElement{
+			Name: xml.Name{Local: "entry"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: name}},
+		}
+	}
+	if err := enc.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case []field:
+		for _, f := range v {
+			if err := writeXML(enc, f.key, f.value); err != nil {
+				return err
+			}
+		}
+	case []any:
+		item := singular(name)
+		for _, el := range v {
+			if err := writeXML(enc, item, el); err != nil {
+				return err
+			}
+		}
+	case string:
+		if err := enc.EncodeToken(xml.CharData(v)); err != nil {
+			return err
+		}
+	case json.Number, bool:
+		if err := enc.EncodeToken(xml.CharData(fmt.Sprint(v))); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(start.End())
+}
+
+// singular returns the element name for the items of an array named name.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s") && len(name) > 1:
+		return strings.TrimSuffix(name, "s")
+	}
+	return "item"
+}
@@ -0,0 +1,116 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// gerritChangesURL is the Gerrit REST endpoint describing a change of the Go project.
+const gerritChangesURL = "https://go-review.googlesource.com/changes/"
+
+// gerritPrefix guards Gerrit's JSON responses against cross-site script inclusion.
+const gerritPrefix = ")]}'"
+
+// ChangeList is a Gerrit change (CL) linked from the release notes. Only Number and URL
+// are recorded when scraping; see WithGerritCLs for the rest.
+type ChangeList struct {
+	Number    int      `json:"number"`
+	URL       string   `json:"url"`
+	Subject   string   `json:"subject,omitempty"`
+	Status    string   `json:"status,omitempty"`    // e.g. "MERGED"
+	Submitted string   `json:"submitted,omitempty"` // RFC3339
+	Files     []string `json:"files,omitempty"`     // touched by its last patch set
+}
+
+// clLinkRe matches links to a CL of the Go project: go.dev/cl/N, golang.org/cl/N, the
+// relative /cl/N on go.dev itself, and go-review.googlesource.com/c/go/+/N.
+var clLinkRe = regexp.MustCompile(`^(?:https?://(?:go\.dev|golang\.org))?/cl/(\d+)|^https?://go-review\.googlesource\.com/(?:c/go/\+/)?(\d+)`)
+
+// sectionCLs returns the CLs linked from a section of the release notes, in page order.
+func sectionCLs(section *goquery.Selection) []ChangeList {
+	var cls []ChangeList
+	section.FindMatcher(linkMatcher).AddSelection(section.FilterMatcher(linkMatcher)).Each(func(_ int, a *goquery.Selection) {
+		m := clLinkRe.FindStringSubmatch(strings.TrimSpace(a.AttrOr("href", "")))
+		if m == nil {
+			return
+		}
+		n, err := strconv.Atoi(m[1] + m[2])
+		if err != nil || slices.ContainsFunc(cls, func(cl ChangeList) bool { return cl.Number == n }) {
+			return
+		}
+		cls = append(cls, ChangeList{Number: n, URL: "https://go.dev/cl/" + strconv.Itoa(n)})
+	})
+	return cls
+}
+
+// ChangeList fetches the metadata of CL number n from Gerrit.
+func (c *Client) ChangeList(ctx context.Context, n int) (ChangeList, error) {
+	url := fmt.Sprintf("%s%d?o=CURRENT_REVISION&o=CURRENT_FILES", gerritChangesURL, n)
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return ChangeList{}, fmt.Errorf("failed to fetch CL %d: %w", n, err)
+	}
+	var change struct {
+		Subject   string `json:"subject"`
+		Status    string `json:"status"`
+		Submitted string `json:"submitted"` // "2006-01-02 15:04:05.000000000", in UTC
+		Revisions map[string]struct {
+			Files map[string]json.RawMessage `json:"files"`
+		} `json:"revisions"`
+	}
+	if err := json.Unmarshal(bytes.TrimPrefix(body, []byte(gerritPrefix)), &change); err != nil {
+		return ChangeList{}, parseError(fmt.Errorf("failed to decode CL %d: %w", n, err))
+	}
+
+	cl := ChangeList{Number: n, URL: "https://go.dev/cl/" + strconv.Itoa(n), Subject: change.Subject, Status: change.Status}
+	if date, clock, ok := strings.Cut(change.Submitted, " "); ok {
+		clock, _, _ = strings.Cut(clock, ".")
+		cl.Submitted = date + "T" + clock + "Z"
+	}
+	for _, rev := range change.Revisions {
+		for file := range rev.Files {
+			if !strings.HasPrefix(file, "/") { // skips the /COMMIT_MSG pseudo-file
+				cl.Files = append(cl.Files, file)
+			}
+		}
+	}
+	slices.Sort(cl.Files)
+	return cl, nil
+}
+
+// addChangeLists fills in the Gerrit metadata of the CLs linked from data, fetching each
+// CL once. CLs that cannot be fetched are logged and keep only their number.
+func (c *Client) addChangeLists(ctx context.Context, data []VersionData) {
+	fetched := make(map[int]ChangeList)
+	var failed int
+	for i := range data {
+		for j := range data[i].Changes {
+			cls := data[i].Changes[j].CLs
+			for k := range cls {
+				if ctx.Err() != nil {
+					return
+				}
+				cl, ok := fetched[cls[k].Number]
+				if !ok {
+					var err error
+					if cl, err = c.ChangeList(ctx, cls[k].Number); err != nil {
+						c.opts.logger.Printf("Warning: %v", err)
+						failed++
+						cl = cls[k]
+					}
+					fetched[cl.Number] = cl
+				}
+				cls[k] = cl
+			}
+		}
+	}
+	c.opts.logger.Printf("Resolved %d of %d CLs", len(fetched)-failed, len(fetched))
+}
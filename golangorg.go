@@ -0,0 +1,128 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Pages of golang.org, the site of the Go project before go.dev, are read from the
+// copies the Internet Archive's Wayback Machine keeps.
+const (
+	golangOrgURL = "https://golang.org"
+	waybackURL   = "https://web.archive.org/web"
+	weeklyPath   = "/doc/devel/weekly.html"
+)
+
+// Capture times of the golang.org pages read: the latest before golang.org redirected
+// release notes to go.dev, and the weekly snapshots page as it stood once they had ended.
+const (
+	golangOrgCapture = "2021"
+	weeklyCapture    = "2013"
+)
+
+// Errors recorded for release notes pages without any section, and for versions the
+// release history does not date.
+const (
+	errNoSections    = "no sections found"
+	errNoReleaseDate = "release date not found"
+)
+
+// archivedURL returns the URL of the Wayback Machine's copy of a golang.org page captured
+// around when, as it was served, without the Wayback Machine's own markup.
+func archivedURL(path, when string) string {
+	return waybackURL + "/" + when + "id_/" + golangOrgURL + path
+}
+
+// ArchivedVersion fetches the release notes of version as golang.org served them, from
+// the Wayback Machine, and parses them into the same model as those of go.dev. The
+// version and its sections link to the archived page.
+func (c *Client) ArchivedVersion(ctx context.Context, version, releaseDate string) (VersionData, error) {
+	version = NormalizeVersion(version)
+	url := archivedURL("/doc/"+version, golangOrgCapture)
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return VersionData{}, fmt.Errorf("failed to fetch %s: %w", url, err)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return VersionData{}, parseError(fmt.Errorf("failed to parse %s: %w", url, err))
+	}
+	vd := c.parsePageAt(url, version, releaseDate, doc.Selection)
+	if slices.Contains(vd.Errors, errNoSections) {
+		return VersionData{}, parseError(fmt.Errorf("no sections found at %s", url))
+	}
+	return vd, nil
+}
+
+// orArchived returns vd, the result of scraping a version from the base URL, unless
+// that failed or found no sections and WithGolangOrgArchive is set, in which case it
+// returns the version as archived from golang.org if that can be read.
+func (c *Client) orArchived(ctx context.Context, vd VersionData, err error, version, releaseDate string) (VersionData, error) {
+	if !c.opts.golangOrg || (err == nil && !slices.Contains(vd.Errors, errNoSections)) {
+		return vd, err
+	}
+	archived, aerr := c.ArchivedVersion(ctx, version, releaseDate)
+	if aerr != nil {
+		c.opts.logger.Printf("Warning: no archived golang.org release notes for %s: %v", version, aerr)
+		return vd, err
+	}
+	c.opts.logger.Printf("Using the golang.org release notes of %s archived at %s", version, archived.URL)
+	return archived, nil
+}
+
+// weeklyDateRe matches the anchor IDs of the snapshots on the weekly snapshots page.
+var weeklyDateRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+
+// WeeklySnapshots fetches the history of the weekly snapshots that preceded Go 1, from
+// the Wayback Machine's copy of golang.org's weekly snapshots page, newest first. Each
+// is a version named after its tag, such as "weekly.2012-03-27", dated the day of the
+// snapshot, with its notes as a single category.
+func (c *Client) WeeklySnapshots(ctx context.Context) ([]VersionData, error) {
+	url := archivedURL(weeklyPath, weeklyCapture)
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch weekly snapshots: %w", err)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, parseError(fmt.Errorf("failed to parse %s: %w", url, err))
+	}
+	return parseWeeklySnapshots(url, doc.Selection)
+}
+
+// parseWeeklySnapshots reads the snapshots of the weekly snapshots page served from url:
+// each is an h2 heading with its date as ID, followed by its notes.
+func parseWeeklySnapshots(url string, page *goquery.Selection) ([]VersionData, error) {
+	var snapshots []VersionData
+	page.Find("h2[id]").Each(func(_ int, h *goquery.Selection) {
+		date := h.AttrOr("id", "")
+		if !weeklyDateRe.MatchString(date) {
+			return
+		}
+		notes := ChangeCategory{
+			Category:    strings.Join(strings.Fields(h.Text()), " "),
+			URL:         url + "#" + date,
+			Anchor:      date,
+			Kind:        KindOther,
+			Description: strings.TrimSpace(selectionText(h.NextUntil("h2"))),
+		}
+		notes.Type = classifyChange(notes.Description)
+		snapshots = append(snapshots, VersionData{
+			Version:     "weekly." + date,
+			ReleaseDate: date,
+			URL:         url + "#" + date,
+			Changes:     []ChangeCategory{notes},
+		})
+	})
+	if len(snapshots) == 0 {
+		return nil, parseError(fmt.Errorf("no weekly snapshots found at %s", url))
+	}
+	slices.SortStableFunc(snapshots, func(a, b VersionData) int { return strings.Compare(b.ReleaseDate, a.ReleaseDate) })
+	return snapshots, nil
+}
@@ -2,50 +2,140 @@
 package gover
 
 import (
-	"cmp"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
-	"log"
-	"net/http"
+	"os"
 	"regexp"
+	"runtime"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
 	"github.com/gocolly/colly/v2"
+	"golang.org/x/net/html"
 )
 
 // VersionData represents the data collected for a specific Go version.
 type VersionData struct {
-	Version     string           `json:"version"`
-	ReleaseDate string           `json:"releaseDate,omitempty"`
-	Changes     []ChangeCategory `json:"changes"`
+	Version         string                     `json:"version"`
+	ReleaseDate     string                     `json:"releaseDate,omitempty"`
+	DateSource      string                     `json:"releaseDateSource,omitempty"` // where ReleaseDate came from, see DateSourceHistory
+	ReleasedAt      string                     `json:"releasedAt,omitempty"`        // RFC3339 time of the release, from its announcement or tag, where known
+	URL             string                     `json:"url,omitempty"`               // the release notes page
+	Language        string                     `json:"language,omitempty"`          // of the release notes page, e.g. "en"
+	Changes         []ChangeCategory           `json:"changes"`
+	Errors          []string                   `json:"errors,omitempty"`          // problems that left this entry incomplete
+	Fingerprint     string                     `json:"fingerprint,omitempty"`     // hash of the source page content, see WithPrevious
+	Announcement    *Announcement              `json:"announcement,omitempty"`    // the blog post announcing the release, see WithAnnouncements
+	Contributions   *ContributionStats         `json:"contributions,omitempty"`   // see WithContributions
+	MilestoneIssues []MilestoneIssue           `json:"milestoneIssues,omitempty"` // closed in the release's GitHub milestone, see WithMilestoneIssues
+	Spec            *SpecChanges               `json:"spec,omitempty"`            // language specification revisions
+	Requirements    *Requirements              `json:"requirements,omitempty"`    // bootstrap and platform requirements
+	PerfClaims      []PerfClaim                `json:"perfClaims,omitempty"`      // quantified performance statements
+	Linking         []LinkingChange            `json:"linking,omitempty"`         // cgo and linker changes
+	Extra           map[string]json.RawMessage `json:"extra,omitempty"`           // added by external enrichers, see ExecStage
+	LocalNotes      []LocalNote                `json:"localNotes,omitempty"`      // user-authored, not from go.dev, see Overlay
+	Draft           bool                       `json:"draft,omitempty"`           // release notes of a release still in development, see WithDrafts
 }
 
 // ChangeCategory represents a high-level category of changes (e.g., "Language Changes", "Core Library").
 type ChangeCategory struct {
-	Category    string         `json:"category"`
-	Title       string         `json:"title,omitempty"`
-	Description string         `json:"description,omitempty"`
-	Examples    []string       `json:"examples,omitempty"`
-	Package     string         `json:"package,omitempty"`
-	Changes     []SymbolChange `json:"changes,omitempty"`
+	Category    string                     `json:"category"`
+	URL         string                     `json:"url,omitempty"`    // link to the section of the release notes
+	Anchor      string                     `json:"anchor,omitempty"` // the section's ID in the release notes, e.g. "minor_library_changes" or "net/http"
+	Kind        CategoryKind               `json:"kind,omitempty"`   // the part of the release notes it belongs to
+	Type        ChangeType                 `json:"type,omitempty"`   // normalized change type, see ChangeTypes
+	Title       string                     `json:"title,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Examples    []string                   `json:"examples,omitempty"`
+	Package     string                     `json:"package,omitempty"`
+	Impact      *Impact                    `json:"impact,omitempty"`
+	Notability  int                        `json:"notability,omitempty"`  // see Notability
+	Boilerplate bool                       `json:"boilerplate,omitempty"` // set for non-informative sections, see SectionFilter
+	RawHTML     string                     `json:"rawHTML,omitempty"`     // the section's source HTML, see WithRawHTML
+	Chars       int                        `json:"chars,omitempty"`       // length of the text, excluding symbol changes
+	Tokens      int                        `json:"tokens,omitempty"`      // approximate LLM token count of the text, see ApproxTokens
+	CLs         []ChangeList               `json:"cls,omitempty"`         // Gerrit changes linked from the section, see ChangeList
+	Changes     []SymbolChange             `json:"changes,omitempty"`
+	Provenance  *Provenance                `json:"provenance,omitempty"` // see WithProvenance
+	Extra       map[string]json.RawMessage `json:"extra,omitempty"`      // added by external enrichers, see ExecStage
+	LocalNotes  []LocalNote                `json:"localNotes,omitempty"` // user-authored, not from go.dev, see Overlay
 }
 
 // SymbolChange represents a specific change to a function, method, or type within a package.
 type SymbolChange struct {
-	Type        string `json:"type"`        // e.g., "added", "changed", "obsoleted"
-	Symbol      string `json:"symbol"`      // e.g., "http.NewRequestWithContext"
-	Description string `json:"description"` // Description of the specific change
+	Type        ChangeType                 `json:"type"`                 // normalized change type, see ChangeTypes
+	Symbol      string                     `json:"symbol"`               // fully qualified, e.g., "net/http.NewRequestWithContext"
+	Description string                     `json:"description"`          // Description of the specific change
+	Impact      *Impact                    `json:"impact,omitempty"`     // Heuristic estimate of the effect on existing code
+	Notability  int                        `json:"notability,omitempty"` // how much it would stand out in a release announcement, see Notability
+	Chars       int                        `json:"chars,omitempty"`      // length of the symbol and description
+	Tokens      int                        `json:"tokens,omitempty"`     // approximate LLM token count, see ApproxTokens
+	Provenance  *Provenance                `json:"provenance,omitempty"` // see WithProvenance
+	Extra       map[string]json.RawMessage `json:"extra,omitempty"`      // added by external enrichers, see ExecStage
+	LocalNotes  []LocalNote                `json:"localNotes,omitempty"` // user-authored, not from go.dev, see Overlay
 }
 
-const goVersionsURL = "https://go.dev/VERSION?m=text"
+// goVersionsPath is the go.dev endpoint reporting the current Go version.
+const goVersionsPath = "/VERSION?m=text"
+
+// overviewCategory is the category holding the title of a release notes page.
+const overviewCategory = "Overview"
+
+// releaseHistoryPath is the go.dev page listing every release and its date.
+const releaseHistoryPath = "/doc/devel/release"
 
 // Scrape fetches Go version information from go.dev and returns a slice of VersionData.
-func Scrape() ([]VersionData, error) {
-	latestVersion, err := getLatestGoVersion()
+// Versions that could only be partially scraped carry their problems in VersionData.Errors;
+// use ScrapeDataset to also get a summary. It is a shorthand for NewClient(opts...).Scrape.
+func Scrape(opts ...Option) ([]VersionData, error) {
+	return NewClient(opts...).Scrape(context.Background())
+}
+
+// ScrapeDataset fetches Go version information from go.dev and returns it in a Dataset.
+// It is a shorthand for NewClient(opts...).ScrapeDataset.
+func ScrapeDataset(opts ...Option) (*Dataset, error) {
+	return NewClient(opts...).ScrapeDataset(context.Background())
+}
+
+// Scrape fetches Go version information and returns a slice of VersionData, with exactly
+// one entry per version, newest first.
+func (c *Client) Scrape(ctx context.Context) ([]VersionData, error) {
+	ds, err := c.ScrapeDataset(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ds.Versions, nil
+}
+
+// ScrapeDataset fetches Go version information and returns it in a Dataset.
+// In best-effort mode (the default, see WithBestEffort) versions that fail to scrape are
+// recorded in the dataset rather than failing the whole scrape.
+func (c *Client) ScrapeDataset(ctx context.Context) (*Dataset, error) {
+	var (
+		ds  *Dataset
+		err error
+	)
+	c.measure(c.opts.metrics, func() { ds, err = c.scrapeDataset(ctx) })
+	return ds, err
+}
+
+// scrapeDataset implements ScrapeDataset.
+func (c *Client) scrapeDataset(ctx context.Context) (*Dataset, error) {
+	log := c.opts.logger
+
+	latestVersion, err := c.LatestVersion(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest Go version: %w", err)
 	}
@@ -61,204 +151,704 @@ func Scrape() ([]VersionData, error) {
 	log.Printf("Will scrape versions: %v", versions)
 
 	log.Println("Scraping release history for dates...")
-	releaseDates, err := scrapeReleaseHistory()
-	if err != nil {
-		return nil, fmt.Errorf("error scraping release history: %w", err)
-	}
+	releaseDates := c.releaseDates(ctx, versions)
 	log.Printf("Found release dates for %d versions", len(releaseDates))
 
 	log.Printf("Starting scraping for version details...")
-	versionData, err := scrapeGoVersions(versions, releaseDates)
+	before := c.counters.read()
+	versionData, err := c.scrapeGoVersions(ctx, versions, releaseDates)
 	if err != nil {
 		return nil, fmt.Errorf("error during scraping: %w", err)
 	}
 	log.Printf("Finished scraping. Found data for %d versions.", len(versionData))
+	versionData = c.withDraft(ctx, versionData, majorVersion)
 
-	return versionData, nil
+	if versionData, err = c.pipeline(false).Transform(ctx, versionData); err != nil {
+		return nil, err
+	}
+
+	ds := NewDataset(versionData)
+	counters := c.counters.read().since(before)
+	ds.Counters = &counters
+	if !c.opts.bestEffort && len(ds.Summary.Partial)+len(ds.Summary.Missing) > 0 {
+		return nil, ds.Err()
+	}
+	if c.opts.checkpoint != "" {
+		if err := os.Remove(c.opts.checkpoint); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Warning: failed to remove checkpoint: %v", err)
+		}
+	}
+	return ds, nil
 }
 
-// getLatestGoVersion fetches the current Go version string from go.dev.
-func getLatestGoVersion() (string, error) {
-	resp, err := http.Get(goVersionsURL)
+// Version fetches the release notes of a single version, such as "go1.22" or "1.22".
+func (c *Client) Version(ctx context.Context, version string) (VersionData, error) {
+	version = NormalizeVersion(version)
+	date := c.releaseDates(ctx, []string{version})[version]
+	vd, err := c.scrapeVersion(c.newCollector(ctx), version, date.date)
+	vd, err = c.orArchived(ctx, vd, err, version, date.date)
+	date.apply(&vd) // a partial result returned with the error is dated too
 	if err != nil {
-		return "", fmt.Errorf("failed to fetch Go versions: %w", err)
+		return vd, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to fetch Go versions, status code: %d", resp.StatusCode)
+	data, err := c.pipeline(true).Transform(ctx, []VersionData{vd})
+	if err != nil {
+		return VersionData{}, err
+	}
+	if len(data) == 0 {
+		return VersionData{}, fmt.Errorf("version %s was filtered out", version)
 	}
+	return data[0], nil
+}
 
-	body, err := io.ReadAll(resp.Body)
+// LatestVersion fetches the current Go version string, e.g. "go1.23.4".
+func (c *Client) LatestVersion(ctx context.Context) (string, error) {
+	body, err := c.get(ctx, c.url(goVersionsPath))
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return "", fmt.Errorf("failed to fetch Go versions: %w", err)
 	}
 
 	firstLine := strings.SplitN(string(body), "\n", 2)[0]
 	return strings.TrimSpace(firstLine), nil
 }
 
+var goReleaseRe = regexp.MustCompile(`go1\.(\d+)`)
+
 // extractMajorVersion parses a Go version string and returns the major version number.
 func extractMajorVersion(versionString string) (int, error) {
-	re := regexp.MustCompile(`go1\.(\d+)`)
-	matches := re.FindStringSubmatch(versionString)
+	matches := goReleaseRe.FindStringSubmatch(versionString)
 
 	if len(matches) < 2 {
-		return 0, fmt.Errorf("could not parse major version from: %s", versionString)
+		return 0, parseError(fmt.Errorf("could not parse major version from: %s", versionString))
 	}
 
 	majorVersion, err := strconv.Atoi(matches[1])
 	if err != nil {
-		return 0, fmt.Errorf("could not convert major version to int: %w", err)
+		return 0, parseError(fmt.Errorf("could not convert major version to int: %w", err))
 	}
 	return majorVersion, nil
 }
 
-// generateVersionStrings creates a list of Go version strings from go1.1 to go1.<majorVersion>.
+// generateVersionStrings creates a list of Go version strings from go1 (the original release,
+// which has no minor suffix) and go1.1 to go1.<majorVersion>.
 func generateVersionStrings(majorVersion int) []string {
-	versions := make([]string, 0, majorVersion)
+	versions := make([]string, 0, majorVersion+1)
+	versions = append(versions, "go1")
 	for i := 1; i <= majorVersion; i++ {
 		versions = append(versions, fmt.Sprintf("go1.%d", i))
 	}
 	return versions
 }
 
-// scrapeReleaseHistory scrapes https://go.dev/doc/devel/release to get all major Go versions and their release dates.
-func scrapeReleaseHistory() (map[string]string, error) {
-	releaseDates := make(map[string]string)
-	var mu sync.Mutex
+// pageResult is the outcome of scraping one version's release notes.
+type pageResult struct {
+	version string
+	data    VersionData
+	err     error
+}
 
-	c := colly.NewCollector(
-		colly.AllowedDomains("go.dev"),
-	)
-	c.UserAgent = "gover-scraper/1.0 (+https://github.com/paulstuart/gover)"
+// scrapeGoVersions scrapes the go.dev documentation for specified Go versions.
+// A fixed pool of workers fetches the pages, each with its own synchronous clone of a
+// rate-limited collector, and sends one result per version back on a channel, so a
+// page that fails or yields no content can never leave the scrape waiting. Pages that
+// can be read without network access, see localVersions, skip the collector and its
+// rate limit and are parsed concurrently by a pool of one worker per CPU instead.
+// The result has exactly one entry per distinct version, newest first, whatever the
+// order pages complete in: a version whose page redirects elsewhere or fires the
+// collector's callbacks again is still parsed once, and one without any result is
+// recorded as failed. With a checkpoint (see WithCheckpoint) each version completed
+// without errors is recorded as it arrives, and on resuming those recorded are not
+// fetched again. Once ctx is done the scrape fails with the context's error at once,
+// rather than recording every page as failed; no more pages are started, and those
+// in flight are abandoned.
+func (c *Client) scrapeGoVersions(ctx context.Context, versions []string, versionReleaseDates map[string]releaseDate) ([]VersionData, error) {
+	log := c.opts.logger
+	var requested []string
+	for _, v := range versions {
+		if !slices.Contains(requested, v) {
+			requested = append(requested, v)
+		}
+	}
+	byVersion := make(map[string]VersionData, len(requested))
+	var cp *checkpoint
+	if c.opts.checkpoint != "" {
+		cp = &checkpoint{path: c.opts.checkpoint}
+		if c.opts.resume {
+			done, err := loadCheckpoint(cp.path)
+			if err != nil {
+				return nil, err
+			}
+			for _, vd := range done {
+				if _, dup := byVersion[vd.Version]; !dup && len(vd.Errors) == 0 && slices.Contains(requested, vd.Version) {
+					byVersion[vd.Version] = vd
+					cp.done = append(cp.done, vd)
+				}
+			}
+			log.Printf("Resuming from %s: %d versions already scraped", cp.path, len(byVersion))
+		}
+	}
+	versions = slices.DeleteFunc(slices.Clone(requested), func(v string) bool {
+		_, done := byVersion[v]
+		return done
+	})
+	local, versions := c.localVersions(versions)
+	if len(local) > 0 {
+		log.Printf("Parsing %d versions from local pages", len(local))
+	}
+	col := c.newCollector(ctx)
 
-	c.OnError(func(r *colly.Response, err error) {
-		log.Printf("Release history request URL: %s failed with response: %d, error: %v", r.Request.URL, r.StatusCode, err)
+	col.Limit(&colly.LimitRule{
+		DomainGlob:  "*",
+		Parallelism: c.opts.parallelism,
+		Delay:       c.opts.pageDelay,
 	})
 
-	versionDateRe := regexp.MustCompile(`(go1\.\d+)(?:\.\d+)?\s+\(released\s+(\d{4}-\d{2}-\d{2})\)`)
+	jobs := make(chan string)
+	results := make(chan pageResult)
 
-	c.OnHTML("h2", func(e *colly.HTMLElement) {
-		text := e.Text
-		matches := versionDateRe.FindStringSubmatch(text)
-		if len(matches) >= 3 {
-			version := matches[1]
-			releaseDate := matches[2]
+	localJobs := make(chan string)
 
-			mu.Lock()
-			if _, exists := releaseDates[version]; !exists {
-				releaseDates[version] = releaseDate
-				log.Printf("Found release: %s, Date: %s", version, releaseDate)
+	var wg sync.WaitGroup
+	for range c.opts.parallelism {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range jobs {
+				data, err := c.scrapeVersion(col.Clone(), v, versionReleaseDates[v].date)
+				data, err = c.orArchived(ctx, data, err, v, versionReleaseDates[v].date)
+				select {
+				case results <- pageResult{version: v, data: data, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	for range runtime.GOMAXPROCS(0) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for v := range localJobs {
+				data, err := c.parseLocalVersion(ctx, v, versionReleaseDates[v].date)
+				select {
+				case results <- pageResult{version: v, data: data, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	feed := func(jobs chan<- string, versions []string) {
+		defer close(jobs)
+		for _, v := range versions {
+			select {
+			case jobs <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+	go feed(jobs, versions)
+	go feed(localJobs, local)
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+collect:
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case r, ok := <-results:
+			if !ok {
+				break collect
+			}
+			if _, dup := byVersion[r.version]; dup {
+				log.Printf("Warning: ignoring a second result for %s", r.version)
+				continue
+			}
+			vd := c.resultData(r, versionReleaseDates[r.version])
+			byVersion[r.version] = vd
+			if cp != nil && len(vd.Errors) == 0 {
+				if err := cp.add(vd); err != nil {
+					log.Printf("Warning: %v", err)
+				}
 			}
-			mu.Unlock()
 		}
+	}
+
+	allVersionData := make([]VersionData, 0, len(requested))
+	for _, v := range requested {
+		vd, ok := byVersion[v]
+		if !ok {
+			vd = c.resultData(pageResult{version: v, err: fmt.Errorf("no result for %s", v)}, versionReleaseDates[v])
+		}
+		allVersionData = append(allVersionData, vd)
+	}
+	slices.SortStableFunc(allVersionData, func(a, b VersionData) int {
+		return -CompareVersions(a.Version, b.Version)
 	})
+	return allVersionData, nil
+}
 
-	err := c.Visit("https://go.dev/doc/devel/release")
-	if err != nil {
-		return nil, fmt.Errorf("failed to visit release history page: %w", err)
+// resultData returns the data of a scraped page dated with date, or if scraping failed, an
+// entry recording the error, so that the version is not silently missing.
+func (c *Client) resultData(r pageResult, date releaseDate) VersionData {
+	if r.err != nil {
+		c.opts.logger.Printf("Failed to scrape %s: %v", r.version, r.err)
+		c.pageSkipped()
+		r.data = VersionData{
+			Version:     r.version,
+			ReleaseDate: date.date,
+			Changes:     []ChangeCategory{},
+			Errors:      []string{r.err.Error()},
+		}
+		if c.dump != nil {
+			c.dump.version(r.data, nil)
+		}
 	}
-	c.Wait()
+	date.apply(&r.data)
+	return r.data
+}
 
-	if len(releaseDates) == 0 {
-		return nil, fmt.Errorf("no release dates found on https://go.dev/doc/devel/release")
+// scrapeVersion fetches and parses the release notes of a single version using col,
+// which must be synchronous so that Visit returns once the page has been handled.
+func (c *Client) scrapeVersion(col *colly.Collector, version, releaseDate string) (VersionData, error) {
+	log := c.opts.logger
+	var (
+		versionData VersionData
+		parsed      bool
+	)
+	col.OnHTML("html", func(e *colly.HTMLElement) {
+		if parsed {
+			return // only the first page served for the version counts
+		}
+		if got, _ := VersionFromURL(e.Request.URL.String()); got != version {
+			log.Printf("Warning: %s release notes were served from %s", version, e.Request.URL)
+		}
+		versionData = c.parseVersionPage(version, releaseDate, e.DOM)
+		parsed = true
+		if c.dump != nil {
+			c.dump.version(versionData, e.DOM)
+		}
+	})
+	url := c.url("/doc/" + version)
+	log.Printf("Visiting: %s", url)
+	if err := col.Visit(url); err != nil {
+		return VersionData{}, networkError(fmt.Errorf("failed to fetch %s: %w", url, err))
 	}
+	if !parsed {
+		return VersionData{}, parseError(fmt.Errorf("no HTML content at %s", url))
+	}
+	return versionData, nil
+}
 
-	return releaseDates, nil
+// localVersions splits versions into those whose release notes can be read without network
+// access, and the others: with a file base URL all of them are local, and otherwise those
+// whose page is in the cache.
+func (c *Client) localVersions(versions []string) (local, remote []string) {
+	if strings.HasPrefix(c.opts.baseURL, "file:") {
+		return versions, nil
+	}
+	if c.opts.cache == nil {
+		return nil, versions
+	}
+	for _, v := range versions {
+		if c.isLocal(c.url("/doc/" + v)) {
+			local = append(local, v)
+		} else {
+			remote = append(remote, v)
+		}
+	}
+	return local, remote
 }
 
-// scrapeGoVersions scrapes the go.dev documentation for specified Go versions.
-func scrapeGoVersions(versions []string, versionReleaseDates map[string]string) ([]VersionData, error) {
-	var allVersionData []VersionData
-	var mu sync.Mutex
-	var wg sync.WaitGroup
+// parseLocalVersion reads the release notes of a version from the cache or the local file
+// system, without the collector, and parses them.
+func (c *Client) parseLocalVersion(ctx context.Context, version, releaseDate string) (VersionData, error) {
+	url := c.url("/doc/" + version)
+	body, err := c.get(ctx, url)
+	if err != nil {
+		return VersionData{}, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return VersionData{}, parseError(fmt.Errorf("failed to parse %s: %w", url, err))
+	}
+	versionData := c.parseVersionPage(version, releaseDate, doc.Selection)
+	if c.dump != nil {
+		c.dump.version(versionData, doc.Selection)
+	}
+	return versionData, nil
+}
 
-	c := colly.NewCollector(
-		colly.AllowedDomains("go.dev"),
-		colly.Async(true),
-	)
+// parseVersionPage extracts the VersionData of a version from its release notes page.
+func (c *Client) parseVersionPage(version, releaseDate string, page *goquery.Selection) VersionData {
+	return c.parsePageAt(c.url("/doc/"+version), version, releaseDate, page)
+}
 
-	c.UserAgent = "gover-scraper/1.0 (+https://github.com/paulstuart/gover)"
+// parsePageAt is parseVersionPage for a page served from url, which the version and its
+// sections link to.
+func (c *Client) parsePageAt(url, version, releaseDate string, page *goquery.Selection) VersionData {
+	log, o := c.opts.logger, c.opts
+	if c.meter != nil {
+		defer c.meter.parsed(time.Now())
+	}
+	fingerprint := pageFingerprint(page, c.sel.content)
+	if prev, ok := o.previous[version]; ok && prev.Fingerprint == fingerprint && (!o.provenance || hasProvenance(prev)) {
+		log.Printf("Content unchanged for Go version: %s, reusing previous data", version)
+		if releaseDate != "" {
+			prev.ReleaseDate = releaseDate
+		}
+		if o.compact {
+			prev.Changes = compactCategories(prev.Changes)
+		}
+		return prev
+	} else if ok {
+		log.Printf("go.dev edited the %s notes since the last scrape", version)
+	}
 
-	c.Limit(&colly.LimitRule{
-		DomainGlob:  "*",
-		Parallelism: 2,
-		Delay:       1 * time.Second,
-	})
+	log.Printf("Processing content for Go version: %s", version)
 
-	c.OnError(func(r *colly.Response, err error) {
-		log.Printf("Request URL: %s failed with response: %d, error: %v", r.Request.URL, r.StatusCode, err)
-	})
+	versionData := VersionData{
+		Version:     version,
+		ReleaseDate: releaseDate,
+		URL:         url,
+		Changes:     []ChangeCategory{},
+		Fingerprint: fingerprint,
+		Language:    detectLanguage(page, c.sel.content),
+	}
 
-	c.OnHTML("html", func(e *colly.HTMLElement) {
-		version := extractVersionFromURL(e.Request.URL.String())
-		if version == "" {
-			log.Printf("Could not extract version from URL: %s", e.Request.URL.String())
-			return
-		}
+	// The parsing heuristics only understand English, so localized pages are not parsed.
+	if lang := versionData.Language; lang != "" && lang != "en" {
+		log.Printf("Warning: %s release notes are in %q, not English, skipping", version, lang)
+		c.pageSkipped()
+		versionData.Errors = append(versionData.Errors, fmt.Sprintf("release notes are in %q, not English", lang))
+		return versionData
+	}
 
-		log.Printf("Processing content for Go version: %s", version)
+	if releaseDate == "" {
+		log.Printf("Warning: Release date not found for %s", version)
+		versionData.Errors = append(versionData.Errors, errNoReleaseDate)
+	}
 
-		versionData := VersionData{
-			Version: version,
-			Changes: []ChangeCategory{},
+	c.countEmptySelectors(version, page)
+	prov := c.newProvenancer(versionData.URL)
+	h1 := page.FindMatcher(c.sel.title).First()
+	if mainTitle := strings.TrimSpace(h1.Text()); mainTitle != "" {
+		log.Printf("Main Title for %s: %s", version, mainTitle)
+		overview := ChangeCategory{
+			Category:    overviewCategory,
+			Kind:        KindOverview,
+			URL:         versionData.URL,
+			Description: mainTitle,
+			Provenance:  prov.of(h1, nil),
+		}
+		if o.rawHTML {
+			overview.RawHTML = sectionHTML(h1)
 		}
+		versionData.Changes = append(versionData.Changes, overview)
+	}
 
-		if date, ok := versionReleaseDates[version]; ok {
-			versionData.ReleaseDate = date
-		} else {
-			log.Printf("Warning: Release date not found for %s", version)
+	sections := c.parseSections(&versionData, page, prov)
+	versionData.Requirements = extractRequirements(page)
+	versionData.PerfClaims = extractPerfClaims(page)
+	versionData.Linking = extractLinking(page)
+	if sections == 0 {
+		versionData.Errors = append(versionData.Errors, errNoSections)
+	}
+	versionData.Changes = filterSections(versionData.Changes, o.sectionFilter, o.boilerplate)
+	if o.compact {
+		versionData.Changes = compactCategories(versionData.Changes)
+	}
+	versionData.Changes = countCategoryTokens(versionData.Changes)
+
+	return versionData
+}
+
+// parseSections appends a category for each section of a release notes page and, as the
+// version's parsing profile directs, for each subsection and each package's changes,
+// in page order. It returns the number of sections.
+func (c *Client) parseSections(vd *VersionData, page *goquery.Selection, prov *provenancer) int {
+	prof := c.sel.profile(vd.Version)
+	if prof == nil {
+		prof = &c.sel.noProfile
+	}
+
+	sections := 0
+	var current ChangeCategory // the innermost section or subsection
+	var kind CategoryKind      // the kind of the enclosing section
+	page.FindMatcher(prof.query).Each(func(_ int, el *goquery.Selection) {
+		switch {
+		case prof.pkg != nil && el.IsMatcher(prof.pkg):
+			if el.ParentsMatcher(prof.pkg).Length() > 0 {
+				return // nested in another entry, part of its description
+			}
+			if cat, ok := c.packageCategory(vd, el, current, prof, prov); ok {
+				vd.Changes = append(vd.Changes, cat)
+			}
+		case el.IsMatcher(c.sel.section):
+			sections++
+			c.opts.logger.Printf("  Found category: %s", el.Text())
+			current = c.headingCategory(vd, el, sectionContent(el), prov)
+			kind = classifySection(current.Anchor, current.Category)
+			current.Kind = kind
+			vd.Changes = append(vd.Changes, current)
+			if c.sel.languageSection.MatchString(current.Category) {
+				vd.Spec = specChanges(sectionContent(el))
+			}
+		default:
+			current = c.headingCategory(vd, el, el.NextUntilMatcher(prof.headings), prov)
+			// Subsections of sections of no particular kind, or of no section, go by their own heading.
+			current.Kind = kind
+			if kind == "" || kind == KindOther {
+				current.Kind = classifySection(current.Anchor, current.Category)
+			}
+			vd.Changes = append(vd.Changes, current)
 		}
+	})
+	return sections
+}
 
-		mainTitle := e.ChildText("h1")
-		if mainTitle != "" {
-			log.Printf("Main Title for %s: %s", version, mainTitle)
-			versionData.Changes = append(versionData.Changes, ChangeCategory{
-				Category:    "Overview",
-				Description: mainTitle,
-			})
+// headingCategory returns the category introduced by a section or subsection heading,
+// described by the paragraph following it.
+func (c *Client) headingCategory(vd *VersionData, heading, content *goquery.Selection, prov *provenancer) ChangeCategory {
+	cat := ChangeCategory{
+		Category: heading.Text(),
+		URL:      vd.URL,
+	}
+	if id, ok := heading.Attr("id"); ok && id != "" {
+		cat.URL += "#" + id
+		cat.Anchor = id
+	}
+	cat.Provenance = prov.of(heading, nil)
+	if next := heading.Next(); next.Length() > 0 && next.IsMatcher(c.sel.description) {
+		cat.Description = next.Text()
+		cat.Type = classifyChange(cat.Description)
+		cat.Impact = classifyImpactPtr(cat.Type, cat.Description)
+		cat.Provenance = prov.of(heading, next)
+	}
+	cat.CLs = sectionCLs(heading.AddSelection(content))
+	if c.opts.rawHTML {
+		cat.RawHTML = selectionHTML(heading.AddSelection(content))
+	}
+	return cat
+}
+
+// anchorPathRe matches anchor IDs that are import paths, as go.dev gives the entries of
+// the minor changes to the library from go1.21 on.
+var anchorPathRe = regexp.MustCompile(`^[a-z0-9]+(/[a-z0-9._-]+)*$`)
+
+// anchorPackage returns the import path a package entry's anchor ID names, or "".
+func anchorPackage(id string) string {
+	if _, ok := anchorKinds[id]; ok || !anchorPathRe.MatchString(id) {
+		return ""
+	}
+	return id
+}
+
+// packageCategory returns the category describing the changes to one package in a release
+// notes entry, filed under the enclosing heading. The package is the one the entry links
+// to or, failing that, the one its anchor ID names. It reports false if there is neither.
+func (c *Client) packageCategory(vd *VersionData, entry *goquery.Selection, heading ChangeCategory, prof *profile, prov *provenancer) (ChangeCategory, bool) {
+	var pkg string
+	if prof.packageLink != nil {
+		pkg = packagePath(entry.FindMatcher(prof.packageLink).First().AttrOr("href", ""))
+	}
+	if pkg == "" {
+		pkg = anchorPackage(entry.AttrOr("id", ""))
+	}
+	if pkg == "" {
+		return ChangeCategory{}, false
+	}
+
+	var body *goquery.Selection
+	if prof.packageBody != nil {
+		body = entry.FindMatcher(prof.packageBody)
+	}
+	section := entry
+	if isHeading(entry) {
+		following := entry.NextUntilMatcher(prof.query)
+		section = entry.AddSelection(following)
+		if body == nil || body.Length() == 0 {
+			body = following
 		}
+	} else if body == nil || body.Length() == 0 {
+		body = entry
+	}
 
-		e.ForEach("h2", func(_ int, el *colly.HTMLElement) {
-			categoryName := el.Text
-			log.Printf("  Found category: %s", categoryName)
+	cat := ChangeCategory{
+		Category:    heading.Category,
+		Kind:        KindLibrary,
+		URL:         heading.URL,
+		Package:     pkg,
+		Description: selectionText(body),
+	}
+	if id, ok := entry.Attr("id"); ok && id != "" {
+		cat.URL = vd.URL + "#" + id
+		cat.Anchor = id
+	}
+	cat.Type = classifyChange(cat.Description)
+	cat.Impact = classifyImpactPtr(cat.Type, cat.Description)
+	var unresolved int
+	cat.Changes, unresolved = resolveSymbols(pkg, body, prov)
+	c.symbolsUnresolved(unresolved)
+	cat.Provenance = prov.of(entry, body)
+	cat.CLs = sectionCLs(section)
+	if c.opts.rawHTML {
+		cat.RawHTML = selectionHTML(section)
+	}
+	return cat, true
+}
 
-			currentCategory := ChangeCategory{
-				Category: categoryName,
-			}
+// textBuffers holds the buffers selectionText collects text in, reused across pages.
+var textBuffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// selectionText returns the text of the elements of a selection, separated by spaces,
+// with runs of whitespace collapsed.
+func selectionText(sel *goquery.Selection) string {
+	buf := textBuffers.Get().(*bytes.Buffer)
+	defer func() {
+		buf.Reset()
+		textBuffers.Put(buf)
+	}()
+	for _, n := range sel.Nodes {
+		writeText(buf, n)
+		buf.WriteByte(' ')
+	}
+	return collapseSpace(buf.String())
+}
+
+// writeText writes the text of n and its descendants to buf, as Selection.Text does.
+func writeText(buf *bytes.Buffer, n *html.Node) {
+	if n.Type == html.TextNode {
+		buf.WriteString(n.Data)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeText(buf, c)
+	}
+}
 
-			nextSibling := el.DOM.Next()
-			if nextSibling.Length() > 0 && nextSibling.Is("p") {
-				currentCategory.Description = nextSibling.Text()
+// collapseSpace trims s and replaces each run of white space in it with a single space,
+// like strings.Join(strings.Fields(s), " ") but without allocating if s is already collapsed.
+func collapseSpace(s string) string {
+	collapsed, space := true, true // a leading space is not collapsed
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if space || r != ' ' {
+				collapsed = false
+				break
 			}
+			space = true
+		} else {
+			space = false
+		}
+	}
+	if collapsed && !space || s == "" {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	space = false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			space = b.Len() > 0
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isHeading reports whether s is a heading element.
+func isHeading(s *goquery.Selection) bool {
+	switch goquery.NodeName(s) {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		return true
+	}
+	return false
+}
+
+// packagePath returns the import path documented at href, such as "net/http" for
+// "/pkg/net/http/#ServeFileFS", or "" if href is not a package documentation link.
+func packagePath(href string) string {
+	_, p, ok := strings.Cut(href, "/pkg/")
+	if !ok {
+		return ""
+	}
+	if i := strings.IndexAny(p, "#?"); i >= 0 {
+		p = p[:i]
+	}
+	p = strings.Trim(p, "/")
+	if p == "" || strings.ContainsAny(p, " ") {
+		return ""
+	}
+	return p
+}
 
-			versionData.Changes = append(versionData.Changes, currentCategory)
-		})
+// Matchers of the elements of release notes pages the extractors read.
+var (
+	bodyMatcher        = cascadia.MustCompile("body")
+	noteMatcher        = cascadia.MustCompile("h2, h3, h4, p, li")
+	noteHeadingMatcher = cascadia.MustCompile("h2, h3, h4")
+	paragraphMatcher   = cascadia.MustCompile("p, li")
+)
 
-		mu.Lock()
-		allVersionData = append(allVersionData, versionData)
-		mu.Unlock()
-		wg.Done()
-	})
+// hashWriters holds the buffered writers pageFingerprint renders pages through, reused
+// across pages.
+var hashWriters = sync.Pool{New: func() any { return bufio.NewWriterSize(nil, 32<<10) }}
 
-	for _, v := range versions {
-		wg.Add(1)
-		url := fmt.Sprintf("https://go.dev/doc/%s", v)
-		log.Printf("Visiting: %s", url)
-		c.Visit(url)
+// pageFingerprint hashes the main content of a release notes page, selected by content,
+// ignoring the site navigation and footer so that unrelated site changes do not count as edits.
+func pageFingerprint(page *goquery.Selection, content goquery.Matcher) string {
+	main := page.FindMatcher(content).First()
+	if main.Length() == 0 {
+		main = page.FindMatcher(bodyMatcher).First()
 	}
+	if main.Length() == 0 {
+		return ""
+	}
+	h := sha256.New()
+	w := hashWriters.Get().(*bufio.Writer)
+	defer hashWriters.Put(w)
+	w.Reset(h)
+	if err := html.Render(w, main.Get(0)); err != nil {
+		return ""
+	}
+	if err := w.Flush(); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
 
-	wg.Wait()
+// sectionContent returns the siblings that follow a heading, up to the next heading of the same kind.
+func sectionContent(heading *goquery.Selection) *goquery.Selection {
+	return heading.NextUntil(goquery.NodeName(heading))
+}
 
-	slices.SortFunc(allVersionData, func(a, b VersionData) int {
-		return -cmp.Compare(parseVersionMinor(a.Version), parseVersionMinor(b.Version))
-	})
+// sectionHTML returns the HTML of a heading and its section content.
+func sectionHTML(heading *goquery.Selection) string {
+	return selectionHTML(heading.AddSelection(sectionContent(heading)))
+}
 
-	return allVersionData, nil
+// selectionHTML returns the concatenated HTML of the elements of a selection.
+func selectionHTML(section *goquery.Selection) string {
+	var b strings.Builder
+	section.Each(func(_ int, s *goquery.Selection) {
+		if html, err := goquery.OuterHtml(s); err == nil {
+			b.WriteString(html)
+		}
+	})
+	return b.String()
 }
 
 // parseVersionMinor extracts the minor version number from a version string like "go1.24".
+// It returns 0 for go1 itself and for unparsable versions.
 func parseVersionMinor(version string) int {
 	if !strings.HasPrefix(version, "go1.") {
 		return 0
@@ -270,26 +860,3 @@ func parseVersionMinor(version string) int {
 	}
 	return minor
 }
-
-// extractVersionFromURL is a helper to get the "go1.X" part from the URL.
-func extractVersionFromURL(url string) string {
-	if len(url) < 4 || url[len(url)-1] == '/' {
-		return ""
-	}
-
-	lastSlash := 0
-	for i := len(url) - 1; i >= 0; i-- {
-		if url[i] == '/' {
-			lastSlash = i
-			break
-		}
-	}
-
-	if lastSlash != 0 && lastSlash < len(url)-1 {
-		segment := url[lastSlash+1:]
-		if len(segment) >= 3 && segment[:2] == "go" {
-			return segment
-		}
-	}
-	return ""
-}
@@ -0,0 +1,99 @@
+package gover
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// GrepOptions scope the text Grep searches and set the context of its matches.
+type GrepOptions struct {
+	Versions Constraint // only search versions matching this, or all if empty
+	Package  string     // only search the changes to this import path, or to those under it if it ends in "/..."
+	Before   int        // sentences of context before each match
+	After    int        // sentences of context after each match
+}
+
+// GrepMatch is a sentence matched by Grep, with the sentences around it.
+type GrepMatch struct {
+	Version  string   `json:"version"`
+	Category string   `json:"category"`
+	Package  string   `json:"package,omitempty"`
+	Symbol   string   `json:"symbol,omitempty"`
+	Line     int      `json:"line"` // of the sentence in the searched text of the version, from 1
+	Text     string   `json:"text"`
+	Before   []string `json:"before,omitempty"`
+	After    []string `json:"after,omitempty"`
+}
+
+// Grep returns the sentences of data matching re, in page order: a lower-level
+// complement to Search, which ranks changes by query terms. Each version is searched as a
+// sequence of sentences, the "lines" of its text: those of each category's description,
+// its examples, and the descriptions of its symbol changes that do not repeat the
+// category's; context is taken from the same sequence.
+func Grep(data []VersionData, re *regexp.Regexp, opts GrepOptions) []GrepMatch {
+	var matches []GrepMatch
+	for _, vd := range data {
+		if len(opts.Versions) > 0 && !opts.Versions.Match(vd.Version) {
+			continue
+		}
+		lines := grepLines(vd, opts.Package)
+		for i, l := range lines {
+			if !re.MatchString(l.Text) {
+				continue
+			}
+			m := l
+			m.Line = i + 1
+			for _, c := range lines[max(i-opts.Before, 0):i] {
+				m.Before = append(m.Before, c.Text)
+			}
+			for _, c := range lines[i+1 : min(i+1+opts.After, len(lines))] {
+				m.After = append(m.After, c.Text)
+			}
+			matches = append(matches, m)
+		}
+	}
+	return matches
+}
+
+// grepLines returns the sentences Grep searches in vd, limited to the changes to pkg, as
+// GrepMatches without a line number or context.
+func grepLines(vd VersionData, pkg string) []GrepMatch {
+	var lines []GrepMatch
+	for _, cat := range vd.Changes {
+		if !matchesPackage(cat.Package, pkg) {
+			continue
+		}
+		base := GrepMatch{Version: vd.Version, Category: cat.Category, Package: cat.Package}
+		var seen []string
+		add := func(symbol string, texts ...string) {
+			for _, t := range texts {
+				if slices.Contains(seen, t) {
+					continue
+				}
+				seen = append(seen, t)
+				l := base
+				l.Symbol, l.Text = symbol, t
+				lines = append(lines, l)
+			}
+		}
+		add("", sentences(cat.Description)...)
+		add("", cat.Examples...)
+		for _, sc := range cat.Changes {
+			add(sc.Symbol, sentences(sc.Description)...)
+		}
+	}
+	return lines
+}
+
+// matchesPackage reports whether the import path path matches pattern: any path if it is
+// empty, the paths under its prefix if it ends in "/...", and otherwise only itself.
+func matchesPackage(path, pattern string) bool {
+	if pattern == "" {
+		return true
+	}
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+	return path == pattern
+}
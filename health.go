@@ -0,0 +1,101 @@
+package gover
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Health states, as monitoring agents such as Nagios expect plugins to report them.
+const (
+	HealthOK       = "OK"
+	HealthWarning  = "WARNING"
+	HealthCritical = "CRITICAL"
+	HealthUnknown  = "UNKNOWN"
+)
+
+// Subjects of a Health check.
+const (
+	HealthToolchain = "toolchain" // a Go toolchain, which falls behind with every patch release
+	HealthDataset   = "dataset"   // a dataset, which falls behind with every major release
+)
+
+// Health is how far a Go toolchain or dataset lags behind the releases on go.dev.
+type Health struct {
+	Subject   string `json:"subject"`          // HealthToolchain or HealthDataset
+	Version   string `json:"version"`          // the toolchain, or the newest version in the dataset
+	Behind    string `json:"behind,omitempty"` // the oldest release it lacks, if any
+	Since     string `json:"since,omitempty"`  // the release date of Behind
+	StaleDays int    `json:"staleDays"`        // days since Since
+	WarnDays  int    `json:"warnDays"`         // the threshold for HealthWarning
+	CritDays  int    `json:"critDays"`         // the threshold for HealthCritical
+	State     string `json:"state"`            // one of the Health states
+}
+
+// CheckHealth reports how stale version is given the release history: the
+// number of days since the oldest release newer than it came out, compared with the warn
+// and crit thresholds. Patch releases only count for HealthToolchain. Versions that cannot
+// be parsed, such as development builds, are HealthUnknown.
+func CheckHealth(subject, version string, releases []Release, now time.Time, warn, crit int) Health {
+	h := Health{Subject: subject, Version: version, WarnDays: warn, CritDays: crit, State: HealthOK}
+	if _, ok := parseGoVersion(version); !ok {
+		h.State = HealthUnknown
+		return h
+	}
+	patches := subject == HealthToolchain
+	consider := func(v, date string) {
+		if CompareVersions(v, version) > 0 && (h.Since == "" || date < h.Since) {
+			h.Behind, h.Since = v, date
+		}
+	}
+	for _, r := range releases {
+		consider(r.Version, r.Date)
+		if patches {
+			for _, p := range r.Patches {
+				consider(p.Version, p.Date)
+			}
+		}
+	}
+	if h.Behind == "" {
+		return h
+	}
+	since, err := time.Parse(DateLayout, h.Since)
+	if err != nil {
+		h.State = HealthUnknown
+		return h
+	}
+	h.StaleDays = int(now.Sub(since).Hours() / 24)
+	switch {
+	case h.StaleDays >= crit:
+		h.State = HealthCritical
+	case h.StaleDays >= warn:
+		h.State = HealthWarning
+	}
+	return h
+}
+
+// String formats h as the status line of a monitoring plugin, with the staleness as
+// performance data.
+func (h Health) String() string {
+	status := fmt.Sprintf("%s %s is up to date", h.Subject, h.Version)
+	switch {
+	case h.State == HealthUnknown:
+		status = fmt.Sprintf("cannot tell how stale %s %s is", h.Subject, h.Version)
+	case h.Behind != "":
+		status = fmt.Sprintf("%s %s is %d days behind %s (released %s)", h.Subject, h.Version, h.StaleDays, h.Behind, h.Since)
+	}
+	return fmt.Sprintf("GOVER %s - %s | stale_days=%d;%d;%d", h.State, status, h.StaleDays, h.WarnDays, h.CritDays)
+}
+
+// LocalGoVersion returns the version of the go command on the PATH, e.g. "go1.23.4".
+func LocalGoVersion(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, "go", "env", "GOVERSION").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run go env: %w", err)
+	}
+	// Experiment builds append their experiments, e.g. "go1.23.4 X:rangefunc".
+	v, _, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	return v, nil
+}
@@ -0,0 +1,203 @@
+package gover
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SymbolEvent is a change to a symbol in one release.
+type SymbolEvent struct {
+	Version     string     `json:"version"`
+	Type        ChangeType `json:"type"`   // one of ChangeTypes
+	Source      string     `json:"source"` // "api", "except.txt", "source" or "release notes"
+	Description string     `json:"description,omitempty"`
+}
+
+// SymbolHistory is the timeline of an exported standard library symbol.
+type SymbolHistory struct {
+	Symbol      string        `json:"symbol"`
+	Added       string        `json:"added,omitempty"`       // the release whose api file first lists it
+	Deprecated  string        `json:"deprecated,omitempty"`  // the first release deprecating it or its package
+	Replacement string        `json:"replacement,omitempty"` // the symbol its deprecation note points to, if any
+	Events      []SymbolEvent `json:"events"`
+}
+
+// HistorySources are the data a SymbolHistory is built from. Any of them may be empty.
+type HistorySources struct {
+	Features     map[string][]APIFeature // api files keyed by version, see APIFeatures
+	Exceptions   []APIException          // see FindAPIExceptions
+	Deprecations []Deprecation           // see StdlibDeprecations
+	Data         []VersionData           // release notes
+}
+
+// Sources of symbol events.
+const (
+	sourceAPI          = "api"
+	sourceExceptions   = "except.txt"
+	sourceDeprecations = "source"
+	sourceReleaseNotes = "release notes"
+)
+
+var (
+	// docLinkRe matches a doc link such as "[Request.Context]" or "[io.ReadAll]".
+	docLinkRe = regexp.MustCompile(`\[(\*?[\w/]+(?:\.\w+)*)\]`)
+	// useInsteadRe matches the identifier recommended by notes such as "Use DialContext instead".
+	useInsteadRe = regexp.MustCompile(`(?i)\buse\s+(?:the\s+)?([\w/]+(?:\.\w+)*)`)
+)
+
+// BuildSymbolHistory combines the additions recorded in the api files, the compatibility
+// exceptions, the harvested deprecations and the release notes into the timeline of symbol,
+// such as "net/http.CloseNotifier" or "net/http.Request.Context".
+func BuildSymbolHistory(symbol string, src HistorySources) SymbolHistory {
+	h := SymbolHistory{Symbol: symbol}
+	pkg := SymbolPackage(symbol)
+
+	versions := make([]string, 0, len(src.Features))
+	for v := range src.Features {
+		versions = append(versions, v)
+	}
+	slices.SortFunc(versions, CompareVersions)
+	for _, v := range versions {
+		if i := slices.IndexFunc(src.Features[v], func(f APIFeature) bool { return apiSymbol(f) == symbol }); i >= 0 {
+			h.Events = append(h.Events, SymbolEvent{Version: v, Type: ChangeAdded, Source: sourceAPI, Description: src.Features[v][i].Decl})
+			break
+		}
+	}
+
+	for _, e := range src.Exceptions {
+		if e.Symbol == symbol {
+			desc := fmt.Sprintf("changed from %q to %q", e.Feature.Decl, e.Decl)
+			h.Events = append(h.Events, SymbolEvent{Version: e.Version, Type: ChangeExcepted, Source: sourceExceptions, Description: desc})
+		}
+	}
+
+	var note string
+	for _, d := range src.Deprecations {
+		if d.Symbol == symbol || d.Kind == "package" && d.Symbol == pkg {
+			if d.Symbol == symbol {
+				note = d.Note
+			}
+			h.Events = append(h.Events, SymbolEvent{Version: d.Version, Type: ChangeDeprecated, Source: sourceDeprecations, Description: d.Note})
+		}
+	}
+
+	for _, vd := range src.Data {
+		for _, cat := range vd.Changes {
+			if cat.Package == pkg && cat.Type == ChangeDeprecated && cat.Description != "" {
+				h.Events = append(h.Events, SymbolEvent{Version: vd.Version, Type: ChangeDeprecated, Source: sourceReleaseNotes, Description: cat.Description})
+			}
+			for _, sc := range cat.Changes {
+				if sc.Symbol == symbol {
+					h.Events = append(h.Events, SymbolEvent{Version: vd.Version, Type: NormalizeChangeType(sc.Type), Source: sourceReleaseNotes, Description: sc.Description})
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(h.Events, func(a, b SymbolEvent) int {
+		if a.Version == "" || b.Version == "" {
+			return cmp.Compare(boolInt(a.Version == ""), boolInt(b.Version == ""))
+		}
+		return CompareVersions(a.Version, b.Version)
+	})
+	for _, e := range h.Events {
+		switch {
+		case e.Type == ChangeAdded && h.Added == "":
+			h.Added = e.Version
+		case e.Type == ChangeDeprecated && h.Deprecated == "":
+			h.Deprecated = e.Version
+			if note == "" {
+				note = e.Description
+			}
+		}
+	}
+	h.Replacement = replacementSymbol(symbol, note, src.Features)
+	return h
+}
+
+// SymbolPackage returns the import path of a fully-qualified symbol, e.g. "net/http" for
+// "net/http.Request.Context". A symbol without a package, or naming a package itself, such
+// as "io/ioutil", is returned as it is.
+func SymbolPackage(symbol string) string {
+	slash := strings.LastIndex(symbol, "/")
+	if dot := strings.Index(symbol[slash+1:], "."); dot >= 0 {
+		return symbol[:slash+1+dot]
+	}
+	return symbol
+}
+
+// replacementSymbol returns the symbol a deprecation note recommends instead of symbol: its
+// first doc link, or the identifier following "use". Identifiers of symbol's package are
+// qualified with it, and those naming a member of symbol's type with the type, when the
+// api files list such a member.
+func replacementSymbol(symbol, note string, features map[string][]APIFeature) string {
+	var name string
+	if m := docLinkRe.FindStringSubmatch(note); m != nil {
+		name = strings.TrimPrefix(m[1], "*")
+	} else if m := useInsteadRe.FindStringSubmatch(note); m != nil && exportedSymbolRe.MatchString(m[1]) {
+		name = m[1]
+	}
+	if name == "" {
+		return ""
+	}
+	if !exportedSymbolRe.MatchString(name) {
+		return name // qualified by another package, e.g. "io.ReadAll"
+	}
+	pkg := SymbolPackage(symbol)
+	if typ, _, ok := strings.Cut(strings.TrimPrefix(symbol, pkg+"."), "."); ok && !strings.Contains(name, ".") {
+		member := pkg + "." + typ + "." + name
+		for _, fs := range features {
+			if slices.ContainsFunc(fs, func(f APIFeature) bool { return apiSymbol(f) == member }) {
+				return member
+			}
+		}
+	}
+	return pkg + "." + name
+}
+
+// SymbolHistory fetches the api files of go1 and the versions of data, and api/except.txt,
+// and returns the timeline of symbol built from them, deps and data.
+func (c *Client) SymbolHistory(ctx context.Context, symbol string, data []VersionData, deps []Deprecation) (SymbolHistory, error) {
+	features, err := c.releaseAPIFeatures(ctx, data)
+	if err != nil {
+		return SymbolHistory{}, err
+	}
+	except, err := c.APIFeatures(ctx, "except")
+	if err != nil {
+		return SymbolHistory{}, err
+	}
+	return BuildSymbolHistory(symbol, HistorySources{
+		Features:     features,
+		Exceptions:   FindAPIExceptions(except, features),
+		Deprecations: deps,
+		Data:         data,
+	}), nil
+}
+
+// releaseAPIFeatures fetches the api files of go1 and the versions of data, keyed by version.
+func (c *Client) releaseAPIFeatures(ctx context.Context, data []VersionData) (map[string][]APIFeature, error) {
+	versions := []string{"go1"}
+	for _, vd := range data {
+		if vd.Version != "go1" {
+			versions = append(versions, vd.Version)
+		}
+	}
+	features := make(map[string][]APIFeature, len(versions))
+	for _, v := range versions {
+		f, err := c.APIFeatures(ctx, v)
+		if err != nil {
+			return nil, err
+		}
+		features[v] = f
+	}
+	return features, nil
+}
+
+// FetchSymbolHistory is a shorthand for NewClient().SymbolHistory.
+func FetchSymbolHistory(symbol string, data []VersionData, deps []Deprecation) (SymbolHistory, error) {
+	return NewClient().SymbolHistory(context.Background(), symbol, data, deps)
+}
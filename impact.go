@@ -0,0 +1,141 @@
+package gover
+
+import (
+	"math"
+	"strings"
+)
+
+// Impact levels, from least to most likely to affect existing code.
+const (
+	ImpactAdditive   = "additive"
+	ImpactBehavioral = "behavioral"
+	ImpactBreaking   = "breaking-ish"
+)
+
+// Impact is a heuristic estimate of how likely a change is to affect existing code.
+type Impact struct {
+	Level      string  `json:"level"`
+	Confidence float64 `json:"confidence"` // 0..1
+}
+
+// Cue phrases used by ClassifyImpact. Each additional cue found raises the confidence.
+var (
+	breakingCues = []string{
+		"removed", "no longer", "now returns an error", "now fails", "now panics", "now rejects",
+		"is now an error", "are now errors", "incompatible", "must now", "stricter",
+	}
+	behavioralCues = []string{
+		"by default", "default is now", "now defaults", "godebug", "behavior", "behaviour",
+		"now returns", "now reports", "now uses", "now treats", "instead of", "changed",
+	}
+)
+
+// ClassifyImpact estimates the impact of a change from its normalized type and description.
+func ClassifyImpact(changeType ChangeType, text string) Impact {
+	t := strings.ToLower(text)
+	breaking := countCues(t, breakingCues)
+	behavioral := countCues(t, behavioralCues)
+
+	switch {
+	case changeType == ChangeRemoved || changeType == ChangeExcepted:
+		return Impact{Level: ImpactBreaking, Confidence: confidence(0.7, breaking)}
+	case breaking > 0:
+		return Impact{Level: ImpactBreaking, Confidence: confidence(0.5, breaking-1)}
+	case changeType == ChangeDeprecated:
+		return Impact{Level: ImpactBehavioral, Confidence: confidence(0.5, behavioral)}
+	case behavioral > 0:
+		return Impact{Level: ImpactBehavioral, Confidence: confidence(0.5, behavioral-1)}
+	case changeType == ChangeAdded:
+		return Impact{Level: ImpactAdditive, Confidence: 0.8}
+	}
+	// A change with no recognizable cues is most likely a behavior change described in unusual terms.
+	return Impact{Level: ImpactBehavioral, Confidence: 0.3}
+}
+
+// ClassifyImpacts fills in the Impact of every category and symbol change in data that does not already have one.
+// It is useful for datasets written before impact classification existed.
+func ClassifyImpacts(data []VersionData) {
+	for i := range data {
+		for j := range data[i].Changes {
+			cat := &data[i].Changes[j]
+			if cat.Impact == nil && cat.Description != "" {
+				cat.Impact = classifyImpactPtr(cat.Type, cat.Description)
+			}
+			for k := range cat.Changes {
+				sc := &cat.Changes[k]
+				if sc.Impact == nil {
+					sc.Impact = classifyImpactPtr(NormalizeChangeType(sc.Type), sc.Description)
+				}
+			}
+		}
+	}
+}
+
+// classifyImpactPtr is ClassifyImpact for use in struct fields.
+func classifyImpactPtr(changeType ChangeType, text string) *Impact {
+	impact := ClassifyImpact(changeType, text)
+	return &impact
+}
+
+// countCues returns the number of cue phrases present in text.
+func countCues(text string, cues []string) int {
+	n := 0
+	for _, c := range cues {
+		if strings.Contains(text, c) {
+			n++
+		}
+	}
+	return n
+}
+
+// confidence raises base by 0.1 for each extra supporting cue, capped at 0.95.
+func confidence(base float64, extra int) float64 {
+	c := min(base+0.1*float64(max(extra, 0)), 0.95)
+	return math.Round(c*100) / 100
+}
+
+// impactRank orders impact levels for FilterByImpact.
+var impactRank = map[string]int{ImpactAdditive: 1, ImpactBehavioral: 2, ImpactBreaking: 3}
+
+// FilterByImpact returns the data restricted to changes whose impact is at least minLevel,
+// so upgrade reviews can start with the changes most likely to matter. Changes without
+// an impact are classified on the fly.
+func FilterByImpact(data []VersionData, minLevel string) []VersionData {
+	threshold := impactRank[minLevel]
+	if threshold == 0 {
+		return data
+	}
+	var out []VersionData
+	for _, vd := range data {
+		var cats []ChangeCategory
+		for _, cat := range vd.Changes {
+			var changes []SymbolChange
+			for _, sc := range cat.Changes {
+				if impactRank[impactLevel(sc.Impact, NormalizeChangeType(sc.Type), sc.Description)] >= threshold {
+					changes = append(changes, sc)
+				}
+			}
+			catLevel := ""
+			if cat.Description != "" {
+				catLevel = impactLevel(cat.Impact, cat.Type, cat.Description)
+			}
+			if len(changes) > 0 || impactRank[catLevel] >= threshold {
+				cat.Changes = changes
+				cats = append(cats, cat)
+			}
+		}
+		if len(cats) > 0 {
+			vd.Changes = cats
+			out = append(out, vd)
+		}
+	}
+	return out
+}
+
+// impactLevel returns the level of impact, classifying the change if impact is nil.
+func impactLevel(impact *Impact, changeType ChangeType, text string) string {
+	if impact != nil {
+		return impact.Level
+	}
+	return ClassifyImpact(changeType, text).Level
+}
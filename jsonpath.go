@@ -0,0 +1,331 @@
+package gover
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPath is a compiled path expression selecting values from the JSON encoding of
+// query results, see ParseJSONPath.
+type JSONPath []pathSegment
+
+// pathSegment is a step of a JSONPath.
+type pathSegment struct {
+	key    string
+	all    bool      // "*", "[*]" or "#" followed by more segments: every element
+	count  bool      // "#" as the last segment: the number of elements
+	filter *pathCond // "#(cond)" or "[?(@.cond)]": the first matching element, or all with "#(cond)#"
+}
+
+// pathCond is the condition of a filter segment.
+type pathCond struct {
+	path  JSONPath
+	op    string // "", "==", "!=", "<", "<=", ">" or ">="; "" tests that path exists
+	value any    // string, json.Number or bool
+}
+
+// ParseJSONPath compiles a path in GJSON or JSONPath style. Keys are separated by dots, and
+// a number selects an array element, counting from the end if negative; "*" selects every
+// element, and "#" does too unless it is last, when it counts them. "#(key==value)" selects
+// the first element whose key compares so with value, and "#(key==value)#" all of them;
+// the operators are ==, !=, <, <=, > and >=, and values are quoted strings, numbers or
+// booleans. A leading "$", and brackets such as [0], [*], ["key"] and [?(@.key==value)],
+// are accepted too. Examples: "versions.0.releaseDate", "versions.#.version",
+// `$.versions[?(@.version=="go1.22")].url`.
+func ParseJSONPath(expr string) (JSONPath, error) {
+	p, rest, err := parsePath(strings.TrimPrefix(strings.TrimSpace(expr), "$"), false)
+	if err == nil && rest != "" {
+		err = fmt.Errorf("unexpected %q", rest)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid path %q: %w", expr, err)
+	}
+	return p, nil
+}
+
+// parsePath parses segments up to the end of s or, in a condition, an operator or ")".
+func parsePath(s string, inCond bool) (JSONPath, string, error) {
+	var p JSONPath
+	for s != "" {
+		switch {
+		case s[0] == '.':
+			s = s[1:]
+		case inCond && (s[0] == ')' || strings.ContainsRune("=!<>", rune(s[0]))):
+			return p, s, nil
+		case strings.HasPrefix(s, "#("):
+			cond, rest, err := parseCond(s[2:])
+			if err != nil {
+				return nil, "", err
+			}
+			seg := pathSegment{filter: cond}
+			s, seg.all = strings.CutPrefix(rest, "#")
+			p = append(p, seg)
+		case s[0] == '#':
+			p = append(p, pathSegment{key: "#", all: true})
+			s = s[1:]
+		case s[0] == '[':
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, "", fmt.Errorf("missing ]")
+			}
+			inner := s[1:end]
+			switch {
+			case strings.HasPrefix(inner, "?(@"):
+				cond, rest, err := parseCond(strings.TrimPrefix(s[3:], "@."))
+				if err != nil {
+					return nil, "", err
+				}
+				if !strings.HasPrefix(rest, "]") {
+					return nil, "", fmt.Errorf("missing ]")
+				}
+				p = append(p, pathSegment{filter: cond, all: true})
+				s = rest[1:]
+				continue
+			case inner == "*":
+				p = append(p, pathSegment{all: true})
+			case strings.HasPrefix(inner, `"`) || strings.HasPrefix(inner, "'"):
+				p = append(p, pathSegment{key: strings.Trim(inner, `"'`)})
+			default:
+				if _, err := strconv.Atoi(inner); err != nil {
+					return nil, "", fmt.Errorf("invalid index [%s]", inner)
+				}
+				p = append(p, pathSegment{key: inner})
+			}
+			s = s[end+1:]
+		default:
+			var key strings.Builder
+			for s != "" && s[0] != '.' && s[0] != '[' && !(inCond && strings.ContainsRune(")=!<>", rune(s[0]))) {
+				if s[0] == '\\' && len(s) > 1 {
+					s = s[1:]
+				}
+				key.WriteByte(s[0])
+				s = s[1:]
+			}
+			p = append(p, pathSegment{key: key.String(), all: key.String() == "*"})
+		}
+	}
+	// "#" counts when last, and only then.
+	if n := len(p); n > 0 && p[n-1].key == "#" {
+		p[n-1] = pathSegment{count: true}
+	}
+	return p, "", nil
+}
+
+// parseCond parses a condition up to and including its closing ")".
+func parseCond(s string) (*pathCond, string, error) {
+	path, s, err := parsePath(s, true)
+	if err != nil {
+		return nil, "", err
+	}
+	cond := &pathCond{path: path}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if rest, ok := strings.CutPrefix(s, op); ok {
+			cond.op, s = op, strings.TrimSpace(rest)
+			break
+		}
+	}
+	if cond.op == "" {
+		rest, ok := strings.CutPrefix(s, ")")
+		if !ok {
+			return nil, "", fmt.Errorf("missing )")
+		}
+		return cond, rest, nil
+	}
+
+	var raw string
+	switch {
+	case strings.HasPrefix(s, `"`) || strings.HasPrefix(s, "'"):
+		end := strings.IndexByte(s[1:], s[0])
+		if end < 0 {
+			return nil, "", fmt.Errorf("unterminated string")
+		}
+		cond.value, s = s[1:end+1], s[end+2:]
+	default:
+		end := strings.IndexByte(s, ')')
+		if end < 0 {
+			return nil, "", fmt.Errorf("missing )")
+		}
+		raw, s = strings.TrimSpace(s[:end]), s[end:]
+		switch raw {
+		case "true", "false":
+			cond.value = raw == "true"
+		default:
+			if _, err := strconv.ParseFloat(raw, 64); err != nil {
+				return nil, "", fmt.Errorf("invalid value %s", raw)
+			}
+			cond.value = json.Number(raw)
+		}
+	}
+	rest, ok := strings.CutPrefix(strings.TrimSpace(s), ")")
+	if !ok {
+		return nil, "", fmt.Errorf("missing )")
+	}
+	return cond, rest, nil
+}
+
+// Eval returns the value p selects from the JSON encoding of v, indented as Encode's JSON.
+func (p JSONPath) Eval(v any) (json.RawMessage, error) {
+	tree, err := jsonTree(v)
+	if err != nil {
+		return nil, err
+	}
+	result, ok := p.eval(tree)
+	if !ok {
+		return nil, fmt.Errorf("path selects nothing")
+	}
+	var buf bytes.Buffer
+	writeTreeJSON(&buf, result)
+	return buf.Bytes(), nil
+}
+
+// eval applies p to a tree built by jsonTree, reporting whether it selects anything.
+func (p JSONPath) eval(node any) (any, bool) {
+	if len(p) == 0 {
+		return node, true
+	}
+	seg, rest := p[0], p[1:]
+	switch {
+	case seg.count:
+		switch n := node.(type) {
+		case []any:
+			return json.Number(strconv.Itoa(len(n))), true
+		case []field:
+			return json.Number(strconv.Itoa(len(n))), true
+		}
+		return nil, false
+	case seg.filter != nil || seg.all:
+		arr, ok := node.([]any)
+		if !ok {
+			return nil, false
+		}
+		out := []any{}
+		for _, el := range arr {
+			if seg.filter != nil && !seg.filter.match(el) {
+				continue
+			}
+			if v, ok := rest.eval(el); ok {
+				if !seg.all {
+					return v, true
+				}
+				out = append(out, v)
+			}
+		}
+		return out, seg.all
+	}
+	switch n := node.(type) {
+	case []field:
+		for _, f := range n {
+			if f.key == seg.key {
+				return rest.eval(f.value)
+			}
+		}
+	case []any:
+		if i, err := strconv.Atoi(seg.key); err == nil {
+			if i < 0 {
+				i += len(n)
+			}
+			if i >= 0 && i < len(n) {
+				return rest.eval(n[i])
+			}
+		}
+	}
+	return nil, false
+}
+
+// match reports whether node satisfies c.
+func (c *pathCond) match(node any) bool {
+	v, ok := c.path.eval(node)
+	if !ok || c.op == "" {
+		return ok
+	}
+	var order int
+	switch want := c.value.(type) {
+	case json.Number:
+		got, ok := v.(json.Number)
+		if !ok {
+			return false
+		}
+		a, _ := got.Float64()
+		b, _ := want.Float64()
+		order = cmp.Compare(a, b)
+	case string:
+		got, ok := v.(string)
+		if !ok {
+			return false
+		}
+		order = strings.Compare(got, want)
+	case bool:
+		got, ok := v.(bool)
+		if !ok || (c.op != "==" && c.op != "!=") {
+			return false
+		}
+		order = 1
+		if got == want {
+			order = 0
+		}
+	}
+	switch c.op {
+	case "==":
+		return order == 0
+	case "!=":
+		return order != 0
+	case "<":
+		return order < 0
+	case "<=":
+		return order <= 0
+	case ">":
+		return order > 0
+	}
+	return order >= 0
+}
+
+// writeTreeJSON writes a tree built by jsonTree as indented JSON, keeping the order of
+// object fields.
+func writeTreeJSON(buf *bytes.Buffer, node any) {
+	var write func(node any, indent string)
+	write = func(node any, indent string) {
+		switch n := node.(type) {
+		case []field:
+			if len(n) == 0 {
+				buf.WriteString("{}")
+				return
+			}
+			buf.WriteString("{\n")
+			for i, f := range n {
+				key, _ := json.Marshal(f.key)
+				buf.WriteString(indent + "  ")
+				buf.Write(key)
+				buf.WriteString(": ")
+				write(f.value, indent+"  ")
+				if i < len(n)-1 {
+					buf.WriteByte(',')
+				}
+				buf.WriteByte('\n')
+			}
+			buf.WriteString(indent + "}")
+		case []any:
+			if len(n) == 0 {
+				buf.WriteString("[]")
+				return
+			}
+			buf.WriteString("[\n")
+			for i, el := range n {
+				buf.WriteString(indent + "  ")
+				write(el, indent+"  ")
+				if i < len(n)-1 {
+					buf.WriteByte(',')
+				}
+				buf.WriteByte('\n')
+			}
+			buf.WriteString(indent + "]")
+		default:
+			b, _ := json.Marshal(n)
+			buf.Write(b)
+		}
+	}
+	write(node, "")
+}
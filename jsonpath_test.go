@@ -0,0 +1,122 @@
+package gover
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// jsonPathDoc is the document the JSONPath tests select from.
+var jsonPathDoc = json.RawMessage(`{
+	"versions": [
+		{"version": "go1.22", "minor": 22, "stable": true, "tags": ["loopvar", "range"]},
+		{"version": "go1.21", "minor": 21, "stable": true, "tags": []},
+		{"version": "go1.22rc1", "minor": 22, "stable": false}
+	],
+	"a.b": 1,
+	"empty": {}
+}`)
+
+func TestJSONPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string // compact JSON
+	}{
+		{"versions.0.version", `"go1.22"`},
+		{"$.versions.1.minor", `21`},
+		{"versions.-1.version", `"go1.22rc1"`},
+		{"versions.*.version", `["go1.22","go1.21","go1.22rc1"]`},
+		{"versions.#.version", `["go1.22","go1.21","go1.22rc1"]`},
+		{"versions.#", `3`},
+		{"versions.0.tags.#", `2`},
+		{"empty", `{}`},
+		{`a\.b`, `1`},
+		{`versions.#(version=="go1.21").minor`, `21`},
+		{`versions.#(minor==22)#.version`, `["go1.22","go1.22rc1"]`},
+		{`versions.#(minor!=22)#.version`, `["go1.21"]`},
+		{`versions.#(minor<22).version`, `"go1.21"`},
+		{`versions.#(minor<=22)#.version`, `["go1.22","go1.21","go1.22rc1"]`},
+		{`versions.#(minor>21)#.version`, `["go1.22","go1.22rc1"]`},
+		{`versions.#(minor>=22).version`, `"go1.22"`},
+		{`versions.#(stable==false).version`, `"go1.22rc1"`},
+		{`versions.#(version=='go1.22rc1').minor`, `22`},
+		{`versions.#(tags)#.version`, `["go1.22","go1.21"]`},
+		{`versions.#(version>"go1.21")#.version`, `["go1.22","go1.22rc1"]`},
+		{"$.versions[0].version", `"go1.22"`},
+		{"$.versions[-1].minor", `22`},
+		{"$.versions[*].minor", `[22,21,22]`},
+		{`$["versions"][1]["version"]`, `"go1.21"`},
+		{`$['a.b']`, `1`},
+		{`$.versions[?(@.version=="go1.22")].minor`, `[22]`},
+		{`$.versions[?(@.stable==true)].version`, `["go1.22","go1.21"]`},
+		{`$.versions[?(@.tags)].version`, `["go1.22","go1.21"]`},
+	}
+	for _, tt := range tests {
+		p, err := ParseJSONPath(tt.path)
+		if err != nil {
+			t.Errorf("ParseJSONPath(%q): %v", tt.path, err)
+			continue
+		}
+		got, err := p.Eval(jsonPathDoc)
+		if err != nil {
+			t.Errorf("%q: Eval: %v", tt.path, err)
+			continue
+		}
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, got); err != nil {
+			t.Errorf("%q: invalid JSON %s: %v", tt.path, got, err)
+			continue
+		}
+		if compact.String() != tt.want {
+			t.Errorf("%q = %s, want %s", tt.path, compact.String(), tt.want)
+		}
+	}
+}
+
+func TestJSONPathSelectsNothing(t *testing.T) {
+	for _, path := range []string{
+		"missing",
+		"versions.3",
+		"versions.-4",
+		"versions.version",
+		"a\\.b.#",
+		`versions.#(version=="go1.0").minor`,
+		`versions.#(stable<true).version`,
+	} {
+		p, err := ParseJSONPath(path)
+		if err != nil {
+			t.Errorf("ParseJSONPath(%q): %v", path, err)
+			continue
+		}
+		if got, err := p.Eval(jsonPathDoc); err == nil {
+			t.Errorf("%q = %s, want an error", path, got)
+		}
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	tests := []struct {
+		path string
+		want string // in the error
+	}{
+		{"versions[0", "missing ]"},
+		{"versions[x]", "invalid index [x]"},
+		{`versions[?(@.minor==22)`, "missing ]"},
+		{"versions.#(minor==22", "missing )"},
+		{"versions.#(minor", "missing )"},
+		{`versions.#(version=="go1.22)`, "unterminated string"},
+		{"versions.#(minor==abc)", "invalid value abc"},
+		{`versions.#(version=="go1.22" x)`, "missing )"},
+	}
+	for _, tt := range tests {
+		_, err := ParseJSONPath(tt.path)
+		if err == nil {
+			t.Errorf("ParseJSONPath(%q) succeeded, want an error", tt.path)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) || !strings.Contains(err.Error(), "invalid path") {
+			t.Errorf("ParseJSONPath(%q) = %v, want invalid path: %s", tt.path, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package gover
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CategoryKind is the part of the release notes a category belongs to, in ChangeCategory.Kind.
+// Like ChangeType, decoding keeps values it does not recognize as they are.
+type CategoryKind string
+
+// Category kinds.
+const (
+	KindOverview CategoryKind = "overview" // the title of the release notes
+	KindLanguage CategoryKind = "language"
+	KindPorts    CategoryKind = "ports"
+	KindTools    CategoryKind = "tools" // the go command, compiler, linker, vet, cgo and other tools
+	KindRuntime  CategoryKind = "runtime"
+	KindLibrary  CategoryKind = "library" // the standard library, including changes to individual packages
+	KindOther    CategoryKind = "other"
+)
+
+// CategoryKinds lists the category kinds.
+var CategoryKinds = []CategoryKind{KindOverview, KindLanguage, KindPorts, KindTools, KindRuntime, KindLibrary, KindOther}
+
+// categoryKindCues match the words of section headings introducing each kind, checked in
+// order. They match whole words, so that "Ports" does not match in "Support" or "Import".
+var categoryKindCues = []struct {
+	kind CategoryKind
+	re   *regexp.Regexp
+}{
+	{KindLanguage, regexp.MustCompile(`(?i)\blanguage\b`)},
+	{KindPorts, regexp.MustCompile(`(?i)\bports?\b`)},
+	{KindTools, regexp.MustCompile(`(?i)\b(?:tools?|toolchains?|go command|compiler|linker|assembler|vet|cgo|gofmt|godoc|cover(?:age)?|trace(?:r|back)?|bootstrap(?:ping)?)\b`)},
+	{KindRuntime, regexp.MustCompile(`(?i)\b(?:runtime|garbage collect(?:or|ion))\b`)},
+	{KindLibrary, regexp.MustCompile(`(?i)\b(?:library|packages?)\b`)},
+}
+
+// ParseCategoryKind returns the category kind named s, compared case-insensitively.
+func ParseCategoryKind(s string) (CategoryKind, error) {
+	k := CategoryKind(strings.ToLower(strings.TrimSpace(s)))
+	if !k.Known() {
+		return "", fmt.Errorf("unknown category kind %q (want one of %s)", s, JoinNames(CategoryKinds))
+	}
+	return k, nil
+}
+
+// Known reports whether k is one of CategoryKinds.
+func (k CategoryKind) Known() bool {
+	return slices.Contains(CategoryKinds, k)
+}
+
+// UnmarshalText normalizes the case of a known category kind and keeps others verbatim.
+func (k *CategoryKind) UnmarshalText(b []byte) error {
+	if n, err := ParseCategoryKind(string(b)); err == nil {
+		*k = n
+	} else {
+		*k = CategoryKind(b)
+	}
+	return nil
+}
+
+// anchorKinds maps the anchor IDs go.dev gives the sections of release notes, which stay
+// the same from release to release whatever the wording of their headings, to their kinds.
+var anchorKinds = map[string]CategoryKind{
+	"language":              KindLanguage,
+	"ports":                 KindPorts,
+	"tools":                 KindTools,
+	"go-command":            KindTools,
+	"gocmd":                 KindTools,
+	"vet":                   KindTools,
+	"cgo":                   KindTools,
+	"compiler":              KindTools,
+	"linker":                KindTools,
+	"assembler":             KindTools,
+	"bootstrap":             KindTools,
+	"gofmt":                 KindTools,
+	"cover":                 KindTools,
+	"trace":                 KindTools,
+	"runtime":               KindRuntime,
+	"gc":                    KindRuntime,
+	"library":               KindLibrary,
+	"stdlib":                KindLibrary,
+	"minor_library_changes": KindLibrary,
+}
+
+// classifySection returns the kind of category a section introduces: by its anchor ID if
+// that is one of anchorKinds, and otherwise by its heading, see classifyHeading.
+func classifySection(anchor, heading string) CategoryKind {
+	if kind, ok := anchorKinds[strings.ToLower(anchor)]; ok {
+		return kind
+	}
+	return classifyHeading(heading)
+}
+
+// classifyHeading returns the kind of category a section heading introduces, or KindOther.
+func classifyHeading(heading string) CategoryKind {
+	for _, c := range categoryKindCues {
+		if c.re.MatchString(heading) {
+			return c.kind
+		}
+	}
+	return KindOther
+}
@@ -0,0 +1,85 @@
+package gover
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+// Matchers of the elements declaring the language of a page.
+var (
+	htmlMatcher     = cascadia.MustCompile("html[lang]")
+	langMetaMatcher = cascadia.MustCompile(`meta[http-equiv="content-language" i][content]`)
+)
+
+// languageSample is the number of words of a page detectLanguage reads.
+const languageSample = 500
+
+// minLanguageSample is the fewest words detectLanguage judges a page's language by.
+const minLanguageSample = 40
+
+// englishWords are common English function words. They make up a third or more of English
+// prose, and next to none of that in other languages.
+var englishWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "be": true, "by": true,
+	"can": true, "for": true, "from": true, "has": true, "have": true, "in": true, "is": true,
+	"it": true, "its": true, "now": true, "of": true, "on": true, "or": true, "that": true,
+	"the": true, "this": true, "to": true, "was": true, "were": true, "when": true,
+	"which": true, "will": true, "with": true,
+}
+
+// detectLanguage returns the language of a release notes page as a lower-case ISO 639-1
+// code, such as "en" or "zh", taken from the lang attribute of its html element or its
+// Content-Language meta tag. Undeclared languages are guessed from the text of content:
+// "zh", "ja", "ko" or "ru" by script, "en" if it reads as English, and otherwise "und"
+// (undetermined). Pages with too little text to tell return "".
+func detectLanguage(page *goquery.Selection, content goquery.Matcher) string {
+	root := page.FilterMatcher(htmlMatcher).AddSelection(page.FindMatcher(htmlMatcher)).First()
+	lang := root.AttrOr("lang", "")
+	if lang == "" {
+		lang = page.FindMatcher(langMetaMatcher).First().AttrOr("content", "")
+	}
+	if lang, _, _ = strings.Cut(strings.TrimSpace(lang), ","); lang != "" {
+		primary, _, _ := strings.Cut(lang, "-")
+		return strings.ToLower(strings.TrimSpace(primary))
+	}
+
+	main := page.FindMatcher(content).First()
+	if main.Length() == 0 {
+		main = page.FindMatcher(bodyMatcher).First()
+	}
+	var words, english int
+	scripts := make(map[string]int)
+	for _, w := range strings.FieldsFunc(main.Text(), func(r rune) bool { return !unicode.IsLetter(r) && r != '\'' }) {
+		if words++; words > languageSample {
+			break
+		}
+		if englishWords[strings.ToLower(w)] {
+			english++
+		}
+		for _, r := range w {
+			for _, script := range []string{"Hiragana", "Katakana", "Hangul", "Han", "Cyrillic"} {
+				if unicode.Is(unicode.Scripts[script], r) {
+					scripts[script]++
+				}
+			}
+		}
+	}
+	switch {
+	case scripts["Hiragana"]+scripts["Katakana"] > 0 && scripts["Hiragana"]+scripts["Katakana"]+scripts["Han"] >= minLanguageSample:
+		return "ja"
+	case scripts["Hangul"] >= minLanguageSample:
+		return "ko"
+	case scripts["Han"] >= minLanguageSample:
+		return "zh"
+	case scripts["Cyrillic"] >= minLanguageSample:
+		return "ru"
+	case words < minLanguageSample:
+		return ""
+	case english*10 >= words:
+		return "en"
+	}
+	return "und"
+}
@@ -0,0 +1,147 @@
+package gover
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Linking topics.
+const (
+	LinkCgo      = "cgo"
+	LinkExternal = "external linking"
+	LinkInternal = "internal linking"
+	LinkLinker   = "linker"
+)
+
+// LinkingChange is a paragraph of the release notes about cgo or linking, which teams
+// shipping cgo binaries review on every upgrade.
+type LinkingChange struct {
+	Topic     string   `json:"topic"`               // LinkCgo, LinkExternal, LinkInternal or LinkLinker
+	Platforms []string `json:"platforms,omitempty"` // the platforms it is limited to, e.g. "windows/arm64" or "linux"
+	Section   string   `json:"section,omitempty"`   // the enclosing heading
+	Text      string   `json:"text"`
+}
+
+// linkingTopics maps cues to the topic they indicate, checked in order.
+var linkingTopics = []struct {
+	re    *regexp.Regexp
+	topic string
+}{
+	{regexp.MustCompile(`(?i)\bexternal(?:ly)?[- ]link|-linkmode[= ]external`), LinkExternal},
+	{regexp.MustCompile(`(?i)\binternal(?:ly)?[- ]link|-linkmode[= ]internal`), LinkInternal},
+	{regexp.MustCompile(`(?i)\bcgo\b|\bCGO_\w+`), LinkCgo},
+	{regexp.MustCompile(`(?i)\blinker\b|\bcmd/link\b|-ldflags\b`), LinkLinker},
+}
+
+// extractLinking collects the paragraphs on a release notes page about cgo and linking:
+// those mentioning them, and those in sections headed "Cgo" or "Linker".
+func extractLinking(page *goquery.Selection) []LinkingChange {
+	var changes []LinkingChange
+	heading, headingTopic := "", ""
+	seen := make(map[string]bool)
+	page.FindMatcher(noteMatcher).Each(func(_ int, s *goquery.Selection) {
+		text := collapseSpace(s.Text())
+		if s.IsMatcher(noteHeadingMatcher) {
+			heading, headingTopic = text, linkingTopic(text)
+			return
+		}
+		if text == "" || seen[text] || s.FindMatcher(paragraphMatcher).Length() > 0 {
+			return
+		}
+		topic := linkingTopic(text)
+		if topic == "" {
+			topic = headingTopic
+		}
+		if topic == "" {
+			return
+		}
+		seen[text] = true
+		changes = append(changes, LinkingChange{
+			Topic:     topic,
+			Platforms: platformQualifiers(text),
+			Section:   heading,
+			Text:      text,
+		})
+	})
+	return changes
+}
+
+// linkingTopic returns the linking topic text mentions, or "".
+func linkingTopic(text string) string {
+	for _, t := range linkingTopics {
+		if t.re.MatchString(text) {
+			return t.topic
+		}
+	}
+	return ""
+}
+
+// platformAliases are the names the release notes use for some GOOS values.
+var platformAliases = map[string]string{
+	"macos": "darwin",
+	"os x":  "darwin",
+}
+
+// portNames maps the GOOS and GOARCH values among the build tags in Features to "GOOS" or
+// "GOARCH".
+var portNames = func() map[string]string {
+	names := make(map[string]string)
+	for _, f := range Features {
+		if kind, _, ok := strings.Cut(f.Description, "="); ok && f.Kind == FeatureBuildTag && (kind == "GOOS" || kind == "GOARCH") {
+			names[f.Name] = kind
+		}
+	}
+	return names
+}()
+
+// impliedGOOS maps GOOS values to the GOOS whose build tag they also satisfy, as their
+// build tags in Features describe.
+var impliedGOOS = func() map[string]string {
+	re := regexp.MustCompile(`also satisfies the (\w+) tag`)
+	implied := make(map[string]string)
+	for _, f := range Features {
+		if m := re.FindStringSubmatch(f.Description); m != nil && f.Kind == FeatureBuildTag {
+			implied[f.Name] = m[1]
+		}
+	}
+	return implied
+}()
+
+// portNameRe matches GOOS and GOARCH values, "GOOS/GOARCH" pairs and platform names.
+var portNameRe = func() *regexp.Regexp {
+	var names []string
+	for name := range portNames {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	for alias := range platformAliases {
+		names = append(names, regexp.QuoteMeta(alias))
+	}
+	slices.SortFunc(names, func(a, b string) int { return len(b) - len(a) }) // longest first
+	name := `(?:` + strings.Join(names, "|") + `)`
+	return regexp.MustCompile(`(?i)(?:^|[^\w/-])(` + name + `(?:/` + name + `)?)(?:$|[^\w/-])`)
+}()
+
+// platformQualifiers returns the platforms text mentions, as GOOS, GOARCH or "GOOS/GOARCH"
+// values in order of appearance, or nil if it mentions none.
+func platformQualifiers(text string) []string {
+	var platforms []string
+	// Matches are separated by at least one character, so search overlapping windows.
+	for i := 0; i < len(text); {
+		loc := portNameRe.FindStringSubmatchIndex(text[i:])
+		if loc == nil {
+			break
+		}
+		p := strings.ToLower(text[i+loc[2] : i+loc[3]])
+		if goos, ok := platformAliases[p]; ok {
+			p = goos
+		}
+		if !slices.Contains(platforms, p) {
+			platforms = append(platforms, p)
+		}
+		i += loc[3]
+	}
+	return platforms
+}
@@ -0,0 +1,135 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// linkWorkers is the number of links checked concurrently.
+const linkWorkers = 4
+
+// LinkStatus is the result of checking a hyperlink of a release notes page.
+type LinkStatus struct {
+	URL    string `json:"url"`
+	Text   string `json:"text,omitempty"`   // the text of its first link
+	Anchor bool   `json:"anchor,omitempty"` // set for links to an anchor on the page itself
+	Status int    `json:"status,omitempty"` // the HTTP status, after redirects
+	Error  string `json:"error,omitempty"`
+	Dead   bool   `json:"dead"`
+}
+
+// CheckLinks fetches the release notes of version and checks every hyperlink in their
+// content: links to anchors on the page must name an element's id, and other links must
+// return a successful status. Each URL is checked once; mailto and javascript links are
+// skipped. The results are in page order.
+func (c *Client) CheckLinks(ctx context.Context, version string) ([]LinkStatus, error) {
+	pageURL := c.url("/doc/" + NormalizeVersion(version))
+	body, err := c.get(ctx, pageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %w", pageURL, err)
+	}
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, parseError(fmt.Errorf("failed to parse %s: %w", pageURL, err))
+	}
+	links, err := pageLinks(doc.Selection, pageURL, c.sel.ReleaseNotes.Content)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []int
+	for i := range links {
+		if !links[i].Anchor {
+			pending = append(pending, i)
+		}
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range linkWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				links[i].Status, links[i].Error = c.checkURL(ctx, links[i].URL)
+				links[i].Dead = links[i].Error != "" || links[i].Status >= http.StatusBadRequest
+			}
+		}()
+	}
+	for _, i := range pending {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return links, ctx.Err()
+}
+
+// CheckLinks is a shorthand for NewClient().CheckLinks.
+func CheckLinks(version string) ([]LinkStatus, error) {
+	return NewClient().CheckLinks(context.Background(), version)
+}
+
+// pageLinks returns the distinct links in the content of a page, resolved against pageURL,
+// with links to anchors on the page already checked.
+func pageLinks(page *goquery.Selection, pageURL, contentSelector string) ([]LinkStatus, error) {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	content := page.Find(contentSelector).First()
+	if content.Length() == 0 {
+		content = page.Find("body")
+	}
+	ids := make(map[string]bool)
+	page.Find("[id], a[name]").Each(func(_ int, s *goquery.Selection) {
+		ids[s.AttrOr("id", s.AttrOr("name", ""))] = true
+	})
+
+	var links []LinkStatus
+	seen := make(map[string]bool)
+	content.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		ref, err := url.Parse(strings.TrimSpace(s.AttrOr("href", "")))
+		if err != nil || ref.Scheme == "mailto" || ref.Scheme == "javascript" {
+			return
+		}
+		u := base.ResolveReference(ref)
+		key := u.String()
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		link := LinkStatus{URL: key, Text: strings.Join(strings.Fields(s.Text()), " ")}
+		if u.Fragment != "" && u.Scheme == base.Scheme && u.Host == base.Host && u.Path == base.Path {
+			link.Anchor = true
+			link.Dead = !ids[u.Fragment]
+			if link.Dead {
+				link.Error = "no element with id " + u.Fragment
+			}
+		}
+		links = append(links, link)
+	})
+	return links, nil
+}
+
+// checkURL fetches u and returns the final status, or the error that prevented it.
+func (c *Client) checkURL(ctx context.Context, u string) (int, string) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err.Error()
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return 0, err.Error()
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, ""
+}
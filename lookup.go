@@ -0,0 +1,157 @@
+package gover
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// SymbolMatch is a symbol change found by LookupSymbol, along with the version and package it belongs to.
+type SymbolMatch struct {
+	Version string       `json:"version"`
+	Package string       `json:"package,omitempty"`
+	Change  SymbolChange `json:"change"`
+	Score   int          `json:"score"`
+}
+
+// maxSuggestions caps the number of did-you-mean suggestions returned by LookupSymbol.
+const maxSuggestions = 5
+
+// LookupSymbol finds symbol changes matching query, which may be a fully-qualified name
+// ("net/http.NewRequestWithContext"), a partial one ("http.NewRequest") or a bare identifier.
+// Matches are ordered best first, then by version. When nothing matches, the closest known
+// symbols are returned as did-you-mean suggestions.
+func LookupSymbol(data []VersionData, query string) ([]SymbolMatch, []string) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, nil
+	}
+
+	var matches []SymbolMatch
+	fuzzy := make(map[string]int)
+	for _, vd := range data {
+		for _, cat := range vd.Changes {
+			for _, sc := range cat.Changes {
+				if sc.Symbol == "" {
+					continue
+				}
+				if score := matchScore(query, sc.Symbol); score > 0 {
+					matches = append(matches, SymbolMatch{
+						Version: vd.Version,
+						Package: cat.Package,
+						Change:  sc,
+						Score:   score,
+					})
+				} else if score := fuzzyScore(query, sc.Symbol); score > 0 {
+					fuzzy[sc.Symbol] = max(fuzzy[sc.Symbol], score)
+				}
+			}
+		}
+	}
+
+	if len(matches) > 0 {
+		slices.SortStableFunc(matches, func(a, b SymbolMatch) int {
+			if c := cmp.Compare(b.Score, a.Score); c != 0 {
+				return c
+			}
+			return cmp.Compare(parseVersionMinor(a.Version), parseVersionMinor(b.Version))
+		})
+		return matches, nil
+	}
+
+	suggestions := make([]string, 0, len(fuzzy))
+	for sym := range fuzzy {
+		suggestions = append(suggestions, sym)
+	}
+	slices.SortFunc(suggestions, func(a, b string) int {
+		if c := cmp.Compare(fuzzy[b], fuzzy[a]); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	if len(suggestions) > maxSuggestions {
+		suggestions = suggestions[:maxSuggestions]
+	}
+	return nil, suggestions
+}
+
+// matchScore scores exact and partial (case-insensitive) matches of query against symbol.
+// It returns 0 if the query is not contained in the symbol.
+func matchScore(query, symbol string) int {
+	q, s := strings.ToLower(query), strings.ToLower(symbol)
+	switch {
+	case s == q:
+		return 100
+	case symbolName(s) == q:
+		return 90
+	case strings.HasSuffix(s, "."+q) || strings.HasSuffix(s, "/"+q):
+		return 85
+	case strings.Contains(s, q):
+		return 70
+	}
+	return 0
+}
+
+// fuzzyScore scores near-misses such as abbreviations ("NewRequestWithCtx") and typos
+// ("NewReqeust"). It returns 0 if symbol is not a plausible suggestion for query, and for
+// queries naming no identifier, such as "net/http.", which every symbol would match.
+func fuzzyScore(query, symbol string) int {
+	q := strings.ToLower(symbolName(query))
+	name := strings.ToLower(symbolName(symbol))
+	if q == "" {
+		return 0
+	}
+
+	dist := levenshtein(q, name)
+	limit := max(2, len(q)/3)
+	switch {
+	case isSubsequence(q, name):
+		return 60 - min(dist, 50)
+	case dist <= limit:
+		return 50 - dist
+	}
+	return 0
+}
+
+// symbolName returns the identifier part of a qualified symbol, e.g. "Client.Do" for "net/http.Client.Do".
+func symbolName(symbol string) string {
+	if i := strings.LastIndex(symbol, "/"); i >= 0 {
+		symbol = symbol[i+1:]
+	}
+	if i := strings.Index(symbol, "."); i >= 0 {
+		return symbol[i+1:]
+	}
+	return symbol
+}
+
+// isSubsequence reports whether all the bytes of sub appear in s in order.
+func isSubsequence(sub, s string) bool {
+	i := 0
+	for j := 0; i < len(sub) && j < len(s); j++ {
+		if sub[i] == s[j] {
+			i++
+		}
+	}
+	return i == len(sub)
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
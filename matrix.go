@@ -0,0 +1,50 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// MatrixResult is the outcome of running a command under one toolchain.
+type MatrixResult struct {
+	Toolchain string        `json:"toolchain"`
+	Passed    bool          `json:"passed"`
+	Error     string        `json:"error,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	Output    string        `json:"output,omitempty"`
+}
+
+// RunMatrix runs the command once per toolchain, in order, and reports the outcome of each run.
+// The toolchain is selected with GOTOOLCHAIN, so the go command downloads it into the module
+// cache on first use and reuses it afterwards; this requires toolchains from go1.21.0 on.
+func RunMatrix(ctx context.Context, toolchains []string, name string, args ...string) []MatrixResult {
+	results := make([]MatrixResult, 0, len(toolchains))
+	for _, tc := range toolchains {
+		var out bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Env = append(os.Environ(), "GOTOOLCHAIN="+tc)
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+
+		start := time.Now()
+		err := cmd.Run()
+		r := MatrixResult{
+			Toolchain: tc,
+			Passed:    err == nil,
+			Duration:  time.Since(start),
+			Output:    out.String(),
+		}
+		if err != nil {
+			r.Error = err.Error()
+		}
+		results = append(results, r)
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return results
+}
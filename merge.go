@@ -0,0 +1,69 @@
+package gover
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// MergeDatasets combines datasets that each cover some of the versions, such as those
+// written by scraping jobs split by version range, into one. When several datasets
+// contain the same version, the entry scraped without errors is preferred, then the one
+// from the most recently generated dataset (the later argument on a tie). Fields left
+// empty in the preferred entry are filled in from the others, in the same order, except
+// those describing the scrape of the entry itself, see entryFields.
+func MergeDatasets(datasets ...*Dataset) *Dataset {
+	type candidate struct {
+		vd          VersionData
+		generatedAt int64
+		order       int
+	}
+	byVersion := map[string][]candidate{}
+	for i, ds := range datasets {
+		for _, vd := range ds.Versions {
+			byVersion[vd.Version] = append(byVersion[vd.Version], candidate{vd, ds.GeneratedAt.UnixNano(), i})
+		}
+	}
+
+	versions := make([]VersionData, 0, len(byVersion))
+	for _, cands := range byVersion {
+		slices.SortFunc(cands, func(a, b candidate) int {
+			if c := cmp.Compare(boolInt(len(a.vd.Errors) > 0), boolInt(len(b.vd.Errors) > 0)); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(b.generatedAt, a.generatedAt); c != 0 {
+				return c
+			}
+			return cmp.Compare(b.order, a.order)
+		})
+		merged := cands[0].vd
+		for _, c := range cands[1:] {
+			fillEmpty(&merged, c.vd)
+		}
+		versions = append(versions, merged)
+	}
+	slices.SortFunc(versions, func(a, b VersionData) int {
+		return CompareVersions(b.Version, a.Version)
+	})
+	return NewDataset(versions)
+}
+
+// entryFields are the fields of a VersionData that describe the page it was scraped from,
+// rather than the release: they hold for that entry only, so merging never copies them
+// from another. An entry without a fingerprint or draft status must not claim those of
+// different data.
+var entryFields = map[string]bool{"Errors": true, "Fingerprint": true, "Draft": true}
+
+// fillEmpty sets the empty fields of dst, other than entryFields, to those of src.
+func fillEmpty(dst *VersionData, src VersionData) {
+	dv, sv := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src)
+	for i := range dv.NumField() {
+		if entryFields[dv.Type().Field(i).Name] {
+			continue
+		}
+		f := dv.Field(i)
+		if f.IsZero() || (f.Kind() == reflect.Slice && f.Len() == 0) {
+			f.Set(sv.Field(i))
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package gover
+
+import (
+	"io"
+	"net/http"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// ScrapeMetrics measures the performance of a scrape, see WithMetrics.
+type ScrapeMetrics struct {
+	Pages      int           `json:"pages"`      // release notes pages parsed
+	Requests   int           `json:"requests"`   // pages fetched, including those answered from the cache
+	Bytes      int64         `json:"bytes"`      // the size of the fetched pages
+	Duration   time.Duration `json:"duration"`   // the whole scrape
+	ParseTime  time.Duration `json:"parseTime"`  // spent parsing release notes pages, summed over workers
+	Allocs     uint64        `json:"allocs"`     // heap allocations during the scrape
+	AllocBytes uint64        `json:"allocBytes"` // bytes allocated during the scrape
+}
+
+// PagesPerSecond returns the number of release notes pages parsed per second of the scrape.
+func (m ScrapeMetrics) PagesPerSecond() float64 {
+	if m.Duration <= 0 {
+		return 0
+	}
+	return float64(m.Pages) / m.Duration.Seconds()
+}
+
+// WithMetrics makes ScrapeDataset record its performance in m when it returns, so that
+// regressions in the parser show up. Allocations are those of the whole process while
+// the scrape runs.
+func WithMetrics(m *ScrapeMetrics) Option {
+	return func(o *options) {
+		o.metrics = m
+	}
+}
+
+// meter accumulates the metrics of a client's requests and parses.
+type meter struct {
+	pages, requests, bytes, parseTime atomic.Int64
+}
+
+// measure runs scrape, recording its metrics in m if it is not nil.
+func (c *Client) measure(m *ScrapeMetrics, scrape func()) {
+	if m == nil {
+		scrape()
+		return
+	}
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	pages, requests, bytes, parseTime := c.meter.pages.Load(), c.meter.requests.Load(), c.meter.bytes.Load(), c.meter.parseTime.Load()
+	scrape()
+	*m = ScrapeMetrics{
+		Pages:     int(c.meter.pages.Load() - pages),
+		Requests:  int(c.meter.requests.Load() - requests),
+		Bytes:     c.meter.bytes.Load() - bytes,
+		Duration:  time.Since(start),
+		ParseTime: time.Duration(c.meter.parseTime.Load() - parseTime),
+	}
+	runtime.ReadMemStats(&after)
+	m.Allocs = after.Mallocs - before.Mallocs
+	m.AllocBytes = after.TotalAlloc - before.TotalAlloc
+}
+
+// parsed records the parse of a release notes page that started at start.
+func (m *meter) parsed(start time.Time) {
+	m.pages.Add(1)
+	m.parseTime.Add(int64(time.Since(start)))
+}
+
+// meterTransport counts the requests and response bytes passing through it.
+type meterTransport struct {
+	meter *meter
+	next  http.RoundTripper
+}
+
+func (t meterTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	t.meter.requests.Add(1)
+	resp.Body = countingBody{ReadCloser: resp.Body, n: &t.meter.bytes}
+	return resp, nil
+}
+
+// countingBody adds the bytes read from a response body to n.
+type countingBody struct {
+	io.ReadCloser
+	n *atomic.Int64
+}
+
+func (b countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n.Add(int64(n))
+	return n, err
+}
@@ -0,0 +1,114 @@
+package gover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// defaultGitHubAPI is the GitHub REST API unless WithGitHubAPIURL is given.
+const defaultGitHubAPI = "https://api.github.com"
+
+// milestoneIssuesPath lists the closed issues of a milestone of the Go issue tracker.
+const milestoneIssuesPath = "/repos/golang/go/issues?state=closed&per_page=100"
+
+// githubURL returns the absolute URL of path on the configured GitHub API.
+func (c *Client) githubURL(path string) string {
+	return c.opts.githubAPI + path
+}
+
+// isGitHubAPI reports whether u is on the host of the configured GitHub API, which is sent
+// the token set with WithGitHubToken.
+func (c *Client) isGitHubAPI(u *url.URL) bool {
+	api, err := url.Parse(c.opts.githubAPI)
+	return err == nil && api.Host != "" && u.Host == api.Host
+}
+
+// MilestoneIssue is an issue closed in the GitHub milestone of a release. The release
+// notes mention only a curated subset of these.
+type MilestoneIssue struct {
+	Number   int      `json:"number"`
+	Title    string   `json:"title"`
+	URL      string   `json:"url"`
+	Labels   []string `json:"labels,omitempty"`
+	ClosedAt string   `json:"closedAt,omitempty"` // RFC3339
+}
+
+// MilestoneIssues fetches the issues closed in the GitHub milestone of version, such as
+// "go1.22", oldest first. Releases predating the move to GitHub have no milestone.
+func (c *Client) MilestoneIssues(ctx context.Context, version string) ([]MilestoneIssue, error) {
+	milestones, err := c.milestones(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := milestones[NormalizeVersion(version)]
+	if !ok {
+		return nil, fmt.Errorf("no milestone for %s", version)
+	}
+	return c.milestoneIssues(ctx, m)
+}
+
+// milestoneIssues fetches the closed issues of m, leaving out pull requests.
+func (c *Client) milestoneIssues(ctx context.Context, m milestone) ([]MilestoneIssue, error) {
+	var issues []MilestoneIssue
+	for page := 1; ; page++ {
+		body, err := c.get(ctx, fmt.Sprintf("%s&milestone=%d&sort=created&direction=asc&page=%d", c.githubURL(milestoneIssuesPath), m.Number, page))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch issues of milestone %s: %w", m.Title, err)
+		}
+		var items []struct {
+			Number   int    `json:"number"`
+			Title    string `json:"title"`
+			HTMLURL  string `json:"html_url"`
+			ClosedAt string `json:"closed_at"`
+			Labels   []struct {
+				Name string `json:"name"`
+			} `json:"labels"`
+			PullRequest json.RawMessage `json:"pull_request"`
+		}
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, parseError(fmt.Errorf("failed to decode issues of milestone %s: %w", m.Title, err))
+		}
+		if len(items) == 0 {
+			return issues, nil
+		}
+		for _, it := range items {
+			if it.PullRequest != nil {
+				continue
+			}
+			issue := MilestoneIssue{Number: it.Number, Title: it.Title, URL: it.HTMLURL, ClosedAt: it.ClosedAt}
+			for _, l := range it.Labels {
+				issue.Labels = append(issue.Labels, l.Name)
+			}
+			issues = append(issues, issue)
+		}
+	}
+}
+
+// addMilestoneIssues fills in the milestone issues of data. The GitHub API allows too few
+// requests without a token for more than a release or two, so without one this is skipped.
+// Failures only lose the issues, so they are logged rather than returned.
+func (c *Client) addMilestoneIssues(ctx context.Context, data []VersionData) {
+	if c.opts.githubToken == "" {
+		c.opts.logger.Printf("Warning: milestone issues not collected: a GitHub token is required")
+		return
+	}
+	milestones, err := c.milestones(ctx)
+	if err != nil {
+		c.opts.logger.Printf("Warning: milestone issues not collected: %v", err)
+		return
+	}
+	for i := range data {
+		m, ok := milestones[data[i].Version]
+		if !ok {
+			continue
+		}
+		issues, err := c.milestoneIssues(ctx, m)
+		if err != nil {
+			c.opts.logger.Printf("Warning: %v", err)
+			continue
+		}
+		data[i].MilestoneIssues = issues
+	}
+}
@@ -0,0 +1,224 @@
+package gover
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Requirement kinds, besides FeatureLanguage.
+const (
+	RequirePackage = "package"
+	RequireSymbol  = "symbol"
+)
+
+// VersionRequirement is a use of a standard library package or symbol, or of a language
+// feature, that needs a minimum Go release.
+type VersionRequirement struct {
+	Version string `json:"version"`
+	Kind    string `json:"kind"` // RequirePackage, RequireSymbol or FeatureLanguage
+	Name    string `json:"name"` // e.g. "slices", "net/http.ServeFileFS" or "range-over-int"
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+}
+
+// MinVersion is the minimum Go release that can compile some code, and the uses requiring
+// releases newer than go1, newest first.
+type MinVersion struct {
+	Version      string               `json:"version"`
+	Requirements []VersionRequirement `json:"requirements,omitempty"`
+}
+
+// APIAdditions returns the release whose api file first lists each symbol, such as
+// "net/http.Request.PathValue", and each package, keyed by name.
+func APIAdditions(features map[string][]APIFeature) map[string]string {
+	added := make(map[string]string)
+	record := func(name, version string) {
+		if prev, ok := added[name]; !ok || CompareVersions(version, prev) < 0 {
+			added[name] = version
+		}
+	}
+	for v, fs := range features {
+		for _, f := range fs {
+			record(f.Package, v)
+			record(apiSymbol(f), v)
+		}
+	}
+	return added
+}
+
+// FindMinVersion parses the Go files at paths and infers the minimum Go release that could
+// compile them from the standard library packages and package-level symbols they use, looked
+// up in added (see APIAdditions), and the language features they use (see Features). Without
+// type information, methods and fields are not resolved and some features, such as range
+// over a variable of integer type, are not detected, so the result is a lower bound.
+func FindMinVersion(paths []string, added map[string]string) (MinVersion, error) {
+	fset := token.NewFileSet()
+	var reqs []VersionRequirement
+	for _, p := range paths {
+		file, err := parser.ParseFile(fset, p, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return MinVersion{}, parseError(fmt.Errorf("failed to parse %s: %w", p, err))
+		}
+		reqs = append(reqs, fileRequirements(fset, file, added)...)
+	}
+
+	mv := MinVersion{Version: "go1"}
+	for _, r := range reqs {
+		if r.Version != "go1" {
+			mv.Requirements = append(mv.Requirements, r)
+		}
+	}
+	slices.SortStableFunc(mv.Requirements, func(a, b VersionRequirement) int {
+		if c := CompareVersions(b.Version, a.Version); c != 0 {
+			return c
+		}
+		return cmp.Or(strings.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+	if len(mv.Requirements) > 0 {
+		mv.Version = mv.Requirements[0].Version
+	}
+	return mv, nil
+}
+
+// fileRequirements returns the uses in file of packages and symbols listed in added, and of
+// language features.
+func fileRequirements(fset *token.FileSet, file *ast.File, added map[string]string) []VersionRequirement {
+	var reqs []VersionRequirement
+	add := func(kind, name, version string, pos token.Pos) {
+		p := fset.Position(pos)
+		reqs = append(reqs, VersionRequirement{Version: version, Kind: kind, Name: name, File: p.Filename, Line: p.Line, Column: p.Column})
+	}
+	feature := func(name string, pos token.Pos) {
+		if i := slices.IndexFunc(Features, func(f Feature) bool { return f.Name == name }); i >= 0 {
+			add(FeatureLanguage, name, Features[i].Version, pos)
+		}
+	}
+
+	for _, imp := range file.Imports {
+		if importPath, err := strconv.Unquote(imp.Path.Value); err == nil {
+			if v, ok := added[importPath]; ok {
+				add(RequirePackage, importPath, v, imp.Pos())
+			}
+		}
+	}
+	for _, use := range fileSymbolUses(fset, file) {
+		if v, ok := added[use.Symbol]; ok {
+			reqs = append(reqs, VersionRequirement{Version: v, Kind: RequireSymbol, Name: use.Symbol, File: use.File, Line: use.Line, Column: use.Column})
+		}
+	}
+
+	// Predeclared identifiers the file declares itself, such as a min function written
+	// before go1.21, do not need a newer release.
+	declared := make(map[string]bool)
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil {
+				declared[d.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					declared[s.Name.Name] = true
+				case *ast.ValueSpec:
+					for _, n := range s.Names {
+						declared[n.Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	var visit func(n ast.Node) bool
+	visit = func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			ast.Inspect(n.X, visit)
+			return false // the selected name is not predeclared
+		case *ast.TypeSpec:
+			switch {
+			case n.Assign.IsValid() && n.TypeParams != nil:
+				feature("generic-type-alias", n.Pos())
+			case n.Assign.IsValid():
+				feature("type-alias", n.Pos())
+			case n.TypeParams != nil:
+				feature("type-parameters", n.Pos())
+			}
+		case *ast.FuncType:
+			if n.TypeParams != nil {
+				feature("type-parameters", n.Pos())
+			}
+		case *ast.BasicLit:
+			if name := literalFeature(n); name != "" {
+				feature(name, n.Pos())
+			}
+		case *ast.Ident:
+			if (n.Name == "any" || n.Name == "comparable") && !declared[n.Name] {
+				feature(n.Name, n.Pos())
+			}
+		case *ast.CallExpr:
+			if fn, ok := n.Fun.(*ast.Ident); ok && !declared[fn.Name] {
+				switch fn.Name {
+				case "min", "max":
+					feature("min-max-builtins", n.Pos())
+				case "clear":
+					feature("clear-builtin", n.Pos())
+				}
+			}
+		case *ast.RangeStmt:
+			switch x := n.X.(type) {
+			case *ast.BasicLit:
+				if x.Kind == token.INT {
+					feature("range-over-int", n.Pos())
+				}
+			case *ast.CallExpr:
+				if fn, ok := x.Fun.(*ast.Ident); ok && (fn.Name == "len" || fn.Name == "int") && !declared[fn.Name] {
+					feature("range-over-int", n.Pos())
+				}
+			case *ast.FuncLit:
+				feature("range-over-func", n.Pos())
+			}
+		}
+		return true
+	}
+	ast.Inspect(file, visit)
+	return reqs
+}
+
+// literalFeature returns the language feature a number literal needs, or "".
+func literalFeature(lit *ast.BasicLit) string {
+	if lit.Kind != token.INT && lit.Kind != token.FLOAT && lit.Kind != token.IMAG {
+		return ""
+	}
+	v := strings.ToLower(lit.Value)
+	switch {
+	case strings.HasPrefix(v, "0b"):
+		return "binary-literal"
+	case strings.HasPrefix(v, "0o"):
+		return "octal-literal"
+	case strings.HasPrefix(v, "0x") && lit.Kind != token.INT:
+		return "hex-float-literal"
+	case strings.Contains(v, "_"):
+		return "digit-separator"
+	}
+	return ""
+}
+
+// MinVersion fetches the api files of go1 and the versions of data and returns the minimum
+// Go release that could compile the Go files at paths, see FindMinVersion.
+func (c *Client) MinVersion(ctx context.Context, paths []string, data []VersionData) (MinVersion, error) {
+	features, err := c.releaseAPIFeatures(ctx, data)
+	if err != nil {
+		return MinVersion{}, err
+	}
+	return FindMinVersion(paths, APIAdditions(features))
+}
@@ -0,0 +1,241 @@
+package gover
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// MirrorReport lists what Mirror saved, by URL path.
+type MirrorReport struct {
+	Pages  []string `json:"pages"`
+	Assets []string `json:"assets,omitempty"`
+	Failed []string `json:"failed,omitempty"` // pages and assets that could not be fetched
+}
+
+// mirrorAssets selects the elements referencing assets a page needs, and their attribute.
+var mirrorAssets = []struct{ selector, attr string }{
+	{`link[rel~="stylesheet"][href], link[rel~="icon"][href]`, "href"},
+	{"script[src]", "src"},
+	{"img[src]", "src"},
+	{"source[src]", "src"},
+}
+
+// Mirror saves the pages a scrape reads (the current version, the release history, the
+// language specification and the release notes of every version) to dir, laid out by URL
+// path, with the stylesheets, scripts and images they reference from the same site. Links
+// between saved files are rewritten to relative ones, and other links to the site to
+// absolute ones, so the mirror can be browsed offline or served by any static file server.
+// Scraping with the base URL set to the server, or to a file URL of dir, then needs no
+// network access. Pages that cannot be fetched are reported rather than failing the mirror.
+func (c *Client) Mirror(ctx context.Context, dir string) (*MirrorReport, error) {
+	log := c.opts.logger
+	var report MirrorReport
+
+	version, err := c.get(ctx, c.url(goVersionsPath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Go versions: %w", err)
+	}
+	versionPath, _, _ := strings.Cut(goVersionsPath, "?")
+	if err := writeMirrorFile(dir, versionPath, version); err != nil {
+		return nil, err
+	}
+	report.Pages = append(report.Pages, versionPath)
+	major, err := extractMajorVersion(string(version))
+	if err != nil {
+		return nil, err
+	}
+
+	pages := []string{releaseHistoryPath, specPath}
+	for _, v := range generateVersionStrings(major) {
+		pages = append(pages, "/doc/"+v)
+	}
+	mirrored := make(map[string]bool)
+	for _, p := range pages {
+		mirrored[p] = true
+	}
+
+	assets := make(map[string]bool)
+	var assetOrder []string
+	for _, p := range pages {
+		log.Printf("Mirroring %s", p)
+		body, err := c.get(ctx, c.url(p))
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.Printf("Warning: failed to mirror %s: %v", p, err)
+			report.Failed = append(report.Failed, p)
+			delete(mirrored, p)
+			continue
+		}
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, parseError(fmt.Errorf("failed to parse %s: %w", p, err))
+		}
+		for _, a := range mirrorAssets {
+			doc.Find(a.selector).Each(func(_ int, s *goquery.Selection) {
+				if u, ok := c.sameSite(p, s.AttrOr(a.attr, "")); ok && !assets[u.Path] {
+					assets[u.Path] = true
+					assetOrder = append(assetOrder, u.Path)
+				}
+			})
+		}
+		report.Pages = append(report.Pages, p)
+	}
+
+	for _, a := range assetOrder {
+		body, err := c.get(ctx, c.url(a))
+		if err == nil {
+			err = writeMirrorFile(dir, a, body)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			log.Printf("Warning: failed to mirror %s: %v", a, err)
+			report.Failed = append(report.Failed, a)
+			delete(assets, a)
+			continue
+		}
+		report.Assets = append(report.Assets, a)
+	}
+
+	// Rewrite the pages once every saved file is known. They are read back from the
+	// client, whose cache, if any, already holds them.
+	for _, p := range report.Pages[1:] {
+		body, err := c.get(ctx, c.url(p))
+		if err != nil {
+			return nil, err
+		}
+		doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, parseError(fmt.Errorf("failed to parse %s: %w", p, err))
+		}
+		c.rewriteLinks(doc.Selection, p, func(target string) bool { return mirrored[target] || assets[target] })
+		html, err := doc.Html()
+		if err != nil {
+			return nil, err
+		}
+		if err := writeMirrorFile(dir, p, []byte(html)); err != nil {
+			return nil, err
+		}
+	}
+	return &report, nil
+}
+
+// Mirror is a shorthand for NewClient(opts...).Mirror.
+func Mirror(dir string, opts ...Option) (*MirrorReport, error) {
+	return NewClient(opts...).Mirror(context.Background(), dir)
+}
+
+// sameSite resolves ref against page and reports whether it is on the site being mirrored.
+func (c *Client) sameSite(page, ref string) (*url.URL, bool) {
+	base, err := url.Parse(c.url(page))
+	if err != nil || ref == "" {
+		return nil, false
+	}
+	r, err := url.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return nil, false
+	}
+	u := base.ResolveReference(r)
+	return u, u.Scheme == base.Scheme && u.Host == base.Host
+}
+
+// rewriteLinks makes the links of a page at URL path page relative when saved reports their
+// target is in the mirror, and absolute when it is elsewhere on the site.
+func (c *Client) rewriteLinks(page *goquery.Selection, pagePath string, saved func(string) bool) {
+	attrs := append([]struct{ selector, attr string }{{"a[href]", "href"}}, mirrorAssets...)
+	for _, a := range attrs {
+		page.Find(a.selector).Each(func(_ int, s *goquery.Selection) {
+			ref := s.AttrOr(a.attr, "")
+			if strings.HasPrefix(ref, "#") {
+				return
+			}
+			u, ok := c.sameSite(pagePath, ref)
+			if !ok {
+				return
+			}
+			if !saved(u.Path) {
+				s.SetAttr(a.attr, u.String())
+				return
+			}
+			rel, err := filepath.Rel(path.Dir(pagePath), u.Path)
+			if err != nil {
+				return
+			}
+			if u.Fragment != "" {
+				rel += "#" + u.Fragment
+			}
+			s.SetAttr(a.attr, filepath.ToSlash(rel))
+		})
+	}
+}
+
+// writeMirrorFile saves the body of the page at URL path p under dir.
+func writeMirrorFile(dir, p string, body []byte) error {
+	if strings.HasSuffix(p, "/") {
+		p += "index.html"
+	}
+	name := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+p)))
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return fmt.Errorf("failed to write mirror: %w", err)
+	}
+	if err := os.WriteFile(name, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write mirror: %w", err)
+	}
+	return nil
+}
+
+// fileTransport serves file URLs from the local file system, so that a mirror written by
+// Mirror can be scraped with a file base URL, and passes other requests to next.
+type fileTransport struct {
+	next http.RoundTripper
+}
+
+var localFiles = http.NewFileTransport(http.Dir("/"))
+
+func (t fileTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Scheme == "file" {
+		return localFiles.RoundTrip(req)
+	}
+	return t.next.RoundTrip(req)
+}
+
+// sniffTransport labels responses served without a meaningful content type by their
+// content, as static file servers serve the extensionless pages of a mirror, so that the
+// collector parses them as HTML.
+type sniffTransport struct {
+	next http.RoundTripper
+}
+
+func (t sniffTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "" && ct != "application/octet-stream" {
+		return resp, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if resp.Header == nil {
+		resp.Header = make(http.Header)
+	}
+	resp.Header.Set("Content-Type", http.DetectContentType(body))
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
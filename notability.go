@@ -0,0 +1,157 @@
+package gover
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+)
+
+// notabilityByKind is the notability of a category of each kind before its text is weighed,
+// ranking headline language features over tool and runtime changes over library tweaks.
+var notabilityByKind = map[CategoryKind]int{
+	KindLanguage: 60,
+	KindRuntime:  45,
+	KindTools:    40,
+	KindPorts:    30,
+	KindLibrary:  25,
+	KindOther:    20,
+}
+
+// symbolNotability is the notability of a symbol change before its text is weighed: the
+// smallest kind of change the release notes describe.
+const symbolNotability = 15
+
+// headlineCues are phrases of the changes release announcements lead with.
+var headlineCues = []string{
+	"generic", "type parameter", "range over", "iterator", "loop variable", "profile-guided",
+	"new package", "now supports", "experimental", "faster", "performance", "security",
+}
+
+// Notability returns a heuristic score from 0 to 100 of how much a change would stand out
+// in a release announcement. Kind is the kind of its category, or "" for a symbol change,
+// which scores lowest; the more likely it is to affect existing code, and the more headline
+// phrases its text uses, the higher it scores.
+func Notability(kind CategoryKind, changeType ChangeType, impact *Impact, text string) int {
+	score := symbolNotability
+	if kind != "" {
+		score = cmp.Or(notabilityByKind[kind], notabilityByKind[KindOther])
+	}
+	if impact == nil {
+		impact = classifyImpactPtr(changeType, text)
+	}
+	switch impact.Level {
+	case ImpactBreaking:
+		score += int(math.Round(20 * impact.Confidence))
+	case ImpactBehavioral:
+		score += int(math.Round(10 * impact.Confidence))
+	}
+	switch changeType {
+	case ChangeRemoved:
+		score += 10
+	case ChangeDeprecated:
+		score += 5
+	}
+	score += 8 * min(countCues(strings.ToLower(text), headlineCues), 3)
+	if len(text) > 400 {
+		score += 5 // headline changes are described at length
+	}
+	return min(score, 100)
+}
+
+// ScoreNotability sets the Notability of every category and symbol change in data. The
+// Overview and boilerplate sections score 0, and a category announcing a new package gets
+// the score of a language change.
+func ScoreNotability(data []VersionData) {
+	filter := SectionFilter{Deny: DefaultSectionDeny}
+	for i := range data {
+		for j := range data[i].Changes {
+			cat := &data[i].Changes[j]
+			switch {
+			case cat.Category == overviewCategory || cat.Boilerplate || filter.IsBoilerplate(cat.Category):
+				cat.Notability = 0
+			case announcesNewPackage(*cat):
+				cat.Notability = Notability(KindLanguage, cat.Type, cat.Impact, cat.Description)
+			default:
+				cat.Notability = Notability(categoryKind(*cat), cat.Type, cat.Impact, cat.Description)
+			}
+			for k := range cat.Changes {
+				sc := &cat.Changes[k]
+				sc.Notability = Notability("", NormalizeChangeType(sc.Type), sc.Impact, sc.Description)
+			}
+		}
+	}
+}
+
+// categoryKind returns the kind of cat, classifying those of datasets scraped before kinds
+// were recorded.
+func categoryKind(cat ChangeCategory) CategoryKind {
+	switch {
+	case cat.Kind != "":
+		return cat.Kind
+	case cat.Package != "":
+		return KindLibrary
+	}
+	return classifySection(cat.Anchor, cat.Category)
+}
+
+// announcesNewPackage reports whether cat introduces its package, as in "The new log/slog
+// package provides structured logging".
+func announcesNewPackage(cat ChangeCategory) bool {
+	if cat.Package == "" {
+		return false
+	}
+	text := strings.ToLower(collapseSpace(cat.Description))
+	return strings.Contains(text, "new "+cat.Package+" package") || strings.Contains(text, "new package "+cat.Package)
+}
+
+// Highlight is one of the most notable changes of a release, see Highlights.
+type Highlight struct {
+	Version    string       `json:"version"`
+	Category   string       `json:"category"`
+	Kind       CategoryKind `json:"kind,omitempty"`
+	Package    string       `json:"package,omitempty"`
+	Symbol     string       `json:"symbol,omitempty"`
+	Type       ChangeType   `json:"type,omitempty"`
+	Notability int          `json:"notability"`
+	Text       string       `json:"text"` // the first sentence of the change's description
+	URL        string       `json:"url,omitempty"`
+}
+
+// Highlights returns the n most notable changes of vd, or all of them if n <= 0, most
+// notable first and otherwise in page order, for summarizing a release. Datasets scraped
+// before notability was scored are scored on the fly.
+func Highlights(vd VersionData, n int) []Highlight {
+	scored := slices.ContainsFunc(vd.Changes, func(cat ChangeCategory) bool { return cat.Notability > 0 })
+	if !scored {
+		data := []VersionData{vd}
+		data[0].Changes = slices.Clone(vd.Changes)
+		for i := range data[0].Changes {
+			data[0].Changes[i].Changes = slices.Clone(data[0].Changes[i].Changes)
+		}
+		ScoreNotability(data)
+		vd = data[0]
+	}
+
+	var highlights []Highlight
+	for _, cat := range vd.Changes {
+		base := Highlight{Version: vd.Version, Category: cat.Category, Kind: categoryKind(cat), Package: cat.Package, URL: cmp.Or(cat.URL, vd.URL)}
+		if first := sentences(cat.Description); cat.Notability > 0 && len(first) > 0 {
+			h := base
+			h.Type, h.Notability, h.Text = cat.Type, cat.Notability, first[0]
+			highlights = append(highlights, h)
+		}
+		for _, sc := range cat.Changes {
+			if first := sentences(sc.Description); sc.Notability > 0 && len(first) > 0 {
+				h := base
+				h.Symbol, h.Type, h.Notability, h.Text = sc.Symbol, NormalizeChangeType(sc.Type), sc.Notability, first[0]
+				highlights = append(highlights, h)
+			}
+		}
+	}
+	slices.SortStableFunc(highlights, func(a, b Highlight) int { return cmp.Compare(b.Notability, a.Notability) })
+	if n > 0 && len(highlights) > n {
+		highlights = highlights[:n]
+	}
+	return highlights
+}
@@ -0,0 +1,368 @@
+package gover
+
+import (
+	"cmp"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures Scrape and NewClient.
+type Option func(*options)
+
+type options struct {
+	boilerplate    BoilerplateMode
+	sectionFilter  SectionFilter
+	rawHTML        bool
+	compact        bool
+	provenance     bool
+	bestEffort     bool
+	previous       map[string]VersionData
+	announcements  bool
+	highlights     bool
+	contributions  bool
+	httpClient     *http.Client
+	cache          Cache
+	logger         *log.Logger
+	baseURL        string
+	allowedDomains []string
+	robots         bool
+	maxRequests    int
+	delay          time.Duration
+	jitter         time.Duration
+	debugDir       string
+	selectors      *Selectors
+	apiExceptions  bool
+	metrics        *ScrapeMetrics
+	moduleProxy    string
+	sumDB          string
+	overlay        *Overlay
+	gerritCLs      bool
+	milestones     bool
+	githubToken    string
+	githubAPI      string
+	transformers   []Stage
+	checkpoint     string
+	parallelism    int
+	pageDelay      time.Duration
+	retries        int
+	golangOrg      bool
+	drafts         bool
+	resume         bool
+}
+
+func newOptions(opts []Option) options {
+	o := options{
+		boilerplate:   BoilerplateMark,
+		sectionFilter: SectionFilter{Deny: DefaultSectionDeny},
+		bestEffort:    true,
+		httpClient:    defaultHTTPClient,
+		logger:        discardLogger,
+		baseURL:       defaultBaseURL,
+		moduleProxy:   defaultModuleProxy,
+		sumDB:         defaultSumDB,
+		githubAPI:     defaultGitHubAPI,
+		parallelism:   presetDefault.Parallelism,
+		pageDelay:     presetDefault.PageDelay,
+		retries:       presetDefault.Retries,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithBoilerplate sets what Scrape does with boilerplate sections. The default is BoilerplateMark.
+func WithBoilerplate(mode BoilerplateMode) Option {
+	return func(o *options) {
+		o.boilerplate = mode
+	}
+}
+
+// WithSectionFilter sets the allow and deny lists used to recognize boilerplate sections.
+// The default denies DefaultSectionDeny.
+func WithSectionFilter(f SectionFilter) Option {
+	return func(o *options) {
+		o.sectionFilter = f
+	}
+}
+
+// WithRawHTML makes Scrape store the original HTML of each section in ChangeCategory.RawHTML,
+// for consumers that want to re-parse the source with their own logic.
+func WithRawHTML(enabled bool) Option {
+	return func(o *options) {
+		o.rawHTML = enabled
+	}
+}
+
+// WithCompaction makes Scrape drop and merge redundant categories, see Compact.
+func WithCompaction(enabled bool) Option {
+	return func(o *options) {
+		o.compact = enabled
+	}
+}
+
+// WithBestEffort controls whether versions that fail to scrape, or only partially scrape,
+// are recorded in the result (the default) or fail the whole scrape.
+func WithBestEffort(enabled bool) Option {
+	return func(o *options) {
+		o.bestEffort = enabled
+	}
+}
+
+// WithPrevious supplies the result of an earlier scrape. Versions whose page content is
+// unchanged since then, according to VersionData.Fingerprint, are reused instead of being
+// parsed again. Entries with errors are always re-parsed.
+func WithPrevious(data []VersionData) Option {
+	return func(o *options) {
+		o.previous = make(map[string]VersionData, len(data))
+		for _, vd := range data {
+			if vd.Fingerprint != "" && len(vd.Errors) == 0 {
+				o.previous[vd.Version] = vd
+			}
+		}
+	}
+}
+
+// WithAnnouncements links each version to the Go blog post announcing it, in VersionData.Announcement.
+func WithAnnouncements(enabled bool) Option {
+	return func(o *options) {
+		o.announcements = enabled
+	}
+}
+
+// WithHighlights also stores the opening paragraph of each announcement post.
+// It implies WithAnnouncements and fetches one extra page per version.
+func WithHighlights(enabled bool) Option {
+	return func(o *options) {
+		o.highlights = enabled
+		o.announcements = o.announcements || enabled
+	}
+}
+
+// WithContributions gathers per-release contribution statistics into VersionData.Contributions:
+// the issues resolved in the release's GitHub milestone and the contributor count given by its
+// announcement post. It implies WithAnnouncements and fetches one extra page per version.
+func WithContributions(enabled bool) Option {
+	return func(o *options) {
+		o.contributions = enabled
+		o.announcements = o.announcements || enabled
+	}
+}
+
+// WithGerritCLs fetches the subject, status, submission time and touched files of every CL
+// linked from the release notes from Gerrit, one request per CL, into ChangeCategory.CLs.
+func WithGerritCLs(enabled bool) Option {
+	return func(o *options) {
+		o.gerritCLs = enabled
+	}
+}
+
+// WithMilestoneIssues lists the issues closed in each release's GitHub milestone in
+// VersionData.MilestoneIssues. It needs a token, see WithGitHubToken, and fetches a page per
+// hundred issues.
+func WithMilestoneIssues(enabled bool) Option {
+	return func(o *options) {
+		o.milestones = enabled
+	}
+}
+
+// WithGitHubToken sets the token authenticating requests to the GitHub API, which raises
+// its rate limit.
+func WithGitHubToken(token string) Option {
+	return func(o *options) {
+		o.githubToken = token
+	}
+}
+
+// WithGitHubAPIURL sets the GitHub REST API release dates, milestones and their issues are
+// fetched from, such as a GitHub Enterprise instance or a test server. The token set with
+// WithGitHubToken is sent to its host only. The default is "https://api.github.com".
+func WithGitHubAPIURL(url string) Option {
+	return func(o *options) {
+		o.githubAPI = strings.TrimRight(url, "/")
+	}
+}
+
+// WithHTTPClient sets the HTTP client used for all requests. The default keeps idle
+// connections open for reuse and negotiates HTTP/2 when the site supports it.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = hc
+	}
+}
+
+// WithCache stores fetched pages in cache and serves repeated requests from it.
+func WithCache(cache Cache) Option {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+// discardLogger is the default logger, keeping the library silent.
+var discardLogger = log.New(io.Discard, "", 0)
+
+// WithLogger sets where progress and warnings are logged. By default, and with a nil
+// logger, nothing is: the library never writes to the standard logger on its own. Pass
+// log.Default() to log as the gover command does.
+func WithLogger(l *log.Logger) Option {
+	return func(o *options) {
+		o.logger = cmp.Or(l, discardLogger)
+	}
+}
+
+// WithBaseURL sets the site release notes are scraped from, such as a mirror of go.dev.
+// A file URL reads a directory written by Mirror. The default is "https://go.dev".
+func WithBaseURL(url string) Option {
+	return func(o *options) {
+		o.baseURL = strings.TrimRight(url, "/")
+	}
+}
+
+// WithAllowedDomains lets the collectors fetching release notes follow links and
+// redirects to the given hosts, such as tip.golang.org or web.archive.org, besides the
+// host of the base URL; requests to other hosts fail. "*" allows any host.
+func WithAllowedDomains(domains ...string) Option {
+	return func(o *options) {
+		o.allowedDomains = append(o.allowedDomains, domains...)
+	}
+}
+
+// WithCheckpoint makes a scrape record each version it completes in the file at path as
+// it goes, and remove the file once done. If resume is set, the versions recorded there
+// by an interrupted scrape are reused instead of being fetched again; versions with
+// errors are always fetched again.
+func WithCheckpoint(path string, resume bool) Option {
+	return func(o *options) {
+		o.checkpoint, o.resume = path, resume
+	}
+}
+
+// WithGolangOrgArchive makes a scrape fall back, for versions whose release notes cannot
+// be fetched from the base URL or have no sections there, to the release notes golang.org
+// served before go.dev, as archived by the Wayback Machine, see Client.ArchivedVersion.
+func WithGolangOrgArchive(enabled bool) Option {
+	return func(o *options) {
+		o.golangOrg = enabled
+	}
+}
+
+// WithDrafts adds the draft release notes of the next release, if tip.golang.org has
+// them yet, to a scrape, see Client.DraftVersion.
+func WithDrafts(enabled bool) Option {
+	return func(o *options) {
+		o.drafts = enabled
+	}
+}
+
+// WithModuleProxy sets the module proxy toolchain versions are listed from. The default
+// is "https://proxy.golang.org".
+func WithModuleProxy(url string) Option {
+	return func(o *options) {
+		o.moduleProxy = strings.TrimRight(url, "/")
+	}
+}
+
+// WithSumDB sets the checksum database ProbeLatestVersion consults. The default is
+// "https://sum.golang.org".
+func WithSumDB(url string) Option {
+	return func(o *options) {
+		o.sumDB = strings.TrimRight(url, "/")
+	}
+}
+
+// WithRobotsTxt makes the client honor the robots.txt rules, including any crawl delay,
+// that each site sets for gover. Disallowed requests fail with ErrDisallowed.
+func WithRobotsTxt(enabled bool) Option {
+	return func(o *options) {
+		o.robots = enabled
+	}
+}
+
+// WithMaxRequests limits the client to n requests over its lifetime, not counting those
+// answered from the cache; further requests fail with ErrBudgetExhausted. 0, the default,
+// sets no limit.
+func WithMaxRequests(n int) Option {
+	return func(o *options) {
+		o.maxRequests = n
+	}
+}
+
+// WithDelay spaces out the client's requests, waiting delay plus a random duration of up
+// to jitter between the start of one request and the next.
+func WithDelay(delay, jitter time.Duration) Option {
+	return func(o *options) {
+		o.delay, o.jitter = delay, jitter
+	}
+}
+
+// WithPreset applies the settings of a preset, see Presets and ParsePreset. Options given
+// after it override its settings.
+func WithPreset(p Preset) Option {
+	return func(o *options) {
+		o.parallelism, o.pageDelay, o.retries = p.Parallelism, p.PageDelay, p.Retries
+		o.delay, o.jitter = p.Delay, p.Jitter
+		o.robots, o.maxRequests = p.Robots, p.MaxRequests
+	}
+}
+
+// WithPolite is a preset for scheduled scraping that goes easy on go.dev: it fetches one
+// release notes page at a time, honors robots.txt, waits 1 to 3 seconds between requests,
+// retries failed ones up to 3 times and stops after 500 requests. It applies the "polite"
+// preset, see WithPreset.
+func WithPolite() Option {
+	return WithPreset(presetPolite)
+}
+
+// WithParallelism sets how many release notes pages a scrape fetches at once. The
+// default is 2.
+func WithParallelism(n int) Option {
+	return func(o *options) {
+		o.parallelism = max(n, 1)
+	}
+}
+
+// WithPageDelay sets how long a scrape waits between starting to fetch one release notes
+// page and the next. The default is 1 second.
+func WithPageDelay(d time.Duration) Option {
+	return func(o *options) {
+		o.pageDelay = d
+	}
+}
+
+// WithRetries sets how many times a request failing with a network error or a 429 or 5xx
+// status is retried, waiting longer each time or as long as the server asks. The
+// default is 2.
+func WithRetries(n int) Option {
+	return func(o *options) {
+		o.retries = max(n, 0)
+	}
+}
+
+// WithDebugDump saves every page the client fetches, and for each release notes page the
+// matches of the selectors used to parse it and the resulting VersionData, under dir.
+// Use it to find out why a version came out empty or incomplete.
+func WithDebugDump(dir string) Option {
+	return func(o *options) {
+		o.debugDir = dir
+	}
+}
+
+// WithSelectors sets the selectors used to parse go.dev pages, typically loaded with
+// LoadSelectors. The default is DefaultSelectors.
+func WithSelectors(s *Selectors) Option {
+	return func(o *options) {
+		o.selectors = s
+	}
+}
+
+// WithAPIExceptions records the changes made under exceptions to the Go 1 compatibility
+// promise, listed in the Go repository's api/except.txt, as SymbolChanges of type
+// ChangeExcepted. It fetches the api file of every release from GitHub.
+func WithAPIExceptions(enabled bool) Option {
+	return func(o *options) {
+		o.apiExceptions = enabled
+	}
+}
@@ -0,0 +1,132 @@
+package gover
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Overlay holds annotations written by the users of a dataset, such as a platform team's
+// migration notes, to be shown alongside the changes they concern. It is read from YAML:
+//
+//	notes:
+//	  - version: go1.22
+//	    category: net/http
+//	    symbol: net/http.Request.PathValue
+//	    author: platform-team
+//	    text: Replaces the path parameters of our router; see the migration guide.
+//	    url: https://wiki.example.com/go1.22
+type Overlay struct {
+	Notes []OverlayNote `yaml:"notes"`
+}
+
+// OverlayNote is an annotation of an Overlay. It is attached to the version, the category
+// (matched by name or package, case-insensitively) or the symbol change it names; with a
+// symbol but no category, to the changes of the symbol in any category of the version.
+type OverlayNote struct {
+	Version  string `yaml:"version"`
+	Category string `yaml:"category,omitempty"`
+	Symbol   string `yaml:"symbol,omitempty"`
+	Author   string `yaml:"author,omitempty"`
+	Text     string `yaml:"text"`
+	URL      string `yaml:"url,omitempty"`
+}
+
+// String identifies n in messages.
+func (n OverlayNote) String() string {
+	return strings.Join(strings.Fields(strings.Join([]string{n.Version, n.Category, n.Symbol}, " ")), " ")
+}
+
+// LocalNote is an annotation merged from an Overlay. It is never part of the upstream
+// release notes.
+type LocalNote struct {
+	Text   string `json:"text"`
+	Author string `json:"author,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// LoadOverlay reads an overlay file. Every note must name a version and have text.
+func LoadOverlay(path string) (*Overlay, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read overlay: %w", err)
+	}
+	var o Overlay
+	if err := yaml.Unmarshal(b, &o); err != nil {
+		return nil, parseError(fmt.Errorf("failed to parse overlay %s: %w", path, err))
+	}
+	for i, n := range o.Notes {
+		if strings.TrimSpace(n.Version) == "" || strings.TrimSpace(n.Text) == "" {
+			return nil, parseError(fmt.Errorf("%s: note %d needs a version and text", path, i+1))
+		}
+	}
+	return &o, nil
+}
+
+// ApplyOverlay replaces the local notes of data with those of o, and returns the notes
+// that matched nothing, e.g. because their version is not in data.
+func ApplyOverlay(data []VersionData, o *Overlay) []OverlayNote {
+	for i := range data {
+		data[i].LocalNotes = nil
+		for j := range data[i].Changes {
+			cat := &data[i].Changes[j]
+			cat.LocalNotes = nil
+			for k := range cat.Changes {
+				cat.Changes[k].LocalNotes = nil
+			}
+		}
+	}
+
+	var unmatched []OverlayNote
+	for _, n := range o.Notes {
+		if !applyNote(data, n) {
+			unmatched = append(unmatched, n)
+		}
+	}
+	return unmatched
+}
+
+// applyNote attaches n to the entry of data it names, and reports whether there was one.
+func applyNote(data []VersionData, n OverlayNote) bool {
+	note := LocalNote{Text: strings.TrimSpace(n.Text), Author: n.Author, URL: n.URL}
+	version := NormalizeVersion(n.Version)
+	matched := false
+	for i := range data {
+		vd := &data[i]
+		if vd.Version != version {
+			continue
+		}
+		if n.Category == "" && n.Symbol == "" {
+			vd.LocalNotes = append(vd.LocalNotes, note)
+			return true
+		}
+		for j := range vd.Changes {
+			cat := &vd.Changes[j]
+			if n.Category != "" && !strings.EqualFold(n.Category, cat.Category) && !strings.EqualFold(n.Category, cat.Package) {
+				continue
+			}
+			if n.Symbol == "" {
+				cat.LocalNotes = append(cat.LocalNotes, note)
+				return true
+			}
+			for k := range cat.Changes {
+				if sc := &cat.Changes[k]; sc.Symbol == n.Symbol {
+					sc.LocalNotes = append(sc.LocalNotes, note)
+					matched = true
+				}
+			}
+		}
+	}
+	return matched
+}
+
+// WithOverlay merges the notes of o into the scraped data, after notability is scored and
+// before the stages added with WithTransformers. Notes naming a scraped version but nothing
+// in it are logged.
+func WithOverlay(o *Overlay) Option {
+	return func(opts *options) {
+		opts.overlay = o
+	}
+}
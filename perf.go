@@ -0,0 +1,116 @@
+package gover
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// PerfClaim is a quantified performance statement from the release notes,
+// such as "the compiler is ~10% faster".
+type PerfClaim struct {
+	Area   string  `json:"area"`   // the component the claim is about, e.g. "compiler"
+	Metric string  `json:"metric"` // what was measured, e.g. "speed" or "memory"
+	Delta  float64 `json:"delta"`  // claimed improvement in percent; negative for regressions
+	Text   string  `json:"text"`   // the sentence making the claim
+}
+
+// percentRe matches percentages and ranges of them, e.g. "10%", "~2-3%" or "5 percent".
+var percentRe = regexp.MustCompile(`(\d+(?:\.\d+)?)(?:\s*(?:-|–|to)\s*(\d+(?:\.\d+)?))?\s*(?:%|percent\b)`)
+
+// perfAreas are the components recognized in claims, most specific first.
+var perfAreas = []string{
+	"garbage collector", "linker", "compiler", "scheduler", "go command", "runtime", "toolchain",
+}
+
+// perfMetrics maps cue words to the metric they measure, checked in order.
+var perfMetrics = []struct{ cue, metric string }{
+	{"binary size", "binary size"},
+	{"binaries", "binary size"},
+	{"build time", "build time"},
+	{"compile time", "build time"},
+	{"cpu", "cpu"},
+	{"latency", "latency"},
+	{"memory", "memory"},
+	{"heap", "memory"},
+	{"allocation", "memory"},
+	{"throughput", "throughput"},
+	{"faster", "speed"},
+	{"slower", "speed"},
+	{"speed", "speed"},
+	{"performance", "speed"},
+}
+
+// perfRegression are cues that a claim reports a regression rather than an improvement.
+var perfRegression = []string{"slower", "larger", "regress", "worse"}
+
+// perfGrowth are cues that a metric went up, which is a regression for costs.
+var perfGrowth = []string{"increase", "grow", "more", "higher"}
+
+// perfCosts are the metrics for which lower is better.
+var perfCosts = map[string]bool{"memory": true, "binary size": true, "build time": true, "latency": true, "cpu": true}
+
+// extractPerfClaims collects the quantified performance claims on a release notes page.
+// Claims about no recognized component are attributed to the enclosing section heading.
+func extractPerfClaims(page *goquery.Selection) []PerfClaim {
+	var claims []PerfClaim
+	heading := ""
+	seen := make(map[string]bool)
+	page.FindMatcher(noteMatcher).Each(func(_ int, s *goquery.Selection) {
+		if s.IsMatcher(noteHeadingMatcher) {
+			heading = collapseSpace(s.Text())
+			return
+		}
+		for _, sentence := range sentences(s.Text()) {
+			if seen[sentence] {
+				continue
+			}
+			if claim, ok := parsePerfClaim(sentence, heading); ok {
+				seen[sentence] = true
+				claims = append(claims, claim)
+			}
+		}
+	})
+	return claims
+}
+
+// parsePerfClaim parses a sentence stating a percentage change of a recognized metric.
+// A range such as "2-3%" is recorded as its midpoint. Changes are taken as improvements
+// unless the sentence says otherwise, since that is what release notes mostly report.
+func parsePerfClaim(sentence, heading string) (PerfClaim, bool) {
+	m := percentRe.FindStringSubmatch(sentence)
+	if m == nil {
+		return PerfClaim{}, false
+	}
+	lower := strings.ToLower(sentence)
+	metric := ""
+	for _, pm := range perfMetrics {
+		if strings.Contains(lower, pm.cue) {
+			metric = pm.metric
+			break
+		}
+	}
+	if metric == "" {
+		return PerfClaim{}, false
+	}
+
+	delta, _ := strconv.ParseFloat(m[1], 64)
+	if m[2] != "" {
+		upper, _ := strconv.ParseFloat(m[2], 64)
+		delta = (delta + upper) / 2
+	}
+	if containsAny(lower, perfRegression...) || (perfCosts[metric] && containsAny(lower, perfGrowth...)) {
+		delta = -delta
+	}
+
+	area := strings.ToLower(heading)
+	for _, a := range perfAreas {
+		if strings.Contains(lower, a) {
+			area = a
+			break
+		}
+	}
+	return PerfClaim{Area: area, Metric: metric, Delta: delta, Text: sentence}, true
+}
@@ -0,0 +1,99 @@
+package gover
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// PinGoMod is the toolchain directive of a go.mod file, as a source of PinSnippets.
+const PinGoMod = "gomod"
+
+// PinSources are the sources PinSnippets writes, in order.
+var PinSources = []string{PinAsdf, PinMise, PinWorkflow, PinDockerfile, PinGoMod}
+
+// PinSnippet is the text pinning a Go version in one kind of configuration file.
+type PinSnippet struct {
+	Source  string `json:"source"` // one of PinSources
+	File    string `json:"file"`   // the file it belongs in
+	Snippet string `json:"snippet"`
+}
+
+// PinSnippets returns the snippets pinning version, such as "go1.23.4", in each of
+// sources, or in all of PinSources if none are given. The go.mod toolchain directive
+// requires go1.21 or later; it is left out for older versions unless asked for.
+func PinSnippets(version string, sources ...string) ([]PinSnippet, error) {
+	version = releaseName(version)
+	if _, ok := parseGoVersion(version); !ok {
+		return nil, fmt.Errorf("invalid Go version %q", version)
+	}
+	all := len(sources) == 0
+	if all {
+		sources = PinSources
+	}
+	bare := strings.TrimPrefix(version, "go")
+	var snippets []PinSnippet
+	for _, source := range sources {
+		var s PinSnippet
+		switch source {
+		case PinAsdf:
+			s = PinSnippet{File: ".tool-versions", Snippet: "golang " + bare}
+		case PinMise:
+			s = PinSnippet{File: "mise.toml", Snippet: "[tools]\ngo = \"" + bare + "\""}
+		case PinWorkflow:
+			s = PinSnippet{File: ".github/workflows/*.yml", Snippet: "- uses: actions/setup-go@v5\n  with:\n    go-version: '" + bare + "'"}
+		case PinDockerfile:
+			s = PinSnippet{File: "Dockerfile", Snippet: "FROM golang:" + bare}
+		case PinGoMod:
+			if CompareVersions(version, "go1.21") < 0 {
+				if all {
+					continue
+				}
+				return nil, fmt.Errorf("the toolchain directive requires go1.21 or later, not %s", version)
+			}
+			s = PinSnippet{File: "go.mod", Snippet: "toolchain " + version}
+		default:
+			return nil, fmt.Errorf("unknown pin source %q (want one of %s)", source, strings.Join(PinSources, ", "))
+		}
+		s.Source = source
+		snippets = append(snippets, s)
+	}
+	return snippets, nil
+}
+
+// LatestPatch returns the newest stable release of the minor version of version, such as
+// "go1.23.4" for "go1.23" or "1.23". A version naming a patch release is returned as is.
+func (c *Client) LatestPatch(ctx context.Context, version string) (string, error) {
+	version = releaseName(version)
+	minor := minorVersion(version)
+	if minor == "" {
+		return "", fmt.Errorf("invalid Go version %q", version)
+	}
+	if minor != version {
+		return version, nil
+	}
+	releases, err := c.Downloads(ctx)
+	if err != nil {
+		return "", err
+	}
+	latest := LatestPatches(StableVersions(releases))
+	if i := slices.IndexFunc(latest, func(v string) bool { return minorVersion(v) == minor }); i >= 0 {
+		return latest[i], nil
+	}
+	return "", fmt.Errorf("no stable release of %s on go.dev", minor)
+}
+
+// LatestPatch is a shorthand for NewClient().LatestPatch.
+func LatestPatch(version string) (string, error) {
+	return NewClient().LatestPatch(context.Background(), version)
+}
+
+// releaseName is NormalizeVersion keeping the patch number: "1.23.4" becomes "go1.23.4".
+func releaseName(v string) string {
+	v = strings.TrimSpace(strings.ToLower(v))
+	if !strings.HasPrefix(v, "go") {
+		v = "go" + v
+	}
+	return v
+}
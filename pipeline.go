@@ -0,0 +1,135 @@
+package gover
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Transformer is a post-processing stage: normalization, enrichment, summarization or
+// filtering of scraped data. Transform may modify data in place and return it, or return
+// new data.
+type Transformer interface {
+	Transform(ctx context.Context, data []VersionData) ([]VersionData, error)
+}
+
+// TransformFunc adapts a function to a Transformer.
+type TransformFunc func(ctx context.Context, data []VersionData) ([]VersionData, error)
+
+// Transform calls f.
+func (f TransformFunc) Transform(ctx context.Context, data []VersionData) ([]VersionData, error) {
+	return f(ctx, data)
+}
+
+// Stage names a Transformer of a Pipeline, for reporting errors.
+type Stage struct {
+	Name string
+	Transformer
+}
+
+// Pipeline runs its stages in order, each on the output of the one before. It is a
+// Transformer itself, so pipelines can be nested.
+type Pipeline []Stage
+
+// Transform runs the stages of p on data. It stops at the first stage that fails, or when
+// ctx is done.
+func (p Pipeline) Transform(ctx context.Context, data []VersionData) ([]VersionData, error) {
+	for _, s := range p {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var err error
+		if data, err = s.Transform(ctx, data); err != nil {
+			return nil, fmt.Errorf("failed to run stage %s: %w", s.Name, err)
+		}
+	}
+	return data, nil
+}
+
+// WithTransformers appends stages to the pipeline Scrape runs on the scraped data, after its
+// own stages: adding the specification version, announcements, contribution statistics,
+// milestone issues, API exceptions and CL metadata as configured, scoring notability, and
+// merging the overlay. Client.Version runs them too, and fails if they filter its version
+// out.
+func WithTransformers(stages ...Stage) Option {
+	return func(o *options) {
+		o.transformers = append(o.transformers, stages...)
+	}
+}
+
+// inPlace adapts an enrichment that modifies data in place to a Transformer.
+func inPlace(f func(ctx context.Context, data []VersionData)) Transformer {
+	return TransformFunc(func(ctx context.Context, data []VersionData) ([]VersionData, error) {
+		f(ctx, data)
+		return data, nil
+	})
+}
+
+// applyOverlay merges the notes of the overlay set with WithOverlay into data, warning of
+// those for versions in data that match nothing.
+func (c *Client) applyOverlay(_ context.Context, data []VersionData) {
+	for _, n := range ApplyOverlay(data, c.opts.overlay) {
+		if slices.ContainsFunc(data, func(vd VersionData) bool { return vd.Version == NormalizeVersion(n.Version) }) {
+			c.opts.logger.Printf("Warning: overlay note for %s matches nothing", n)
+		}
+	}
+}
+
+// pipeline returns the stages Scrape runs on the scraped data: its own, as configured, then
+// those added with WithTransformers. For a single version, the stages that fetch data about
+// every release are left out.
+func (c *Client) pipeline(single bool) Pipeline {
+	var p Pipeline
+	if !single {
+		p = append(p, Stage{"spec", inPlace(c.addSpecVersion)})
+	}
+	if c.opts.announcements {
+		p = append(p, Stage{"announcements", inPlace(c.addAnnouncements)})
+	}
+	if c.opts.contributions {
+		p = append(p, Stage{"contributions", inPlace(c.addResolvedIssues)})
+	}
+	if c.opts.milestones {
+		p = append(p, Stage{"milestone-issues", inPlace(c.addMilestoneIssues)})
+	}
+	if c.opts.apiExceptions && !single {
+		p = append(p, Stage{"api-exceptions", inPlace(c.addAPIExceptions)})
+	}
+	if c.opts.gerritCLs {
+		p = append(p, Stage{"gerrit", inPlace(c.addChangeLists)})
+	}
+	p = append(p, NotabilityStage)
+	if c.opts.overlay != nil {
+		p = append(p, Stage{"overlay", inPlace(c.applyOverlay)})
+	}
+	return append(p, c.opts.transformers...)
+}
+
+// Built-in stages for composing pipelines.
+var (
+	CompactStage    = Stage{"compact", TransformFunc(func(_ context.Context, data []VersionData) ([]VersionData, error) { return Compact(data), nil })}
+	ImpactStage     = Stage{"impact", inPlace(func(_ context.Context, data []VersionData) { ClassifyImpacts(data) })}
+	NotabilityStage = Stage{"notability", inPlace(func(_ context.Context, data []VersionData) { ScoreNotability(data) })}
+	TokensStage     = Stage{"tokens", TransformFunc(func(_ context.Context, data []VersionData) ([]VersionData, error) { return CountTokens(data), nil })}
+)
+
+// FilterStage returns a stage applying FilterBoilerplate.
+func FilterStage(f SectionFilter, mode BoilerplateMode) Stage {
+	return Stage{"filter", TransformFunc(func(_ context.Context, data []VersionData) ([]VersionData, error) {
+		return FilterBoilerplate(data, f, mode), nil
+	})}
+}
+
+// TypeStage returns a stage applying FilterByType.
+func TypeStage(types ...ChangeType) Stage {
+	return Stage{"type", TransformFunc(func(_ context.Context, data []VersionData) ([]VersionData, error) {
+		return FilterByType(data, types...), nil
+	})}
+}
+
+// PlatformStage returns a stage applying FilterByPlatform.
+func PlatformStage(goos, goarch string) Stage {
+	return Stage{"platform", TransformFunc(func(_ context.Context, data []VersionData) ([]VersionData, error) {
+		return FilterByPlatform(data, goos, goarch), nil
+	})}
+}
@@ -0,0 +1,192 @@
+package gover
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rules of a Policy, as named in a PolicyCheck.
+const (
+	RulePrerelease       = "prerelease"
+	RuleMinimum          = "minimum"
+	RuleSupported        = "supported"
+	RuleMaxMinorsBehind  = "max-minors-behind"
+	RuleMaxPatchesBehind = "max-patches-behind"
+	RuleSecurityPatches  = "security-patches"
+)
+
+// Policy is an organization's rules for the Go version its programs are built with, read
+// from YAML by LoadPolicy. Rules left out are not checked:
+//
+//	maxMinorsBehind: 1     # at most one release line behind the latest
+//	maxPatchesBehind: 2    # at most two patch releases behind the latest of its line
+//	securityPatches: true  # every security fix of its line
+//	supported: true        # a line still supported
+//	prerelease: false      # no release candidates or betas
+//	minimum: go1.21.4      # this release or a newer one
+type Policy struct {
+	MaxMinorsBehind  *int   `yaml:"maxMinorsBehind,omitempty" json:"maxMinorsBehind,omitempty"`
+	MaxPatchesBehind *int   `yaml:"maxPatchesBehind,omitempty" json:"maxPatchesBehind,omitempty"`
+	SecurityPatches  bool   `yaml:"securityPatches,omitempty" json:"securityPatches,omitempty"`
+	Supported        bool   `yaml:"supported,omitempty" json:"supported,omitempty"`
+	Prerelease       *bool  `yaml:"prerelease,omitempty" json:"prerelease,omitempty"` // whether pre-releases are allowed
+	Minimum          string `yaml:"minimum,omitempty" json:"minimum,omitempty"`
+}
+
+// PolicyCheck is the outcome of a rule of a Policy.
+type PolicyCheck struct {
+	Rule   string `json:"rule"` // one of the Rule constants
+	Pass   bool   `json:"pass"`
+	Reason string `json:"reason"`
+}
+
+// PolicyResult is the outcome of evaluating a Policy, see EvaluatePolicy.
+type PolicyResult struct {
+	Version string        `json:"version"`
+	Pass    bool          `json:"pass"` // all checks passed
+	Checks  []PolicyCheck `json:"checks"`
+}
+
+// Table implements Tabular.
+func (r PolicyResult) Table() [][]string {
+	table := [][]string{{"Rule", "Result", "Reason"}}
+	for _, c := range r.Checks {
+		result := "fail"
+		if c.Pass {
+			result = "pass"
+		}
+		table = append(table, []string{c.Rule, result, c.Reason})
+	}
+	return table
+}
+
+// LoadPolicy reads a policy file. Fields it does not know of are rejected, so that a
+// misspelled rule is not silently left unchecked.
+func LoadPolicy(path string) (*Policy, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	var p Policy
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	dec.KnownFields(true)
+	if err := dec.Decode(&p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, parseError(fmt.Errorf("failed to parse policy %s: %w", path, err))
+	}
+	if p.Minimum != "" {
+		if _, ok := parseGoVersion(p.Minimum); !ok {
+			return nil, parseError(fmt.Errorf("%s: invalid minimum Go version %q", path, p.Minimum))
+		}
+		p.Minimum = releaseName(p.Minimum)
+	}
+	for _, n := range []*int{p.MaxMinorsBehind, p.MaxPatchesBehind} {
+		if n != nil && *n < 0 {
+			return nil, parseError(fmt.Errorf("%s: negative limit %d", path, *n))
+		}
+	}
+	return &p, nil
+}
+
+// EvaluatePolicy checks current, such as "go1.22.3", against the rules of p given the
+// release history, as returned by Client.ReleaseHistory, with a reason for each outcome.
+// A pre-release of a line not released yet is behind by nothing and lacks no patches.
+func EvaluatePolicy(p *Policy, releases []Release, current string) (PolicyResult, error) {
+	current = releaseName(current)
+	gv, ok := parseGoVersion(current)
+	if !ok {
+		return PolicyResult{}, fmt.Errorf("invalid Go version %q", current)
+	}
+	if len(releases) == 0 {
+		return PolicyResult{}, errors.New("empty release history")
+	}
+	line := "go1"
+	if gv.minor > 0 {
+		line = fmt.Sprintf("go1.%d", gv.minor)
+	}
+	i := slices.IndexFunc(releases, func(r Release) bool { return r.Version == line }) // newest first
+
+	res := PolicyResult{Version: current, Pass: true}
+	check := func(rule string, pass bool, format string, args ...any) {
+		res.Checks = append(res.Checks, PolicyCheck{Rule: rule, Pass: pass, Reason: fmt.Sprintf(format, args...)})
+		res.Pass = res.Pass && pass
+	}
+
+	if p.Prerelease != nil && !*p.Prerelease {
+		if gv.pre != "" {
+			check(RulePrerelease, false, "%s is a pre-release", current)
+		} else {
+			check(RulePrerelease, true, "%s is a stable release", current)
+		}
+	}
+	if p.Minimum != "" {
+		if CompareVersions(current, p.Minimum) < 0 {
+			check(RuleMinimum, false, "%s is older than the minimum %s", current, p.Minimum)
+		} else {
+			check(RuleMinimum, true, "%s is at least %s", current, p.Minimum)
+		}
+	}
+	if p.Supported {
+		switch {
+		case i < 0 && CompareVersions(line, releases[0].Version) > 0:
+			check(RuleSupported, true, "%s is not released yet", line)
+		case i < 0:
+			check(RuleSupported, false, "%s is not in the release history", line)
+		case i >= 2:
+			check(RuleSupported, false, "%s has been unsupported since %s was released on %s", line, releases[i-2].Version, releases[i-2].Date)
+		default:
+			check(RuleSupported, true, "%s is supported", line)
+		}
+	}
+	if p.MaxMinorsBehind != nil {
+		latest := releases[0].Version
+		behind := 0
+		if lv, ok := parseGoVersion(latest); ok && lv.minor > gv.minor {
+			behind = lv.minor - gv.minor
+		}
+		check(RuleMaxMinorsBehind, behind <= *p.MaxMinorsBehind, "%s is %d release line(s) behind %s, %d allowed", line, behind, latest, *p.MaxMinorsBehind)
+	}
+	if p.MaxPatchesBehind != nil {
+		behind := 0
+		latest := line
+		if i >= 0 {
+			latest = releases[i].Latest()
+			if gv.pre != "" {
+				behind++ // the release of its line
+			}
+			for _, patch := range releases[i].Patches {
+				if CompareVersions(patch.Version, current) > 0 {
+					behind++
+				}
+			}
+		}
+		check(RuleMaxPatchesBehind, behind <= *p.MaxPatchesBehind, "%s is %d patch release(s) behind %s, %d allowed", current, behind, latest, *p.MaxPatchesBehind)
+	}
+	if p.SecurityPatches {
+		if i < 0 || !slices.ContainsFunc(releases[i].Patches, func(p Patch) bool { return p.Security }) {
+			check(RuleSecurityPatches, true, "%s has no security releases", line)
+		} else {
+			s, err := SummarizeSecurity(releases, line)
+			if err != nil {
+				return PolicyResult{}, err
+			}
+			var missing []string
+			for _, f := range s.Fixes {
+				if CompareVersions(f.Version, current) > 0 {
+					missing = append(missing, f.Version)
+				}
+			}
+			if len(missing) > 0 {
+				check(RuleSecurityPatches, false, "%s lacks the security fixes of %d release(s) up to %s", current, len(missing), s.Minimum)
+			} else {
+				check(RuleSecurityPatches, true, "%s includes all %d security releases of %s", current, len(s.Fixes), line)
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,144 @@
+package gover
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// policyReleases is the release history the policy tests evaluate against, newest first.
+var policyReleases = []Release{
+	{Version: "go1.23", Date: "2024-08-13", Patches: []Patch{
+		{Version: "go1.23.1", Date: "2024-09-05", Security: true, Summary: "includes security fixes to the encoding/gob package"},
+		{Version: "go1.23.2", Date: "2024-10-01"},
+	}},
+	{Version: "go1.22", Date: "2024-02-06", Patches: []Patch{
+		{Version: "go1.22.1", Date: "2024-03-05", Security: true, Summary: "includes security fixes to the net/http package"},
+		{Version: "go1.22.2", Date: "2024-04-03"},
+		{Version: "go1.22.3", Date: "2024-05-07", Security: true, Summary: "includes security fixes to the go command"},
+	}},
+	{Version: "go1.21", Date: "2023-08-08", Patches: []Patch{
+		{Version: "go1.21.1", Date: "2023-09-06"},
+	}},
+}
+
+func TestEvaluatePolicy(t *testing.T) {
+	zero, one, two := 0, 1, 2
+	no := false
+	strict := &Policy{
+		MaxMinorsBehind:  &zero,
+		MaxPatchesBehind: &one,
+		SecurityPatches:  true,
+		Supported:        true,
+		Prerelease:       &no,
+		Minimum:          "go1.21.4",
+	}
+	tests := []struct {
+		policy  *Policy
+		current string
+		want    map[string]string // rule: "pass" or "fail", and a part of the reason
+	}{
+		{strict, "go1.22.1", map[string]string{
+			RulePrerelease:       "pass: is a stable release",
+			RuleMinimum:          "pass: is at least go1.21.4",
+			RuleSupported:        "pass: go1.22 is supported",
+			RuleMaxMinorsBehind:  "fail: 1 release line(s) behind go1.23",
+			RuleMaxPatchesBehind: "fail: 2 patch release(s) behind go1.22.3",
+			RuleSecurityPatches:  "fail: lacks the security fixes of 1 release(s) up to go1.22.3",
+		}},
+		{strict, "1.23.2", map[string]string{
+			RulePrerelease:       "pass",
+			RuleMinimum:          "pass",
+			RuleSupported:        "pass",
+			RuleMaxMinorsBehind:  "pass: 0 release line(s)",
+			RuleMaxPatchesBehind: "pass: 0 patch release(s)",
+			RuleSecurityPatches:  "pass: includes all 1 security releases of go1.23",
+		}},
+		{strict, "go1.21.1", map[string]string{
+			RuleMinimum:         "fail: older than the minimum go1.21.4",
+			RuleSupported:       "fail: unsupported since go1.23 was released on 2024-08-13",
+			RuleSecurityPatches: "pass: go1.21 has no security releases",
+		}},
+		{strict, "go1.24rc1", map[string]string{
+			RulePrerelease:       "fail: is a pre-release",
+			RuleSupported:        "pass: go1.24 is not released yet",
+			RuleMaxMinorsBehind:  "pass: 0 release line(s)",
+			RuleMaxPatchesBehind: "pass: 0 patch release(s)",
+		}},
+		{&Policy{MaxPatchesBehind: &two}, "go1.23rc2", map[string]string{
+			RuleMaxPatchesBehind: "fail: 3 patch release(s) behind go1.23.2",
+		}},
+		{&Policy{Supported: true}, "go1.20.5", map[string]string{
+			RuleSupported: "fail: go1.20 is not in the release history",
+		}},
+		{&Policy{}, "go1.22.3", map[string]string{}},
+	}
+	for _, tt := range tests {
+		res, err := EvaluatePolicy(tt.policy, policyReleases, tt.current)
+		if err != nil {
+			t.Errorf("%s: %v", tt.current, err)
+			continue
+		}
+		pass := true
+		seen := make(map[string]bool)
+		for _, c := range res.Checks {
+			seen[c.Rule] = true
+			pass = pass && c.Pass
+			want, ok := tt.want[c.Rule]
+			if !ok {
+				continue
+			}
+			result, reason, _ := strings.Cut(want, ": ")
+			if got := map[bool]string{true: "pass", false: "fail"}[c.Pass]; got != result || !strings.Contains(c.Reason, reason) {
+				t.Errorf("%s: %s = %s: %s, want %s", tt.current, c.Rule, got, c.Reason, want)
+			}
+		}
+		for rule := range tt.want {
+			if !seen[rule] {
+				t.Errorf("%s: no %s check", tt.current, rule)
+			}
+		}
+		if res.Pass != pass {
+			t.Errorf("%s: Pass = %t, want %t", tt.current, res.Pass, pass)
+		}
+	}
+}
+
+func TestEvaluatePolicyErrors(t *testing.T) {
+	if _, err := EvaluatePolicy(&Policy{}, policyReleases, "latest"); err == nil {
+		t.Error("EvaluatePolicy of an invalid version succeeded, want an error")
+	}
+	if _, err := EvaluatePolicy(&Policy{}, nil, "go1.22"); err == nil {
+		t.Error("EvaluatePolicy with no release history succeeded, want an error")
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	tests := []struct {
+		yaml    string
+		wantErr string // "" if it loads
+	}{
+		{"", ""},
+		{"maxMinorsBehind: 1\nsecurityPatches: true\nminimum: 1.21.4\n", ""},
+		{"maxMinorBehind: 1\n", "maxMinorBehind"},
+		{"maxPatchesBehind: -1\n", "negative limit -1"},
+		{"minimum: latest\n", `invalid minimum Go version "latest"`},
+		{"supported: [\n", "failed to parse policy"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "policy.yaml")
+		if err := os.WriteFile(path, []byte(tt.yaml), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		p, err := LoadPolicy(path)
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("LoadPolicy(%q): %v", tt.yaml, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("LoadPolicy(%q) = %v, want an error with %s", tt.yaml, err, tt.wantErr)
+		case tt.wantErr == "" && p.Minimum != "" && p.Minimum != "go1.21.4":
+			t.Errorf("LoadPolicy(%q).Minimum = %q, want go1.21.4", tt.yaml, p.Minimum)
+		}
+	}
+}
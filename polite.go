@@ -0,0 +1,224 @@
+package gover
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/temoto/robotstxt"
+)
+
+// Errors returned for requests that politeness settings prevent. Both also match ErrNetwork.
+var (
+	ErrDisallowed      = errors.New("disallowed by robots.txt")
+	ErrBudgetExhausted = errors.New("request budget exhausted")
+)
+
+// Preset bundles the settings governing how hard a client works a site, see WithPreset.
+type Preset struct {
+	Name        string
+	Parallelism int           // release notes pages fetched at once
+	PageDelay   time.Duration // between the starts of release notes page fetches
+	Delay       time.Duration // between any requests, see WithDelay
+	Jitter      time.Duration
+	Retries     int  // of a request failing with a network error or a 429 or 5xx status
+	Robots      bool // see WithRobotsTxt
+	MaxRequests int  // see WithMaxRequests
+}
+
+// presets, from the quickest to the gentlest. The default holds the settings a client has
+// without a preset.
+var (
+	presetFast    = Preset{Name: "fast", Parallelism: 4, PageDelay: 250 * time.Millisecond, Retries: 1}
+	presetDefault = Preset{Name: "default", Parallelism: 2, PageDelay: time.Second, Retries: 2}
+	presetPolite  = Preset{Name: "polite", Parallelism: 1, PageDelay: time.Second, Delay: time.Second, Jitter: 2 * time.Second, Retries: 3, Robots: true, MaxRequests: 500}
+)
+
+// Presets returns the presets, from the quickest to the gentlest: "fast", "default", which
+// holds the settings a client has without a preset, and "polite".
+func Presets() []Preset {
+	return []Preset{presetFast, presetDefault, presetPolite}
+}
+
+// ParsePreset returns the preset with the given name, see Presets.
+func ParsePreset(name string) (Preset, error) {
+	var names []string
+	for _, p := range Presets() {
+		if p.Name == name {
+			return p, nil
+		}
+		names = append(names, p.Name)
+	}
+	return Preset{}, fmt.Errorf("unknown preset %q (have %s)", name, strings.Join(names, ", "))
+}
+
+// politeness enforces a client's robots.txt, request budget and delay settings across
+// all of its requests.
+type politeness struct {
+	robots      bool
+	maxRequests int
+	delay       time.Duration
+	jitter      time.Duration
+
+	mu       sync.Mutex
+	requests int
+	next     time.Time // earliest start of the next request
+
+	robotsMu sync.Mutex
+	rules    map[string]*robotstxt.Group // by scheme and host
+}
+
+// newPoliteness returns the politeness state for o, or nil if o imposes no restrictions.
+func newPoliteness(o options) *politeness {
+	if !o.robots && o.maxRequests == 0 && o.delay == 0 && o.jitter == 0 {
+		return nil
+	}
+	return &politeness{
+		robots:      o.robots,
+		maxRequests: o.maxRequests,
+		delay:       o.delay,
+		jitter:      o.jitter,
+		rules:       map[string]*robotstxt.Group{},
+	}
+}
+
+// politeTransport applies politeness to the requests it forwards to next.
+type politeTransport struct {
+	p    *politeness
+	next http.RoundTripper
+}
+
+func (t politeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var crawlDelay time.Duration
+	if t.p.robots {
+		group, err := t.p.robotsGroup(req, t.next)
+		if err != nil {
+			return nil, err
+		}
+		if !group.Test(req.URL.Path) {
+			return nil, classified{ErrDisallowed, ErrNetwork}
+		}
+		crawlDelay = group.CrawlDelay
+	}
+	wait, err := t.p.reserve(crawlDelay)
+	if err != nil {
+		return nil, err
+	}
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+	return t.next.RoundTrip(req)
+}
+
+// reserve counts a request against the budget and returns how long it must wait to keep
+// the configured delay, which is at least minDelay, after the previous request.
+func (p *politeness) reserve(minDelay time.Duration) (time.Duration, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.maxRequests > 0 && p.requests >= p.maxRequests {
+		return 0, classified{fmt.Errorf("%w after %d requests", ErrBudgetExhausted, p.requests), ErrNetwork}
+	}
+	p.requests++
+
+	now := time.Now()
+	start := now
+	if p.next.After(now) {
+		start = p.next
+	}
+	delay := max(p.delay, minDelay)
+	if p.jitter > 0 {
+		delay += rand.N(p.jitter)
+	}
+	p.next = start.Add(delay)
+	return start.Sub(now), nil
+}
+
+// robotsGroup returns the robots.txt rules for gover on the request's host, fetching
+// them through next on first use. A missing robots.txt allows everything.
+func (p *politeness) robotsGroup(req *http.Request, next http.RoundTripper) (*robotstxt.Group, error) {
+	key := req.URL.Scheme + "://" + req.URL.Host
+	p.robotsMu.Lock()
+	defer p.robotsMu.Unlock()
+	if group, ok := p.rules[key]; ok {
+		return group, nil
+	}
+
+	robotsReq, err := http.NewRequestWithContext(req.Context(), http.MethodGet, key+"/robots.txt", nil)
+	if err != nil {
+		return nil, err
+	}
+	robotsReq.Header.Set("User-Agent", userAgent)
+	resp, err := next.RoundTrip(robotsReq)
+	if err != nil {
+		return nil, networkError(fmt.Errorf("failed to fetch robots.txt: %w", err))
+	}
+	defer resp.Body.Close()
+	data, err := robotstxt.FromResponse(resp)
+	if err != nil {
+		return nil, parseError(fmt.Errorf("failed to parse %s/robots.txt: %w", key, err))
+	}
+	group := data.FindGroup(userAgent)
+	p.rules[key] = group
+	return group, nil
+}
+
+// Waits before retrying a failed request: retryBackoff, doubled on every further attempt,
+// or the wait the server asks for in a Retry-After header, up to maxRetryWait.
+const (
+	retryBackoff = 500 * time.Millisecond
+	maxRetryWait = 30 * time.Second
+)
+
+// retryTransport retries GET requests through next that fail with a network error or a
+// 429 or 5xx status, up to retries times. Requests refused by the politeness settings
+// are not retried.
+type retryTransport struct {
+	retries int
+	next    http.RoundTripper
+}
+
+func (t retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.next.RoundTrip(req)
+		if attempt == t.retries || req.Method != http.MethodGet || !retryable(resp, err) {
+			return resp, err
+		}
+		wait := retryBackoff << attempt
+		if resp != nil {
+			if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+				wait = time.Duration(secs) * time.Second
+			}
+			io.Copy(io.Discard, resp.Body) // so that the connection can be reused
+			resp.Body.Close()
+		}
+		timer := time.NewTimer(min(wait, maxRetryWait))
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// retryable reports whether a request that returned resp and err may succeed if retried.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrDisallowed) && !errors.Is(err, ErrBudgetExhausted) &&
+			!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
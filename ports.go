@@ -0,0 +1,131 @@
+package gover
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Port is a GOOS/GOARCH pair supported by a Go toolchain, as listed by "go tool dist list -json".
+type Port struct {
+	GOOS         string `json:"GOOS"`
+	GOARCH       string `json:"GOARCH"`
+	CgoSupported bool   `json:"CgoSupported"`
+	FirstClass   bool   `json:"FirstClass"` // see https://go.dev/wiki/PortingPolicy#first-class-ports
+}
+
+// String returns the port as "GOOS/GOARCH".
+func (p Port) String() string {
+	return p.GOOS + "/" + p.GOARCH
+}
+
+// PortEvent is a section of a release's notes mentioning a port.
+type PortEvent struct {
+	Version     string `json:"version"`
+	Category    string `json:"category"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// PortTimeline is the history of a port, or of every port of a GOOS or GOARCH.
+type PortTimeline struct {
+	Port       string      `json:"port"`                 // e.g. "wasip1" or "android/arm64"
+	Ports      []Port      `json:"ports,omitempty"`      // the matching ports of the local toolchain
+	Introduced string      `json:"introduced,omitempty"` // the release that introduced its GOOS and GOARCH, see Features
+	FirstClass string      `json:"firstClass,omitempty"` // the first release whose notes call it first-class
+	Events     []PortEvent `json:"events,omitempty"`
+}
+
+// portAliases are the names the release notes use for some GOOS and GOARCH values.
+var portAliases = map[string][]string{
+	"darwin":  {"macOS", "OS X"},
+	"ios":     {"iOS"},
+	"wasm":    {"WebAssembly"},
+	"wasip1":  {"WASI"},
+	"loong64": {"LoongArch"},
+	"riscv64": {"RISC-V"},
+}
+
+var firstClassRe = regexp.MustCompile(`(?i)\bfirst[- ]class\b`)
+
+// DistPorts lists the ports supported by the go command on the PATH.
+func DistPorts(ctx context.Context) ([]Port, error) {
+	out, err := exec.CommandContext(ctx, "go", "tool", "dist", "list", "-json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ports: %w", err)
+	}
+	var ports []Port
+	if err := json.Unmarshal(out, &ports); err != nil {
+		return nil, parseError(fmt.Errorf("failed to decode ports: %w", err))
+	}
+	return ports, nil
+}
+
+// BuildPortTimeline returns the history of port, a GOOS, a GOARCH or a "GOOS/GOARCH" pair:
+// the release that introduced it according to the build tags in Features, and the sections of
+// the release notes in data mentioning it. Changes to individual packages are left out. ports
+// are the ports of a toolchain, see DistPorts, and may be empty.
+func BuildPortTimeline(port string, data []VersionData, ports []Port) PortTimeline {
+	port = strings.ToLower(strings.TrimSpace(port))
+	t := PortTimeline{Port: port}
+	goos, goarch, pair := strings.Cut(port, "/")
+	for _, p := range ports {
+		if pair && p.GOOS == goos && p.GOARCH == goarch || !pair && (p.GOOS == port || p.GOARCH == port) {
+			t.Ports = append(t.Ports, p)
+		}
+	}
+
+	terms := []string{port}
+	if pair {
+		terms = []string{goos, goarch}
+	}
+	for i, term := range terms {
+		j := slices.IndexFunc(Features, func(f Feature) bool { return f.Kind == FeatureBuildTag && f.Name == term })
+		if j < 0 {
+			t.Introduced = "" // unknown unless both GOOS and GOARCH are known
+			break
+		}
+		if v := Features[j].Version; i == 0 || CompareVersions(v, t.Introduced) > 0 {
+			t.Introduced = v
+		}
+	}
+
+	matchers := make([]*regexp.Regexp, len(terms))
+	for i, term := range terms {
+		names := []string{regexp.QuoteMeta(term)}
+		for _, alias := range portAliases[term] {
+			names = append(names, regexp.QuoteMeta(alias))
+		}
+		matchers[i] = regexp.MustCompile(`(?i)(?:^|[^\w-])(?:` + strings.Join(names, "|") + `)(?:$|[^\w-])`)
+	}
+	mentions := func(text string) bool {
+		if pair && strings.Contains(strings.ToLower(text), port) {
+			return true
+		}
+		for _, m := range matchers {
+			if !m.MatchString(text) {
+				return false
+			}
+		}
+		return true
+	}
+
+	data = slices.Clone(data)
+	slices.SortStableFunc(data, func(a, b VersionData) int { return CompareVersions(a.Version, b.Version) })
+	for _, vd := range data {
+		for _, cat := range vd.Changes {
+			if cat.Package != "" || cat.Boilerplate || !mentions(cat.Category+"\n"+cat.Description) {
+				continue
+			}
+			t.Events = append(t.Events, PortEvent{Version: vd.Version, Category: cat.Category, URL: cat.URL, Description: cat.Description})
+			if t.FirstClass == "" && firstClassRe.MatchString(cat.Description) {
+				t.FirstClass = vd.Version
+			}
+		}
+	}
+	return t
+}
@@ -0,0 +1,159 @@
+package gover
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// postgresSchema creates the tables used by LoadPostgres. Categories and symbol changes are
+// keyed by their position within their parent, which keeps reloading the same dataset idempotent.
+var postgresSchema = []string{
+	`CREATE TABLE IF NOT EXISTS go_versions (
+		version      TEXT PRIMARY KEY,
+		release_date DATE
+	)`,
+	`CREATE TABLE IF NOT EXISTS go_change_categories (
+		id                BIGSERIAL PRIMARY KEY,
+		version           TEXT NOT NULL REFERENCES go_versions (version) ON DELETE CASCADE,
+		position          INTEGER NOT NULL,
+		category          TEXT NOT NULL,
+		type              TEXT,
+		title             TEXT,
+		description       TEXT,
+		examples          JSONB,
+		package           TEXT,
+		impact            TEXT,
+		impact_confidence REAL,
+		UNIQUE (version, position)
+	)`,
+	`CREATE TABLE IF NOT EXISTS go_symbol_changes (
+		id                BIGSERIAL PRIMARY KEY,
+		category_id       BIGINT NOT NULL REFERENCES go_change_categories (id) ON DELETE CASCADE,
+		position          INTEGER NOT NULL,
+		type              TEXT NOT NULL,
+		symbol            TEXT NOT NULL,
+		description       TEXT,
+		impact            TEXT,
+		impact_confidence REAL,
+		UNIQUE (category_id, position)
+	)`,
+	`ALTER TABLE go_change_categories ADD COLUMN IF NOT EXISTS kind TEXT`,
+	`CREATE INDEX IF NOT EXISTS go_symbol_changes_symbol ON go_symbol_changes (symbol)`,
+	`CREATE INDEX IF NOT EXISTS go_change_categories_package ON go_change_categories (package)`,
+}
+
+// LoadPostgres creates the gover schema in db if needed and upserts every version,
+// category and symbol change in data, in a single transaction. Versions, categories and
+// symbol changes left over from a previous load that no longer appear in data are deleted,
+// so that the database holds data alone, and loading the same dataset twice leaves it
+// unchanged. db must be connected to PostgreSQL.
+func LoadPostgres(ctx context.Context, db *sql.DB, data []VersionData) error {
+	for _, stmt := range postgresSchema {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to create schema: %w", err)
+		}
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	versions := make([]string, len(data))
+	for i, vd := range data {
+		if err := upsertVersion(ctx, tx, vd); err != nil {
+			return fmt.Errorf("failed to load %s: %w", vd.Version, err)
+		}
+		versions[i] = vd.Version
+	}
+	// Their categories and symbol changes are deleted with them.
+	list, err := json.Marshal(versions)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM go_versions WHERE version NOT IN (SELECT jsonb_array_elements_text($1::jsonb))`,
+		string(list)); err != nil {
+		return fmt.Errorf("failed to delete versions no longer in the dataset: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit: %w", err)
+	}
+	return nil
+}
+
+func upsertVersion(ctx context.Context, tx *sql.Tx, vd VersionData) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO go_versions (version, release_date) VALUES ($1, $2)
+		ON CONFLICT (version) DO UPDATE SET release_date = EXCLUDED.release_date`,
+		vd.Version, nullString(vd.ReleaseDate))
+	if err != nil {
+		return err
+	}
+
+	for pos, cat := range vd.Changes {
+		examples, err := json.Marshal(cat.Examples)
+		if err != nil {
+			return err
+		}
+		level, confidence := impactColumns(cat.Impact)
+
+		var categoryID int64
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO go_change_categories
+				(version, position, category, type, title, description, examples, package, impact, impact_confidence, kind)
+			VALUES ($1, $2, $3, $4, $5, $6, $7::jsonb, $8, $9, $10, $11)
+			ON CONFLICT (version, position) DO UPDATE SET
+				category = EXCLUDED.category, kind = EXCLUDED.kind, type = EXCLUDED.type, title = EXCLUDED.title,
+				description = EXCLUDED.description, examples = EXCLUDED.examples, package = EXCLUDED.package,
+				impact = EXCLUDED.impact, impact_confidence = EXCLUDED.impact_confidence
+			RETURNING id`,
+			vd.Version, pos, cat.Category, nullString(string(cat.Type)), nullString(cat.Title), nullString(cat.Description),
+			string(examples), nullString(cat.Package), level, confidence, nullString(string(cat.Kind)),
+		).Scan(&categoryID)
+		if err != nil {
+			return err
+		}
+
+		for spos, sc := range cat.Changes {
+			level, confidence := impactColumns(sc.Impact)
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO go_symbol_changes
+					(category_id, position, type, symbol, description, impact, impact_confidence)
+				VALUES ($1, $2, $3, $4, $5, $6, $7)
+				ON CONFLICT (category_id, position) DO UPDATE SET
+					type = EXCLUDED.type, symbol = EXCLUDED.symbol, description = EXCLUDED.description,
+					impact = EXCLUDED.impact, impact_confidence = EXCLUDED.impact_confidence`,
+				categoryID, spos, string(NormalizeChangeType(sc.Type)), sc.Symbol, nullString(sc.Description), level, confidence)
+			if err != nil {
+				return err
+			}
+		}
+		if _, err := tx.ExecContext(ctx,
+			`DELETE FROM go_symbol_changes WHERE category_id = $1 AND position >= $2`,
+			categoryID, len(cat.Changes)); err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`DELETE FROM go_change_categories WHERE version = $1 AND position >= $2`,
+		vd.Version, len(vd.Changes))
+	return err
+}
+
+// nullString maps empty strings to SQL NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// impactColumns returns the impact level and confidence columns for impact, which may be nil.
+func impactColumns(impact *Impact) (sql.NullString, sql.NullFloat64) {
+	if impact == nil {
+		return sql.NullString{}, sql.NullFloat64{}
+	}
+	return nullString(impact.Level), sql.NullFloat64{Float64: impact.Confidence, Valid: true}
+}
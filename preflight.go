@@ -0,0 +1,109 @@
+package gover
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+)
+
+// Priority added to a reading list entry by the impact of its change.
+var impactPriority = map[string]int{ImpactBreaking: 30, ImpactBehavioral: 15}
+
+// ReadingItem is an entry of the reading list Preflight returns: a section of the release
+// notes to read before upgrading.
+type ReadingItem struct {
+	Version  string       `json:"version"`
+	Category string       `json:"category"`
+	Kind     CategoryKind `json:"kind,omitempty"`
+	Package  string       `json:"package,omitempty"`
+	Symbols  []string     `json:"symbols,omitempty"` // the relevant symbol changes, if not the whole category is relevant
+	Impact   string       `json:"impact"`            // the highest impact level of the relevant changes
+	Priority int          `json:"priority"`          // the higher the sooner to read
+	Reason   string       `json:"reason"`            // what made it relevant to the profile
+	Text     string       `json:"text"`              // the first sentence of the category's description
+	URL      string       `json:"url"`
+}
+
+// ReadingList is a prioritized list of sections of the release notes, see Preflight.
+type ReadingList []ReadingItem
+
+// Table implements Tabular.
+func (l ReadingList) Table() [][]string {
+	table := [][]string{{"Priority", "Version", "Category", "Impact", "Reason", "URL"}}
+	for _, it := range l {
+		table = append(table, []string{strconv.Itoa(it.Priority), it.Version, it.Category, it.Impact, it.Reason, it.URL})
+	}
+	return table
+}
+
+// Preflight returns what to read before upgrading from one version to another: the
+// changes of the versions in between that are relevant to profile, once it is applied
+// (see Profile.Apply), one entry per category of the release notes, most important first.
+// Priority is the notability of the category or of its most notable relevant symbol
+// change, plus 30 if any relevant change may break existing code and 15 if it may change
+// its behavior. Overview and boilerplate sections are left out.
+func Preflight(data []VersionData, from, to string, profile Profile) (ReadingList, error) {
+	diff, err := Diff(profile.Apply(data), from, to)
+	if err != nil {
+		return nil, err
+	}
+	list := ReadingList{}
+	filter := SectionFilter{Deny: DefaultSectionDeny}
+	for _, vd := range diff {
+		scored := slices.ContainsFunc(vd.Changes, func(cat ChangeCategory) bool { return cat.Notability > 0 })
+		for _, cat := range vd.Changes {
+			if cat.Category == overviewCategory || cat.Boilerplate || filter.IsBoilerplate(cat.Category) {
+				continue
+			}
+			if !scored {
+				cat = scoreCategory(cat)
+			}
+			item := ReadingItem{
+				Version:  vd.Version,
+				Category: cat.Category,
+				Kind:     categoryKind(cat),
+				Package:  cat.Package,
+				URL:      cmp.Or(cat.URL, vd.URL),
+			}
+			if first := sentences(cat.Description); len(first) > 0 {
+				item.Text = first[0]
+			}
+			var levels []string
+			if item.Reason = profile.relevance(cat); item.Reason != "" {
+				item.Priority = cat.Notability
+				levels = append(levels, impactLevel(cat.Impact, cat.Type, cat.Description))
+				for _, sc := range cat.Changes {
+					levels = append(levels, impactLevel(sc.Impact, NormalizeChangeType(sc.Type), sc.Description))
+				}
+			} else {
+				for _, sc := range cat.Changes {
+					reason := profile.symbolRelevance(sc)
+					if reason == "" {
+						continue
+					}
+					item.Reason = cmp.Or(item.Reason, reason)
+					item.Symbols = append(item.Symbols, sc.Symbol)
+					item.Priority = max(item.Priority, sc.Notability)
+					levels = append(levels, impactLevel(sc.Impact, NormalizeChangeType(sc.Type), sc.Description))
+				}
+				if item.Reason == "" {
+					continue
+				}
+			}
+			item.Impact = slices.MaxFunc(levels, func(a, b string) int { return cmp.Compare(impactRank[a], impactRank[b]) })
+			item.Priority += impactPriority[item.Impact]
+			list = append(list, item)
+		}
+	}
+	slices.SortStableFunc(list, func(a, b ReadingItem) int { return cmp.Compare(b.Priority, a.Priority) })
+	return list, nil
+}
+
+// scoreCategory returns a copy of cat with the notability of it and its symbol changes
+// scored, for datasets scraped before notability was.
+func scoreCategory(cat ChangeCategory) ChangeCategory {
+	data := []VersionData{{Changes: []ChangeCategory{cat}}}
+	data[0].Changes[0].Changes = slices.Clone(cat.Changes)
+	ScoreNotability(data)
+	return data[0].Changes[0]
+}
@@ -0,0 +1,104 @@
+package gover
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+)
+
+// Version sources compared by ProbeLatestVersion.
+const (
+	SourceGoDev = "go.dev"
+	SourceProxy = "proxy"
+	SourceSumDB = "sumdb"
+)
+
+// probeToolchainPlatform is the toolchain looked up in the checksum database, one that
+// every release publishes.
+const probeToolchainPlatform = "linux-amd64"
+
+// VersionProbe compares the latest Go release announced by go.dev with the toolchains
+// published to the module proxy and recorded in the checksum database, to catch one of
+// them lagging behind the others.
+type VersionProbe struct {
+	GoDev   string   `json:"goDev,omitempty"`   // the first line of go.dev/VERSION?m=text
+	Proxy   string   `json:"proxy,omitempty"`   // the newest stable toolchain on the module proxy
+	Latest  string   `json:"latest,omitempty"`  // the newer of the two
+	SumDB   bool     `json:"sumDB"`             // whether the checksum database records the toolchain of Latest
+	Lagging []string `json:"lagging,omitempty"` // the sources not serving Latest, e.g. SourceProxy
+	Errors  []string `json:"errors,omitempty"`  // sources that could not be queried
+}
+
+// Consistent reports whether every source could be queried and serves the latest release.
+func (p VersionProbe) Consistent() bool {
+	return len(p.Lagging) == 0 && len(p.Errors) == 0
+}
+
+// LatestToolchain returns the newest stable release published as a toolchain on the module proxy.
+func (c *Client) LatestToolchain(ctx context.Context) (string, error) {
+	versions, err := c.ToolchainVersions(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range slices.Backward(versions) {
+		if gv, ok := parseGoVersion(v); ok && gv.pre == "" {
+			return v, nil
+		}
+	}
+	return "", parseError(errors.New("no stable toolchain on the module proxy"))
+}
+
+// ProbeLatestVersion queries go.dev, the module proxy and the checksum database for the
+// latest Go release. Sources that fail are recorded in the probe rather than failing it.
+func (c *Client) ProbeLatestVersion(ctx context.Context) VersionProbe {
+	var p VersionProbe
+	if v, err := c.LatestVersion(ctx); err != nil {
+		p.Errors = append(p.Errors, SourceGoDev+": "+err.Error())
+	} else {
+		p.GoDev = v
+	}
+	if v, err := c.LatestToolchain(ctx); err != nil {
+		p.Errors = append(p.Errors, SourceProxy+": "+err.Error())
+	} else {
+		p.Proxy = v
+	}
+
+	p.Latest = p.GoDev
+	if p.Latest == "" || p.Proxy != "" && CompareVersions(p.Proxy, p.Latest) > 0 {
+		p.Latest = p.Proxy
+	}
+	if p.Latest == "" {
+		return p
+	}
+	if p.GoDev != "" && p.GoDev != p.Latest {
+		p.Lagging = append(p.Lagging, SourceGoDev)
+	}
+	if p.Proxy != "" && p.Proxy != p.Latest {
+		p.Lagging = append(p.Lagging, SourceProxy)
+	}
+
+	lookup := c.opts.sumDB + "/lookup/golang.org/toolchain@v0.0.1-" + p.Latest + "." + probeToolchainPlatform
+	switch status, errText := c.checkURL(ctx, lookup); {
+	case errText != "" || status >= http.StatusInternalServerError:
+		if errText == "" {
+			errText = http.StatusText(status)
+		}
+		p.Errors = append(p.Errors, SourceSumDB+": "+errText)
+	case status == http.StatusOK:
+		p.SumDB = true
+	default:
+		p.Lagging = append(p.Lagging, SourceSumDB)
+	}
+	return p
+}
+
+// ProbeLatestVersion is a shorthand for NewClient().ProbeLatestVersion.
+func ProbeLatestVersion() VersionProbe {
+	return NewClient().ProbeLatestVersion(context.Background())
+}
+
+// LatestToolchain is a shorthand for NewClient().LatestToolchain.
+func LatestToolchain() (string, error) {
+	return NewClient().LatestToolchain(context.Background())
+}
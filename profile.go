@@ -0,0 +1,227 @@
+package gover
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed config.yaml
+var defaultConfigYAML []byte
+
+// Config holds the settings of a gover configuration file. The defaults are in
+// config.yaml.
+type Config struct {
+	Profile  string    `yaml:"profile,omitempty"` // the profile applied when none is selected, if any
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// Profile describes the kind of program a team builds, such as network services or
+// command-line tools, selecting the changes of the release notes relevant to it.
+type Profile struct {
+	Name        string         `yaml:"name" json:"name"`
+	Description string         `yaml:"description,omitempty" json:"description,omitempty"`
+	Kinds       []CategoryKind `yaml:"kinds,omitempty" json:"kinds,omitempty"`       // parts of the release notes that are always relevant
+	Packages    []string       `yaml:"packages,omitempty" json:"packages,omitempty"` // of interest: import paths, or those under them if ending in "/..."
+	Keywords    []string       `yaml:"keywords,omitempty" json:"keywords,omitempty"` // phrases marking a change as relevant, case-insensitively
+	GOOS        string         `yaml:"goos,omitempty" json:"goos,omitempty"`         // hide changes scoped to other operating systems, see FilterByPlatform
+	GOARCH      string         `yaml:"goarch,omitempty" json:"goarch,omitempty"`     // hide changes scoped to other architectures
+	Ignore      []string       `yaml:"ignore,omitempty" json:"ignore,omitempty"`     // categories to hide, by heading, package pattern or kind
+}
+
+// DefaultConfig returns the built-in configuration.
+func DefaultConfig() *Config {
+	var c Config
+	if err := yaml.Unmarshal(defaultConfigYAML, &c); err != nil {
+		panic("invalid embedded config: " + err.Error())
+	}
+	return &c
+}
+
+// ParseConfig parses a configuration in YAML (or JSON). A profile it defines replaces the
+// default of the same name; the other defaults are kept. The default profile, if set, must
+// be one of them.
+func ParseConfig(data []byte) (*Config, error) {
+	var c Config
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	for i, p := range c.Profiles {
+		if p.Name == "" {
+			return nil, fmt.Errorf("profile %d has no name", i+1)
+		}
+		for _, k := range p.Kinds {
+			if _, err := ParseCategoryKind(string(k)); err != nil {
+				return nil, fmt.Errorf("profile %s: %w", p.Name, err)
+			}
+		}
+	}
+	merged := DefaultConfig()
+	merged.Profile = c.Profile
+	for _, p := range c.Profiles {
+		if i := slices.IndexFunc(merged.Profiles, func(d Profile) bool { return d.Name == p.Name }); i >= 0 {
+			merged.Profiles[i] = p
+		} else {
+			merged.Profiles = append(merged.Profiles, p)
+		}
+	}
+	if merged.Profile != "" {
+		if _, err := merged.Lookup(merged.Profile); err != nil {
+			return nil, fmt.Errorf("default %w", err)
+		}
+	}
+	return merged, nil
+}
+
+// LoadConfig reads a configuration file, see ParseConfig.
+func LoadConfig(path string) (*Config, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	c, err := ParseConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return c, nil
+}
+
+// DefaultConfigPath returns where the configuration file is looked for by default:
+// gover/config.yaml in the user configuration directory, see os.UserConfigDir.
+func DefaultConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "gover", "config.yaml"), nil
+}
+
+// LoadDefaultConfig reads the file at DefaultConfigPath, or returns the built-in
+// configuration if there is none.
+func LoadDefaultConfig() (*Config, error) {
+	path, err := DefaultConfigPath()
+	if err != nil {
+		return DefaultConfig(), nil
+	}
+	c, err := LoadConfig(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultConfig(), nil
+	}
+	return c, err
+}
+
+// Lookup returns the profile with the given name.
+func (c *Config) Lookup(name string) (Profile, error) {
+	for _, p := range c.Profiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	names := make([]string, len(c.Profiles))
+	for i, p := range c.Profiles {
+		names[i] = p.Name
+	}
+	return Profile{}, fmt.Errorf("unknown profile %q (have %s)", name, strings.Join(names, ", "))
+}
+
+// Apply returns data limited to what matters to p: without the categories it ignores,
+// the changes scoped to other platforms than its GOOS and GOARCH, and, if it lists
+// packages, the changes to other packages that are not relevant to p by kind or keyword
+// either. Categories about no package are kept unless ignored, but lose the symbol changes
+// to other packages not mentioning a keyword, and are dropped if none is left.
+func (p Profile) Apply(data []VersionData) []VersionData {
+	data = FilterByPlatform(data, p.GOOS, p.GOARCH)
+	out := make([]VersionData, 0, len(data))
+	for _, vd := range data {
+		var cats []ChangeCategory
+		for _, cat := range vd.Changes {
+			if p.ignores(cat) || (cat.Package != "" && len(p.Packages) > 0 && p.relevance(cat) == "") {
+				continue
+			}
+			if cat.Package == "" && len(p.Packages) > 0 && len(cat.Changes) > 0 {
+				cat.Changes = slices.DeleteFunc(slices.Clone(cat.Changes), func(sc SymbolChange) bool {
+					return SymbolPackage(sc.Symbol) != "" && p.symbolRelevance(sc) == ""
+				})
+				if len(cat.Changes) == 0 {
+					continue
+				}
+			}
+			cats = append(cats, cat)
+		}
+		vd.Changes = cats
+		out = append(out, vd)
+	}
+	return out
+}
+
+// ignores reports whether p ignores cat: whether an entry of its Ignore list is the
+// category's heading (case-insensitively), kind or package, or a pattern matching its
+// package.
+func (p Profile) ignores(cat ChangeCategory) bool {
+	for _, ig := range p.Ignore {
+		switch {
+		case strings.EqualFold(ig, cat.Category), strings.EqualFold(ig, string(categoryKind(cat))):
+			return true
+		case cat.Package != "" && matchesPackage(cat.Package, ig):
+			return true
+		}
+	}
+	return false
+}
+
+// relevance returns why cat is relevant to p: the part of the release notes, package or
+// keyword it matched, or "" if it is not relevant.
+func (p Profile) relevance(cat ChangeCategory) string {
+	if kind := categoryKind(cat); slices.Contains(p.Kinds, kind) {
+		return "kind " + string(kind)
+	}
+	if cat.Package != "" {
+		if pattern := p.packagePattern(cat.Package); pattern != "" {
+			return "package " + pattern
+		}
+	}
+	if kw := p.keyword(cat.Category + "\n" + cat.Description); kw != "" {
+		return fmt.Sprintf("mentions %q", kw)
+	}
+	return ""
+}
+
+// symbolRelevance returns why sc is relevant to p, or "" if it is not.
+func (p Profile) symbolRelevance(sc SymbolChange) string {
+	if pkg := SymbolPackage(sc.Symbol); pkg != "" {
+		if pattern := p.packagePattern(pkg); pattern != "" {
+			return "package " + pattern
+		}
+	}
+	if kw := p.keyword(sc.Description); kw != "" {
+		return fmt.Sprintf("mentions %q", kw)
+	}
+	return ""
+}
+
+// packagePattern returns the pattern of p's packages matching the import path pkg, or "".
+func (p Profile) packagePattern(pkg string) string {
+	for _, pattern := range p.Packages {
+		if matchesPackage(pkg, pattern) {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// keyword returns the first of p's keywords that text contains, or "".
+func (p Profile) keyword(text string) string {
+	text = strings.ToLower(text)
+	for _, kw := range p.Keywords {
+		if kw != "" && strings.Contains(text, strings.ToLower(kw)) {
+			return kw
+		}
+	}
+	return ""
+}
@@ -0,0 +1,119 @@
+package gover
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// Provenance traces a record of the dataset back to the element of the release notes page
+// it was parsed from, see WithProvenance.
+type Provenance struct {
+	URL       string    `json:"url"`            // the page
+	Selector  string    `json:"selector"`       // a CSS selector matching only the element, e.g. "h2#language"
+	Text      string    `json:"text,omitempty"` // the selector of the element its description was taken from, if another
+	ScrapedAt time.Time `json:"scrapedAt"`
+}
+
+// WithProvenance makes Scrape record in each category and symbol change parsed from a release
+// notes page the element it came from and when, so that any statement in the dataset can be
+// traced back to its source. Versions reused from WithPrevious keep the provenance of the
+// scrape that parsed them; those recorded without provenance are parsed again.
+func WithProvenance(enabled bool) Option {
+	return func(o *options) {
+		o.provenance = enabled
+	}
+}
+
+// hasProvenance reports whether any category of vd records its provenance.
+func hasProvenance(vd VersionData) bool {
+	return slices.ContainsFunc(vd.Changes, func(cat ChangeCategory) bool { return cat.Provenance != nil })
+}
+
+// provenancer records the provenance of the records parsed from one page. Its methods do
+// nothing on a nil provenancer, which is used when provenance is not wanted.
+type provenancer struct {
+	url string
+	at  time.Time
+}
+
+// newProvenancer returns the provenancer for the page at url, or nil if the client does not
+// record provenance.
+func (c *Client) newProvenancer(url string) *provenancer {
+	if !c.opts.provenance {
+		return nil
+	}
+	return &provenancer{url: url, at: time.Now().UTC()}
+}
+
+// of returns the provenance of a record parsed from el, with its description taken from
+// the first element of text, which may be empty or el itself.
+func (p *provenancer) of(el, text *goquery.Selection) *Provenance {
+	if p == nil || el.Length() == 0 {
+		return nil
+	}
+	prov := &Provenance{URL: p.url, Selector: elementSelector(el.Get(0)), ScrapedAt: p.at}
+	if text != nil && text.Length() > 0 && text.Get(0) != el.Get(0) {
+		prov.Text = elementSelector(text.Get(0))
+	}
+	return prov
+}
+
+// cssIdentRe matches ids that can be written as CSS #id selectors without escaping.
+var cssIdentRe = regexp.MustCompile(`^[A-Za-z_][\w-]*$`)
+
+// elementSelector returns a CSS selector matching only n: the path of child combinators
+// from its nearest ancestor with an id, or from the root, using :nth-of-type where siblings
+// share a tag.
+func elementSelector(n *html.Node) string {
+	var steps []string
+	for ; n != nil && n.Type == html.ElementNode; n = n.Parent {
+		if id := nodeAttr(n, "id"); id != "" {
+			if cssIdentRe.MatchString(id) {
+				steps = append(steps, n.Data+"#"+id)
+			} else {
+				steps = append(steps, n.Data+`[id="`+strings.ReplaceAll(id, `"`, `\"`)+`"]`)
+			}
+			break
+		}
+		step := n.Data
+		index, count := 0, 0
+		if n.Parent != nil {
+			for s := n.Parent.FirstChild; s != nil; s = s.NextSibling {
+				if s.Type == html.ElementNode && s.Data == n.Data {
+					count++
+					if s == n {
+						index = count
+					}
+				}
+			}
+		}
+		if count > 1 {
+			step += ":nth-of-type(" + strconv.Itoa(index) + ")"
+		}
+		steps = append(steps, step)
+	}
+	var b strings.Builder
+	for i := len(steps) - 1; i >= 0; i-- {
+		b.WriteString(steps[i])
+		if i > 0 {
+			b.WriteString(" > ")
+		}
+	}
+	return b.String()
+}
+
+// nodeAttr returns the value of the attribute key of n, or "".
+func nodeAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
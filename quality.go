@@ -0,0 +1,64 @@
+package gover
+
+import (
+	"math"
+	"slices"
+)
+
+// expectedKinds are the parts release notes have had since go1.5: each should yield a
+// category of its kind.
+var expectedKinds = []CategoryKind{KindLanguage, KindPorts, KindTools, KindRuntime, KindLibrary}
+
+// Weights of the parts of a completeness score.
+const (
+	sectionsWeight = 0.4
+	symbolsWeight  = 0.3
+	dateWeight     = 0.2
+	errorsWeight   = 0.1
+)
+
+// VersionQuality scores how completely a version was scraped, for gating on data quality.
+type VersionQuality struct {
+	Version          string  `json:"version"`
+	Score            float64 `json:"score"`            // from 0 to 1, see Completeness
+	Sections         int     `json:"sections"`         // expected parts of the release notes found
+	ExpectedSections int     `json:"expectedSections"` // see expectedKinds
+	Packages         int     `json:"packages"`         // package categories
+	ResolvedPackages int     `json:"resolvedPackages"` // of those, with symbol changes rather than only prose
+	Dated            bool    `json:"dated"`            // the release date is known
+	Errors           int     `json:"errors,omitempty"`
+}
+
+// Completeness scores vd from 0 to 1: 0.4 for the share of the expected parts of the
+// release notes (language, ports, tools, runtime and library) found, 0.3 for the share of
+// package categories resolved into symbol changes, 0.2 for a release date and 0.1 for
+// being scraped without errors.
+func Completeness(vd VersionData) VersionQuality {
+	q := VersionQuality{Version: vd.Version, ExpectedSections: len(expectedKinds), Dated: vd.ReleaseDate != "", Errors: len(vd.Errors)}
+	var found []CategoryKind
+	for _, cat := range vd.Changes {
+		if kind := categoryKind(cat); slices.Contains(expectedKinds, kind) && !slices.Contains(found, kind) {
+			found = append(found, kind)
+		}
+		if cat.Package != "" {
+			q.Packages++
+			if len(cat.Changes) > 0 {
+				q.ResolvedPackages++
+			}
+		}
+	}
+	q.Sections = len(found)
+
+	score := sectionsWeight * float64(q.Sections) / float64(q.ExpectedSections)
+	if q.Packages > 0 {
+		score += symbolsWeight * float64(q.ResolvedPackages) / float64(q.Packages)
+	}
+	if q.Dated {
+		score += dateWeight
+	}
+	if q.Errors == 0 {
+		score += errorsWeight
+	}
+	q.Score = math.Round(score*100) / 100
+	return q
+}
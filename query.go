@@ -0,0 +1,321 @@
+package gover
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DateLayout is the layout of VersionData.ReleaseDate.
+const DateLayout = "2006-01-02"
+
+// SearchResult is a single change matched by Search.
+type SearchResult struct {
+	Version  string     `json:"version"`
+	Category string     `json:"category"`
+	Package  string     `json:"package,omitempty"`
+	Symbol   string     `json:"symbol,omitempty"`
+	Type     ChangeType `json:"type,omitempty"`
+	Text     string     `json:"text"`
+	Score    int        `json:"score"`
+}
+
+// NormalizeVersion returns the "go1.X" form of a version such as "1.22", "go1.22" or "go1.22.3".
+// The original release is "go1", also for "go1.0.3". Pre-releases and versions it cannot
+// parse are returned as they are, with the "go" prefix.
+func NormalizeVersion(v string) string {
+	v = strings.TrimSpace(strings.ToLower(v))
+	if !strings.HasPrefix(v, "go") {
+		v = "go" + v
+	}
+	return cmp.Or(minorVersion(v), v)
+}
+
+// FindVersion returns the entry for version v.
+func FindVersion(data []VersionData, v string) (VersionData, bool) {
+	v = NormalizeVersion(v)
+	for _, vd := range data {
+		if vd.Version == v {
+			return vd, true
+		}
+	}
+	return VersionData{}, false
+}
+
+// FindSection returns the category of vd named by section, which may be the section's anchor
+// ("runtime"), its package ("net/http" or "http"), or all or part of its name, compared case-insensitively.
+func FindSection(vd VersionData, section string) (ChangeCategory, bool) {
+	s := strings.ToLower(strings.TrimSpace(section))
+	matchers := []func(ChangeCategory) bool{
+		func(cat ChangeCategory) bool {
+			_, anchor, ok := strings.Cut(cat.URL, "#")
+			return ok && strings.ToLower(anchor) == s
+		},
+		func(cat ChangeCategory) bool { return cat.Package == section },
+		func(cat ChangeCategory) bool { return cat.Package != "" && path.Base(cat.Package) == section },
+		func(cat ChangeCategory) bool { return strings.ToLower(cat.Category) == s },
+		func(cat ChangeCategory) bool { return strings.Contains(strings.ToLower(cat.Category), s) },
+	}
+	for _, match := range matchers {
+		for _, cat := range vd.Changes {
+			if match(cat) {
+				return cat, true
+			}
+		}
+	}
+	return ChangeCategory{}, false
+}
+
+// Diff returns the versions released after from, up to and including to, oldest first.
+// These are the release notes to read when upgrading from one version to the other.
+func Diff(data []VersionData, from, to string) ([]VersionData, error) {
+	fv, okFrom := parseGoVersion(NormalizeVersion(from))
+	tv, okTo := parseGoVersion(NormalizeVersion(to))
+	if !okFrom || !okTo {
+		return nil, fmt.Errorf("invalid version range %s..%s", from, to)
+	}
+	lo, hi := fv.minor, tv.minor
+	if lo >= hi {
+		return nil, fmt.Errorf("version %s is not older than %s", from, to)
+	}
+
+	var out []VersionData
+	for _, vd := range data {
+		if m := parseVersionMinor(vd.Version); m > lo && m <= hi {
+			out = append(out, vd)
+		}
+	}
+	slices.SortFunc(out, func(a, b VersionData) int {
+		return cmp.Compare(parseVersionMinor(a.Version), parseVersionMinor(b.Version))
+	})
+	return out, nil
+}
+
+// PackageChanges returns, for each version, only the changes that concern the import path pkg.
+// Versions without any such change are omitted.
+func PackageChanges(data []VersionData, pkg string) []VersionData {
+	var out []VersionData
+	for _, vd := range data {
+		var cats []ChangeCategory
+		for _, cat := range vd.Changes {
+			if cat.Package == pkg {
+				cats = append(cats, cat)
+				continue
+			}
+			var changes []SymbolChange
+			for _, sc := range cat.Changes {
+				if strings.HasPrefix(sc.Symbol, pkg+".") {
+					changes = append(changes, sc)
+				}
+			}
+			if len(changes) > 0 {
+				cat.Changes = changes
+				cats = append(cats, cat)
+			}
+		}
+		if len(cats) > 0 {
+			vd.Changes = cats
+			out = append(out, vd)
+		}
+	}
+	return out
+}
+
+// FilterByDate returns the versions released on or after after and before before.
+// A zero time leaves that end of the range open. Versions without a parsable release
+// date are dropped whenever a bound is given.
+func FilterByDate(data []VersionData, after, before time.Time) []VersionData {
+	if after.IsZero() && before.IsZero() {
+		return data
+	}
+	var out []VersionData
+	for _, vd := range data {
+		released, err := time.Parse(DateLayout, vd.ReleaseDate)
+		if err != nil {
+			continue
+		}
+		if !after.IsZero() && released.Before(after) {
+			continue
+		}
+		if !before.IsZero() && !released.Before(before) {
+			continue
+		}
+		out = append(out, vd)
+	}
+	return out
+}
+
+// FilterByType returns the data restricted to changes of the given normalized types.
+// Symbol changes of other types are dropped, and a category is kept if its own type
+// matches or if any of its symbol changes survive.
+func FilterByType(data []VersionData, types ...ChangeType) []VersionData {
+	if len(types) == 0 {
+		return data
+	}
+	var out []VersionData
+	for _, vd := range data {
+		var cats []ChangeCategory
+		for _, cat := range vd.Changes {
+			var changes []SymbolChange
+			for _, sc := range cat.Changes {
+				if slices.Contains(types, NormalizeChangeType(sc.Type)) {
+					changes = append(changes, sc)
+				}
+			}
+			if len(changes) > 0 || slices.Contains(types, cat.Type) {
+				cat.Changes = changes
+				cats = append(cats, cat)
+			}
+		}
+		if len(cats) > 0 {
+			vd.Changes = cats
+			out = append(out, vd)
+		}
+	}
+	return out
+}
+
+// FilterByPackages returns the data restricted to the changes to the packages with the
+// given import paths: their categories, and the symbol changes of theirs in other
+// categories. Versions left without changes are dropped.
+func FilterByPackages(data []VersionData, pkgs ...string) []VersionData {
+	var out []VersionData
+	for _, vd := range data {
+		var cats []ChangeCategory
+		for _, cat := range vd.Changes {
+			if cat.Package != "" {
+				if slices.Contains(pkgs, cat.Package) {
+					cats = append(cats, cat)
+				}
+				continue
+			}
+			var changes []SymbolChange
+			for _, sc := range cat.Changes {
+				if slices.Contains(pkgs, SymbolPackage(sc.Symbol)) {
+					changes = append(changes, sc)
+				}
+			}
+			if len(changes) > 0 {
+				cat.Changes = changes
+				cats = append(cats, cat)
+			}
+		}
+		if len(cats) > 0 {
+			vd.Changes = cats
+			out = append(out, vd)
+		}
+	}
+	return out
+}
+
+// FilterByPlatform hides the changes scoped to platforms other than goos and goarch, going
+// by the platforms their heading, or failing that their text, names. Changes naming no
+// platform, and those naming one of several matching platforms, are kept. An empty goos or
+// goarch matches any. Cgo and linking changes are filtered the same way.
+func FilterByPlatform(data []VersionData, goos, goarch string) []VersionData {
+	if goos == "" && goarch == "" {
+		return data
+	}
+	keep := func(platforms []string) bool {
+		return len(platforms) == 0 || slices.ContainsFunc(platforms, func(p string) bool { return platformMatches(p, goos, goarch) })
+	}
+	out := make([]VersionData, 0, len(data))
+	for _, vd := range data {
+		var cats []ChangeCategory
+		for _, cat := range vd.Changes {
+			platforms := platformQualifiers(cat.Category)
+			if len(platforms) == 0 {
+				platforms = platformQualifiers(cat.Description)
+			}
+			if !keep(platforms) {
+				continue
+			}
+			var changes []SymbolChange
+			for _, sc := range cat.Changes {
+				if keep(platformQualifiers(sc.Description)) {
+					changes = append(changes, sc)
+				}
+			}
+			cat.Changes = changes
+			cats = append(cats, cat)
+		}
+		vd.Changes = cats
+		var linking []LinkingChange
+		for _, l := range vd.Linking {
+			if keep(l.Platforms) {
+				linking = append(linking, l)
+			}
+		}
+		vd.Linking = linking
+		out = append(out, vd)
+	}
+	return out
+}
+
+// platformMatches reports whether platform, a GOOS, a GOARCH or a "GOOS/GOARCH" pair,
+// includes goos and goarch. A GOOS also includes those that satisfy its build tag, such as
+// android for linux.
+func platformMatches(platform, goos, goarch string) bool {
+	sys, arch, pair := strings.Cut(platform, "/")
+	if !pair {
+		if portNames[platform] == "GOARCH" {
+			sys, arch = "", platform
+		} else {
+			sys, arch = platform, ""
+		}
+	}
+	return (sys == "" || goos == "" || sys == goos || impliedGOOS[goos] == sys) &&
+		(arch == "" || goarch == "" || arch == goarch)
+}
+
+// Search finds changes whose text contains every word of query (case-insensitive),
+// ranked by how often the words occur. An empty query matches every change, which
+// combined with FilterByDate answers questions like "what changed during 2023".
+func Search(data []VersionData, query string) []SearchResult {
+	terms := strings.Fields(strings.ToLower(query))
+
+	var results []SearchResult
+	add := func(r SearchResult, text string) {
+		if r.Score = TermScore(strings.ToLower(text), terms); r.Score > 0 || len(terms) == 0 {
+			results = append(results, r)
+		}
+	}
+	for _, vd := range data {
+		for _, cat := range vd.Changes {
+			base := SearchResult{Version: vd.Version, Category: cat.Category, Package: cat.Package}
+			r := base
+			r.Type, r.Text = cat.Type, cat.Description
+			add(r, strings.Join(append([]string{cat.Category, cat.Title, cat.Package, cat.Description}, cat.Examples...), " "))
+			for _, sc := range cat.Changes {
+				r := base
+				r.Symbol, r.Type, r.Text = sc.Symbol, NormalizeChangeType(sc.Type), sc.Description
+				add(r, sc.Symbol+" "+sc.Description)
+			}
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b SearchResult) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		return cmp.Compare(parseVersionMinor(b.Version), parseVersionMinor(a.Version))
+	})
+	return results
+}
+
+// TermScore returns the total number of occurrences of terms in text, or 0 unless every term
+// occurs. Search ranks its results by it.
+func TermScore(text string, terms []string) int {
+	score := 0
+	for _, t := range terms {
+		n := strings.Count(text, t)
+		if n == 0 {
+			return 0
+		}
+		score += n
+	}
+	return score
+}
@@ -0,0 +1,108 @@
+package gover
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly/v2"
+)
+
+// go1ReleaseDate is the release date of go1, used if the release history page does not list it.
+const go1ReleaseDate = "2012-03-28"
+
+// Release is a major Go release and its minor revisions, as listed in the release history.
+type Release struct {
+	Version string  `json:"version"` // e.g. "go1.22"
+	Date    string  `json:"date"`
+	Patches []Patch `json:"patches,omitempty"` // oldest first
+}
+
+// Patch is a minor revision of a release.
+type Patch struct {
+	Version  string `json:"version"` // e.g. "go1.22.1"
+	Date     string `json:"date"`
+	Security bool   `json:"security"` // includes security fixes
+	Summary  string `json:"summary,omitempty"`
+}
+
+// Latest returns the newest revision of r, e.g. "go1.22.5", or r.Version if it has no patches.
+func (r Release) Latest() string {
+	if len(r.Patches) == 0 {
+		return r.Version
+	}
+	return r.Patches[len(r.Patches)-1].Version
+}
+
+// ReleaseHistory fetches the release timeline from go.dev without scraping any release notes.
+// It is a shorthand for NewClient().ReleaseHistory.
+func ReleaseHistory(ctx context.Context) ([]Release, error) {
+	return NewClient().ReleaseHistory(ctx)
+}
+
+// ReleaseHistory scrapes https://go.dev/doc/devel/release for every major Go release, newest
+// first, with the dates of its minor revisions and whether they include security fixes.
+func (c *Client) ReleaseHistory(ctx context.Context) ([]Release, error) {
+	log := c.opts.logger
+	var releases []Release
+
+	col := c.newCollector(ctx)
+	col.OnError(func(r *colly.Response, err error) {
+		log.Printf("Release history request URL: %s failed with response: %d, error: %v", r.Request.URL, r.StatusCode, err)
+	})
+	col.OnHTML("html", func(e *colly.HTMLElement) {
+		releases = parseReleaseHistory(e.DOM, c.sel)
+	})
+
+	historyURL := c.url(releaseHistoryPath)
+	if err := col.Visit(historyURL); err != nil {
+		return nil, networkError(fmt.Errorf("failed to visit release history page: %w", err))
+	}
+
+	if len(releases) == 0 {
+		return nil, parseError(fmt.Errorf("no release dates found on %s", historyURL))
+	}
+	if !strings.EqualFold(releases[len(releases)-1].Version, "go1") {
+		releases = append(releases, Release{Version: "go1", Date: go1ReleaseDate})
+	}
+	log.Printf("Found %d releases", len(releases))
+	return releases, nil
+}
+
+// parseReleaseHistory extracts the releases from the release history page. Major releases
+// are h2 headings such as "go1.22.0 (released 2024-02-06)"; minor revisions are paragraphs
+// starting "go1.22.1 (released 2024-03-05) includes security fixes to ...".
+func parseReleaseHistory(page *goquery.Selection, sel *selectors) []Release {
+	var releases []Release
+	index := make(map[string]int)
+	page.Find(sel.ReleaseHistory.Release + ", " + sel.ReleaseHistory.Patch).Each(func(_ int, s *goquery.Selection) {
+		text := strings.Join(strings.Fields(s.Text()), " ")
+		if s.Is(sel.ReleaseHistory.Release) {
+			m := sel.release.FindStringSubmatch(text)
+			if m == nil {
+				return
+			}
+			if _, ok := index[m[1]]; !ok {
+				index[m[1]] = len(releases)
+				releases = append(releases, Release{Version: m[1], Date: m[2]})
+			}
+			return
+		}
+		m := sel.patch.FindStringSubmatch(text)
+		if m == nil {
+			return
+		}
+		i, ok := index[strings.TrimSuffix(m[1], ".0")] // go1.0.1 is a revision of go1
+		if !ok {
+			return
+		}
+		releases[i].Patches = append(releases[i].Patches, Patch{
+			Version:  strings.Fields(text)[0],
+			Date:     m[2],
+			Security: strings.Contains(strings.ToLower(text), "security fix"),
+			Summary:  text,
+		})
+	})
+	return releases
+}
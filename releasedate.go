@@ -0,0 +1,89 @@
+package gover
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Release date sources, in VersionData.DateSource. The release history is preferred;
+// the others are only consulted for versions it does not date, such as when go.dev changes
+// its layout. go.dev/dl lists no dates, so it cannot serve as one.
+const (
+	DateSourceHistory   = "release-history" // https://go.dev/doc/devel/release
+	DateSourceGitHubTag = "github-tag"      // the commit the release is tagged at in the Go repository
+)
+
+// tagCommitPath, followed by a tag or branch of the Go repository, is the GitHub API
+// resource of the commit it points to.
+const tagCommitPath = "/repos/golang/go/commits/"
+
+// releaseDate is the release date of a version, the source that supplied it and, if the
+// source has it, the RFC3339 time of the release.
+type releaseDate struct {
+	date, source, at string
+}
+
+// apply records the source and time of d in vd, unless vd has another date.
+func (d releaseDate) apply(vd *VersionData) {
+	if vd.ReleaseDate != "" && vd.ReleaseDate == d.date {
+		vd.DateSource = d.source
+		vd.ReleasedAt = cmp.Or(d.at, vd.ReleasedAt)
+	}
+}
+
+// releaseDates returns the release date of each major version, keyed by version, falling back
+// to the GitHub tags of versions missing from the release history. Versions no source dates
+// are left out, and scraped without a date.
+func (c *Client) releaseDates(ctx context.Context, versions []string) map[string]releaseDate {
+	log := c.opts.logger
+	dates := make(map[string]releaseDate)
+	releases, err := c.ReleaseHistory(ctx)
+	if err != nil {
+		log.Printf("Warning: release history unavailable, dating releases by their GitHub tags: %v", err)
+	}
+	for _, r := range releases {
+		dates[r.Version] = releaseDate{date: r.Date, source: DateSourceHistory}
+	}
+	for _, v := range versions {
+		if _, ok := dates[v]; ok {
+			continue
+		}
+		at, err := c.tagTime(ctx, v)
+		if err != nil {
+			log.Printf("Warning: no release date for %s: %v", v, err)
+			continue
+		}
+		dates[v] = releaseDate{at.Format(DateLayout), DateSourceGitHubTag, at.Format(time.RFC3339)}
+	}
+	return dates
+}
+
+// tagTime returns the time of the commit the Go repository tags version at. It can precede
+// the announcement of the release by a day or so.
+func (c *Client) tagTime(ctx context.Context, version string) (time.Time, error) {
+	tag := version
+	if v, ok := parseGoVersion(version); ok && v.minor >= 21 {
+		tag += ".0" // from go1.21, the first release of a version is tagged go1.N.0
+	}
+	body, err := c.get(ctx, c.githubURL(tagCommitPath+tag))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to fetch tag %s: %w", tag, err)
+	}
+	var commit struct {
+		Commit struct {
+			Committer struct {
+				Date time.Time `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	if err := json.Unmarshal(body, &commit); err != nil {
+		return time.Time{}, parseError(fmt.Errorf("failed to decode tag %s: %w", tag, err))
+	}
+	if commit.Commit.Committer.Date.IsZero() {
+		return time.Time{}, parseError(fmt.Errorf("tag %s has no commit date", tag))
+	}
+	return commit.Commit.Committer.Date, nil
+}
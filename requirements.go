@@ -0,0 +1,69 @@
+package gover
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Requirements are the build and platform requirements stated by a release's notes.
+type Requirements struct {
+	Bootstrap string                `json:"bootstrap,omitempty"` // oldest Go release that can build the toolchain from source, e.g. "go1.20.6"
+	Platforms []PlatformRequirement `json:"platforms,omitempty"`
+}
+
+// PlatformRequirement is a minimum operating system or C toolchain version.
+type PlatformRequirement struct {
+	Platform string `json:"platform"` // e.g. "macOS", "Linux kernel", "GCC"
+	Minimum  string `json:"minimum"`  // e.g. "macOS 10.15 Catalina or later"
+	Text     string `json:"text"`     // the sentence stating the requirement
+}
+
+var (
+	// bootstrapRe finds the Go version following "requires" in a sentence about bootstrapping,
+	// e.g. "Go 1.22 requires the final point release of Go 1.20 or later for bootstrap".
+	bootstrapRe = regexp.MustCompile(`(?i)\brequires?\b.*?\bgo ?(1\.\d+(?:\.\d+)?)`)
+
+	// platformRe finds minimum platform versions, e.g. "requires macOS 10.15 Catalina or later".
+	platformRe = regexp.MustCompile(`(?i)\b(?:requires?|needs?)\s+(?:at least\s+|an?\s+)?((macOS|OS X|Windows|Linux kernel|FreeBSD|OpenBSD|NetBSD|DragonFly BSD|Solaris|illumos|AIX|iOS|Android|glibc|GCC|Clang|Xcode)\b[^;,:]*?(?:\s+or (?:later|newer|above)\b)?)(?:\s*[;,:]|\.?$|\s+(?:when|if|on|for|to)\b)`)
+)
+
+// extractRequirements collects the bootstrap and platform requirements stated on a release
+// notes page. It returns nil if there are none.
+func extractRequirements(page *goquery.Selection) *Requirements {
+	var req Requirements
+	seen := make(map[string]bool)
+	page.FindMatcher(paragraphMatcher).Each(func(_ int, s *goquery.Selection) {
+		for _, sentence := range sentences(s.Text()) {
+			if req.Bootstrap == "" && strings.Contains(strings.ToLower(sentence), "bootstrap") {
+				if m := bootstrapRe.FindStringSubmatch(sentence); m != nil {
+					req.Bootstrap = "go" + m[1]
+				}
+			}
+			for _, m := range platformRe.FindAllStringSubmatch(sentence, -1) {
+				minimum := strings.TrimSpace(m[1])
+				if seen[minimum] {
+					continue
+				}
+				seen[minimum] = true
+				req.Platforms = append(req.Platforms, PlatformRequirement{Platform: m[2], Minimum: minimum, Text: sentence})
+			}
+		}
+	})
+	if req.Bootstrap == "" && len(req.Platforms) == 0 {
+		return nil
+	}
+	return &req
+}
+
+// sentences splits text into whitespace-normalized sentences.
+func sentences(text string) []string {
+	var out []string
+	for _, s := range strings.SplitAfter(collapseSpace(text), ". ") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,117 @@
+package gover
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+var (
+	// exportedSymbolRe matches the names of exported package-level identifiers and
+	// their methods or fields, such as "ServeFileFS" and "Request.PathValue".
+	exportedSymbolRe = regexp.MustCompile(`^[A-Z]\w*(?:\.[A-Z]\w*)?$`)
+	// methodExprRe matches method expressions such as "(*Request).PathValue".
+	methodExprRe = regexp.MustCompile(`^\(\*?(\w+)\)\.(\w+)$`)
+
+	linkMatcher     = cascadia.MustCompile("a[href]")
+	mentionMatcher  = cascadia.MustCompile("a[href], code")
+	sentenceMatcher = cascadia.MustCompile("p, li, dd") // blocks of sentences mentioning symbols
+)
+
+// resolveSymbols returns a change for each symbol mentioned in the description of the
+// changes to package pkg: links to a symbol's documentation, and <code> spans naming an
+// identifier of pkg. Symbols are fully qualified, e.g. "net/http.ServeFileFS", and
+// described by the sentence mentioning them. It also returns the number of <code> spans
+// that look like exported identifiers but name no symbol of pkg.
+func resolveSymbols(pkg string, body *goquery.Selection, prov *provenancer) (changes []SymbolChange, unresolved int) {
+	seen := make(map[string]bool)
+	add := func(symbol string, mention *goquery.Selection) {
+		if seen[symbol] {
+			return
+		}
+		seen[symbol] = true
+		desc := mentioningSentence(mention)
+		typ := classifyChange(desc)
+		changes = append(changes, SymbolChange{
+			Type:        typ,
+			Symbol:      symbol,
+			Description: desc,
+			Impact:      classifyImpactPtr(typ, desc),
+			Provenance:  prov.of(mention, mentionBlock(mention)),
+		})
+	}
+
+	body.FindMatcher(mentionMatcher).Each(func(_ int, s *goquery.Selection) {
+		if goquery.NodeName(s) == "a" {
+			if symbol := linkedSymbol(s.AttrOr("href", "")); symbol != "" {
+				add(symbol, s)
+			}
+			return
+		}
+		if link := s.ParentsMatcher(linkMatcher); link.Length() > 0 && linkedSymbol(link.AttrOr("href", "")) != "" {
+			return // resolved from the link
+		}
+		if name := codeSymbol(pkg, s.Text()); name != "" {
+			add(pkg+"."+name, s)
+		} else if text := strings.TrimSpace(s.Text()); identifierRe.MatchString(text) && strings.ContainsFunc(text, unicode.IsUpper) {
+			unresolved++
+		}
+	})
+	return changes, unresolved
+}
+
+// linkedSymbol returns the symbol documented at href, such as "net/http.Request.PathValue"
+// for "/pkg/net/http/#Request.PathValue", or "" if href does not link to a symbol.
+func linkedSymbol(href string) string {
+	pkg := packagePath(href)
+	if pkg == "" {
+		return ""
+	}
+	u, err := url.Parse(href)
+	if err != nil || !exportedSymbolRe.MatchString(u.Fragment) {
+		return ""
+	}
+	return pkg + "." + u.Fragment
+}
+
+// codeSymbol returns the identifier of pkg named by the text of a <code> span, such as
+// "Request.PathValue" for "(*Request).PathValue()" or "http.Request.PathValue" in package
+// net/http, or "" if the text does not name one.
+func codeSymbol(pkg, text string) string {
+	text = strings.TrimSuffix(strings.TrimSpace(text), "()")
+	if m := methodExprRe.FindStringSubmatch(text); m != nil {
+		text = m[1] + "." + m[2]
+	}
+	text = strings.TrimPrefix(text, path.Base(pkg)+".")
+	if !exportedSymbolRe.MatchString(text) {
+		return ""
+	}
+	return text
+}
+
+// mentionBlock returns the paragraph or list item containing mention.
+func mentionBlock(mention *goquery.Selection) *goquery.Selection {
+	if block := mention.ClosestMatcher(sentenceMatcher); block.Length() > 0 {
+		return block
+	}
+	return mention.Parent()
+}
+
+// mentioningSentence returns the sentence of the paragraph containing mention that
+// mentions it, or the whole paragraph if that cannot be told.
+func mentioningSentence(mention *goquery.Selection) string {
+	block := mentionBlock(mention)
+	name := collapseSpace(mention.Text())
+	all := sentences(block.Text())
+	for _, s := range all {
+		if name != "" && strings.Contains(s, name) {
+			return s
+		}
+	}
+	return strings.Join(all, " ")
+}
@@ -0,0 +1,177 @@
+package gover
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SymbolUse is a reference in Go source to a package-level identifier of an imported package.
+type SymbolUse struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+	Symbol string `json:"symbol"` // e.g. "net/http.CloseNotifier"
+}
+
+// majorVersionRe matches the major version suffix of an import path, e.g. "/v2".
+var majorVersionRe = regexp.MustCompile(`^v\d+$`)
+
+// ScanSymbolUses parses the Go files under root and returns their references to package-level
+// identifiers of imported packages, such as http.CloseNotifier. Vendored, testdata and hidden
+// directories are skipped. Without type information methods and fields are not resolved, so
+// req.PathValue is not reported as a use of net/http.Request.PathValue.
+func ScanSymbolUses(root string) ([]SymbolUse, error) {
+	return scanSymbolUses(root, nil)
+}
+
+// scanSymbolUses scans root as ScanSymbolUses does, also skipping the directories skip
+// reports, if it is not nil.
+func scanSymbolUses(root string, skip func(dir string) bool) ([]SymbolUse, error) {
+	var uses []SymbolUse
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if p != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || (skip != nil && skip(p))) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(p, ".go") {
+			return nil
+		}
+		file, err := parser.ParseFile(fset, p, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return parseError(err)
+		}
+		uses = append(uses, fileSymbolUses(fset, file)...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+	}
+	return uses, nil
+}
+
+// StdlibImports returns the standard library packages imported by the Go files matched
+// by patterns, sorted: a directory, or with a "/..." suffix the directory and those
+// under it, as with "./..." for the current module. Directories are skipped as by
+// ScanSymbolUses, as are those of nested modules, having a go.mod of their own. An import
+// is of the standard library if data has changes to its package, so that the packages
+// of a module whose path has no dot, such as "example/app", are not mistaken for it.
+// Only direct imports count; the packages those depend on are not included.
+func StdlibImports(data []VersionData, patterns ...string) ([]string, error) {
+	stdlib := datasetPackages(data)
+	imports := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, pattern := range patterns {
+		root, recursive := strings.CutSuffix(pattern, "/...")
+		if pattern == "..." {
+			root, recursive = ".", true
+		}
+		err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				name := d.Name()
+				if p != root && (!recursive || name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+					return filepath.SkipDir
+				}
+				if _, err := os.Stat(filepath.Join(p, "go.mod")); p != root && err == nil {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(p, ".go") {
+				return nil
+			}
+			file, err := parser.ParseFile(fset, p, nil, parser.ImportsOnly)
+			if err != nil {
+				return parseError(err)
+			}
+			for _, imp := range file.Imports {
+				if importPath, err := strconv.Unquote(imp.Path.Value); err == nil && stdlib[importPath] {
+					imports[importPath] = true
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", pattern, err)
+		}
+	}
+	return slices.Sorted(maps.Keys(imports)), nil
+}
+
+// datasetPackages returns the set of packages data has changes to, whole or to their symbols.
+func datasetPackages(data []VersionData) map[string]bool {
+	pkgs := make(map[string]bool)
+	for _, vd := range data {
+		for _, cat := range vd.Changes {
+			if cat.Package != "" {
+				pkgs[cat.Package] = true
+			}
+			for _, sc := range cat.Changes {
+				pkgs[SymbolPackage(sc.Symbol)] = true
+			}
+		}
+	}
+	return pkgs
+}
+
+// fileSymbolUses returns the selector expressions of file that refer to imported packages.
+func fileSymbolUses(fset *token.FileSet, file *ast.File) []SymbolUse {
+	imports := make(map[string]string)
+	for _, imp := range file.Imports {
+		importPath, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(importPath)
+		if majorVersionRe.MatchString(name) {
+			name = path.Base(path.Dir(importPath))
+		}
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name != "_" && name != "." {
+			imports[name] = importPath
+		}
+	}
+
+	var uses []SymbolUse
+	ast.Inspect(file, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if x, ok := sel.X.(*ast.Ident); ok {
+			if importPath, ok := imports[x.Name]; ok {
+				pos := fset.Position(sel.Sel.Pos())
+				uses = append(uses, SymbolUse{
+					File:   pos.Filename,
+					Line:   pos.Line,
+					Column: pos.Column,
+					Symbol: importPath + "." + sel.Sel.Name,
+				})
+			}
+		}
+		return true
+	})
+	return uses
+}
@@ -0,0 +1,120 @@
+package gover
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// securityFixRe captures what the security fixes of a patch release apply to in its
+// release history entry, e.g. "the crypto/x509, net/http, and net/mail packages" in
+// "go1.22.1 (released 2024-03-05) includes security fixes to the crypto/x509, net/http,
+// and net/mail packages, as well as bug fixes to the compiler".
+var securityFixRe = regexp.MustCompile(`security fix(?:es)? to (.+?)(?:,? as well as |,? affecting |\.(?:\s|$)|$)`)
+
+// securityTools maps the tools named in the release history to their command paths.
+var securityTools = map[string]string{
+	"go command": "cmd/go",
+	"compiler":   "cmd/compile",
+	"linker":     "cmd/link",
+	"cgo":        "cmd/cgo",
+}
+
+// SecurityFix is a patch release including security fixes.
+type SecurityFix struct {
+	Version  string   `json:"version"` // e.g. "go1.21.1"
+	Date     string   `json:"date"`
+	Packages []string `json:"packages,omitempty"` // packages and commands fixed, such as "net/http" or "cmd/go"
+}
+
+// SecurityPosture summarizes the security fixes of a minor line, see SummarizeSecurity.
+type SecurityPosture struct {
+	Version   string        `json:"version"` // the minor line, e.g. "go1.21"
+	Date      string        `json:"date"`
+	Supported bool          `json:"supported"`          // fewer than two newer minor lines have been released
+	Patches   int           `json:"patches"`            // patch releases of the line
+	Fixes     []SecurityFix `json:"fixes,omitempty"`    // those including security fixes, oldest first
+	Packages  []string      `json:"packages,omitempty"` // fixed by any of them, sorted
+	Minimum   string        `json:"minimum"`            // the oldest release with all the security fixes
+	Latest    string        `json:"latest"`             // the newest release, which users should be on
+}
+
+// Table implements Tabular.
+func (s SecurityPosture) Table() [][]string {
+	table := [][]string{
+		{"Security", "Value"},
+		{"Version", s.Version},
+		{"Released", s.Date},
+		{"Supported", strconv.FormatBool(s.Supported)},
+		{"Patches", strconv.Itoa(s.Patches)},
+		{"Security patches", strconv.Itoa(len(s.Fixes))},
+		{"Affected packages", strings.Join(s.Packages, ", ")},
+		{"Minimum", s.Minimum},
+		{"Latest", s.Latest},
+	}
+	for _, f := range s.Fixes {
+		table = append(table, []string{f.Version + " (" + f.Date + ")", strings.Join(f.Packages, ", ")})
+	}
+	return table
+}
+
+// SummarizeSecurity summarizes the security fixes of the minor line of version, such as
+// "go1.21", "1.21" or "go1.21.3", from the release history: how many of its patch
+// releases included security fixes, the packages they fixed and the release users must
+// be on. Minimum is the newest patch release with security fixes, Latest the newest patch
+// release; both are the line itself if none were made.
+func SummarizeSecurity(releases []Release, version string) (SecurityPosture, error) {
+	minor := minorVersion(releaseName(version))
+	if minor == "" {
+		return SecurityPosture{}, fmt.Errorf("invalid Go version %q", version)
+	}
+	i := slices.IndexFunc(releases, func(r Release) bool { return r.Version == minor })
+	if i < 0 {
+		return SecurityPosture{}, fmt.Errorf("no release %s in the release history", minor)
+	}
+	r := releases[i]
+	s := SecurityPosture{
+		Version:   r.Version,
+		Date:      r.Date,
+		Supported: i < 2, // releases are newest first
+		Patches:   len(r.Patches),
+		Minimum:   r.Version,
+		Latest:    r.Latest(),
+	}
+	for _, p := range r.Patches {
+		if !p.Security {
+			continue
+		}
+		fix := SecurityFix{Version: p.Version, Date: p.Date, Packages: securityPackages(p.Summary)}
+		s.Fixes = append(s.Fixes, fix)
+		s.Packages = append(s.Packages, fix.Packages...)
+		s.Minimum = p.Version
+	}
+	slices.Sort(s.Packages)
+	s.Packages = slices.Compact(s.Packages)
+	return s, nil
+}
+
+// securityPackages returns the packages and commands the security fixes of a patch release
+// apply to, from its release history entry.
+func securityPackages(summary string) []string {
+	m := securityFixRe.FindStringSubmatch(summary)
+	if m == nil {
+		return nil
+	}
+	list := strings.NewReplacer(", and ", ", ", " and ", ", ").Replace(m[1])
+	var packages []string
+	for _, p := range strings.Split(list, ", ") {
+		p = strings.TrimPrefix(strings.TrimSpace(p), "the ")
+		p = strings.TrimSuffix(strings.TrimSuffix(p, " packages"), " package")
+		if tool, ok := securityTools[p]; ok {
+			p = tool
+		}
+		if p != "" && !slices.Contains(packages, p) {
+			packages = append(packages, p)
+		}
+	}
+	return packages
+}
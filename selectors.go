@@ -0,0 +1,259 @@
+package gover
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+	"gopkg.in/yaml.v3"
+)
+
+// SelectorsVersion is the newest selectors configuration format this package understands.
+const SelectorsVersion = 1
+
+//go:embed selectors.yaml
+var defaultSelectorsYAML []byte
+
+// Selectors configures the CSS selectors and regular expressions used to parse go.dev
+// pages, so that parsing can be fixed when the markup changes. The defaults are in
+// selectors.yaml; patterns must keep the capture groups of the defaults.
+type Selectors struct {
+	Version        int                     `yaml:"version"`
+	ReleaseNotes   ReleaseNotesSelectors   `yaml:"releaseNotes"`
+	ReleaseHistory ReleaseHistorySelectors `yaml:"releaseHistory"`
+	Spec           SpecSelectors           `yaml:"spec"`
+	Announcements  AnnouncementSelectors   `yaml:"announcements"`
+}
+
+// ReleaseNotesSelectors locate the parts of a release notes page.
+type ReleaseNotesSelectors struct {
+	Title           string             `yaml:"title"`
+	Section         string             `yaml:"section"`
+	Description     string             `yaml:"description"`
+	Content         string             `yaml:"content"`
+	LanguageSection string             `yaml:"languageSection"` // regular expression matching the heading
+	Profiles        []ProfileSelectors `yaml:"profiles"`        // the first matching a version applies
+}
+
+// ProfileSelectors locate the subsections and per-package entries of the release notes of
+// the versions matching Versions, whose markup differs from era to era. Selectors left
+// empty are not used.
+type ProfileSelectors struct {
+	Name        string `yaml:"name"`
+	Versions    string `yaml:"versions"`    // version constraint, see ParseConstraint
+	Subsection  string `yaml:"subsection"`  // headings nested in sections
+	Package     string `yaml:"package"`     // entries describing the changes to one package
+	PackageLink string `yaml:"packageLink"` // the link to the package's documentation within an entry
+	PackageBody string `yaml:"packageBody"` // the description within an entry (default: the entry, or the content following a heading)
+}
+
+// ReleaseHistorySelectors locate the releases on the release history page.
+type ReleaseHistorySelectors struct {
+	Release        string `yaml:"release"`
+	Patch          string `yaml:"patch"`
+	ReleasePattern string `yaml:"releasePattern"` // captures the version and date
+	PatchPattern   string `yaml:"patchPattern"`   // captures the minor version and date
+}
+
+// SpecSelectors locate the version of the language specification.
+type SpecSelectors struct {
+	VersionPattern string `yaml:"versionPattern"` // captures the version and date
+}
+
+// AnnouncementSelectors locate release announcements on the Go blog.
+type AnnouncementSelectors struct {
+	TitlePattern string `yaml:"titlePattern"` // captures the minor version
+	Paragraphs   string `yaml:"paragraphs"`
+}
+
+// DefaultSelectors returns the built-in selectors.
+func DefaultSelectors() *Selectors {
+	var s Selectors
+	if err := yaml.Unmarshal(defaultSelectorsYAML, &s); err != nil {
+		panic("invalid embedded selectors: " + err.Error())
+	}
+	return &s
+}
+
+// ParseSelectors parses a selectors configuration in YAML (or JSON). Entries it does not
+// set keep their defaults.
+func ParseSelectors(data []byte) (*Selectors, error) {
+	s := DefaultSelectors()
+	if err := yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to parse selectors: %w", err)
+	}
+	if s.Version > SelectorsVersion {
+		return nil, fmt.Errorf("selectors version %d is newer than the supported version %d", s.Version, SelectorsVersion)
+	}
+	if _, err := s.compile(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// LoadSelectors reads a selectors configuration file, see ParseSelectors.
+func LoadSelectors(path string) (*Selectors, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read selectors: %w", err)
+	}
+	s, err := ParseSelectors(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// selectors is the validated form of Selectors used by the parsers, with the selectors
+// of release notes pages compiled once rather than on every page.
+type selectors struct {
+	Selectors
+	title           goquery.Matcher
+	section         goquery.Matcher
+	description     goquery.Matcher
+	content         goquery.Matcher
+	languageSection *regexp.Regexp
+	release         *regexp.Regexp
+	patch           *regexp.Regexp
+	specVersion     *regexp.Regexp
+	announcement    *regexp.Regexp
+	profiles        []profile
+	noProfile       profile // for versions no profile matches
+}
+
+// profile is the validated form of ProfileSelectors. Matchers of selectors left empty are nil.
+type profile struct {
+	ProfileSelectors
+	versions    Constraint
+	headings    goquery.Matcher // sections and subsections
+	query       goquery.Matcher // headings and package entries
+	pkg         goquery.Matcher
+	packageLink goquery.Matcher
+	packageBody goquery.Matcher
+}
+
+// profile returns the parsing profile for the release notes of version, or nil if there is none.
+func (s *selectors) profile(version string) *profile {
+	for i, p := range s.profiles {
+		if p.versions.Match(version) {
+			return &s.profiles[i]
+		}
+	}
+	return nil
+}
+
+// compile checks the selectors and compiles them and the regular expressions.
+func (s *Selectors) compile() (*selectors, error) {
+	c := &selectors{Selectors: *s}
+	for _, sel := range []struct {
+		name, selector string
+		m              *goquery.Matcher
+	}{
+		{"releaseNotes.title", s.ReleaseNotes.Title, &c.title},
+		{"releaseNotes.section", s.ReleaseNotes.Section, &c.section},
+		{"releaseNotes.description", s.ReleaseNotes.Description, &c.description},
+		{"releaseNotes.content", s.ReleaseNotes.Content, &c.content},
+		{"releaseHistory.release", s.ReleaseHistory.Release, nil},
+		{"releaseHistory.patch", s.ReleaseHistory.Patch, nil},
+		{"announcements.paragraphs", s.Announcements.Paragraphs, nil},
+	} {
+		g, err := cascadia.Compile(sel.selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector %s %q: %w", sel.name, sel.selector, err)
+		}
+		if sel.m != nil {
+			*sel.m = g
+		}
+	}
+
+	for i, p := range s.ReleaseNotes.Profiles {
+		versions, err := ParseConstraint(p.Versions)
+		if err != nil {
+			return nil, fmt.Errorf("invalid versions of profile %d (%s): %w", i, p.Name, err)
+		}
+		prof := profile{ProfileSelectors: p, versions: versions}
+		for _, sel := range []struct {
+			name, selector string
+			m              *goquery.Matcher
+		}{
+			{"subsection", p.Subsection, nil},
+			{"package", p.Package, &prof.pkg},
+			{"packageLink", p.PackageLink, &prof.packageLink},
+			{"packageBody", p.PackageBody, &prof.packageBody},
+		} {
+			if sel.selector == "" {
+				continue
+			}
+			g, err := cascadia.Compile(sel.selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector %s of profile %d (%s) %q: %w", sel.name, i, p.Name, sel.selector, err)
+			}
+			if sel.m != nil {
+				*sel.m = g
+			}
+		}
+		c.profiles = append(c.profiles, prof)
+	}
+	c.noProfile.headings, c.noProfile.query = c.section, c.section
+	for i := range c.profiles {
+		p := &c.profiles[i]
+		var err error
+		if p.headings, p.query, err = c.profileQueries(p.ProfileSelectors); err != nil {
+			return nil, fmt.Errorf("invalid selectors of profile %d (%s): %w", i, p.Name, err)
+		}
+	}
+	for _, p := range []struct {
+		name    string
+		pattern string
+		groups  int
+		re      **regexp.Regexp
+	}{
+		{"releaseNotes.languageSection", s.ReleaseNotes.LanguageSection, 0, &c.languageSection},
+		{"releaseHistory.releasePattern", s.ReleaseHistory.ReleasePattern, 2, &c.release},
+		{"releaseHistory.patchPattern", s.ReleaseHistory.PatchPattern, 2, &c.patch},
+		{"spec.versionPattern", s.Spec.VersionPattern, 2, &c.specVersion},
+		{"announcements.titlePattern", s.Announcements.TitlePattern, 1, &c.announcement},
+	} {
+		re, err := regexp.Compile(p.pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", p.name, err)
+		}
+		if re.NumSubexp() < p.groups {
+			return nil, fmt.Errorf("pattern %s %q must have %d capture groups", p.name, p.pattern, p.groups)
+		}
+		*p.re = re
+	}
+	return c, nil
+}
+
+// profileQueries compiles the selectors of the headings of release notes parsed with p,
+// and of those headings and its package entries.
+func (s *selectors) profileQueries(p ProfileSelectors) (headings, query goquery.Matcher, err error) {
+	h := s.ReleaseNotes.Section
+	if p.Subsection != "" {
+		h += ", " + p.Subsection
+	}
+	q := h
+	if p.Package != "" {
+		q += ", " + p.Package
+	}
+	if headings, err = cascadia.Compile(h); err != nil {
+		return nil, nil, err
+	}
+	if query, err = cascadia.Compile(q); err != nil {
+		return nil, nil, err
+	}
+	return headings, query, nil
+}
+
+// defaultCompiled is the compiled form of the built-in selectors.
+var defaultCompiled = func() *selectors {
+	c, err := DefaultSelectors().compile()
+	if err != nil {
+		panic("invalid embedded selectors: " + err.Error())
+	}
+	return c
+}()
@@ -0,0 +1,75 @@
+package gover
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// specPath is the go.dev page of the language specification.
+const specPath = "/ref/spec"
+
+// SpecChanges maps a release to the language specification.
+type SpecChanges struct {
+	Version  string   `json:"version,omitempty"`  // date of the spec revision for the release, when known
+	Sections []string `json:"sections,omitempty"` // spec sections referenced by "Changes to the language", e.g. "For_statements"
+}
+
+// specChanges collects the spec sections linked from the content of a language changes section.
+// It returns nil if there are none.
+func specChanges(content *goquery.Selection) *SpecChanges {
+	var sections []string
+	seen := make(map[string]bool)
+	content.Find("a[href]").AddSelection(content.Filter("a[href]")).Each(func(_ int, a *goquery.Selection) {
+		u, err := url.Parse(a.AttrOr("href", ""))
+		if err != nil || !strings.HasSuffix(u.Path, specPath) || u.Fragment == "" || seen[u.Fragment] {
+			return
+		}
+		seen[u.Fragment] = true
+		sections = append(sections, u.Fragment)
+	})
+	if len(sections) == 0 {
+		return nil
+	}
+	return &SpecChanges{Sections: sections}
+}
+
+// SpecVersion fetches the language version the current specification describes and the
+// date of its revision (in DateLayout). go.dev only publishes the current revision.
+func (c *Client) SpecVersion(ctx context.Context) (version, date string, err error) {
+	body, err := c.get(ctx, c.url(specPath))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to fetch language specification: %w", err)
+	}
+	m := c.sel.specVersion.FindSubmatch(body)
+	if m == nil {
+		return "", "", parseError(fmt.Errorf("language version not found in specification"))
+	}
+	t, err := time.Parse("Jan 2, 2006", string(m[2]))
+	if err != nil {
+		return "", "", parseError(fmt.Errorf("failed to parse specification date: %w", err))
+	}
+	return string(m[1]), t.Format(DateLayout), nil
+}
+
+// addSpecVersion records the current spec revision on the release it describes. Failing to
+// fetch it only loses the date, so it is logged rather than returned.
+func (c *Client) addSpecVersion(ctx context.Context, data []VersionData) {
+	version, date, err := c.SpecVersion(ctx)
+	if err != nil {
+		c.opts.logger.Printf("Warning: specification version not recorded: %v", err)
+		return
+	}
+	for i := range data {
+		if data[i].Version == version {
+			if data[i].Spec == nil {
+				data[i].Spec = &SpecChanges{}
+			}
+			data[i].Spec.Version = date
+		}
+	}
+}
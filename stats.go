@@ -0,0 +1,44 @@
+package gover
+
+// Stats summarizes a dataset.
+type Stats struct {
+	Versions []VersionStats `json:"versions"`
+	API      []APIGrowth    `json:"api,omitempty"`
+}
+
+// VersionStats counts the changes recorded for a version.
+type VersionStats struct {
+	Version       string             `json:"version"`
+	ReleaseDate   string             `json:"releaseDate,omitempty"`
+	Categories    int                `json:"categories"`
+	SymbolChanges int                `json:"symbolChanges"`
+	ByType        map[ChangeType]int `json:"byType"`
+
+	Contributions *ContributionStats `json:"contributions,omitempty"`
+}
+
+// ComputeStats counts the categories and changes of each version in data, preserving its order.
+// The API field is left empty; fill it with ComputeAPIGrowth when api files are available.
+func ComputeStats(data []VersionData) Stats {
+	stats := Stats{Versions: make([]VersionStats, 0, len(data))}
+	for _, vd := range data {
+		vs := VersionStats{
+			Version:       vd.Version,
+			ReleaseDate:   vd.ReleaseDate,
+			ByType:        make(map[ChangeType]int),
+			Contributions: vd.Contributions,
+		}
+		for _, cat := range vd.Changes {
+			vs.Categories++
+			if cat.Type != "" {
+				vs.ByType[cat.Type]++
+			}
+			for _, sc := range cat.Changes {
+				vs.SymbolChanges++
+				vs.ByType[NormalizeChangeType(sc.Type)]++
+			}
+		}
+		stats.Versions = append(stats.Versions, vs)
+	}
+	return stats
+}
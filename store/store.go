@@ -0,0 +1,152 @@
+// Package store indexes a gover dataset in memory for answering many queries without
+// re-scanning it each time, as gover serve does.
+package store
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/paulstuart/gover"
+)
+
+// Store is an indexed, read-only view of a dataset. It is safe for concurrent use; the
+// data it returns shares memory with the dataset it was loaded from and must not be modified.
+type Store struct {
+	versions  []gover.VersionData
+	byVersion map[string]int
+	packages  map[string][]categoryRef
+	symbols   map[string][]gover.SymbolMatch
+	records   []record
+}
+
+// categoryRef locates the changes of one category that concern a package.
+type categoryRef struct {
+	version, category int
+	changes           []int // the indexes of the symbol changes, or nil for the whole category
+}
+
+// record is a change Search matches, with its lower-cased text.
+type record struct {
+	result gover.SearchResult
+	text   string
+}
+
+// Load indexes data, which must not be modified afterwards.
+func Load(data []gover.VersionData) *Store {
+	s := &Store{
+		versions:  data,
+		byVersion: make(map[string]int, len(data)),
+		packages:  make(map[string][]categoryRef),
+		symbols:   make(map[string][]gover.SymbolMatch),
+	}
+	for i, vd := range data {
+		if _, ok := s.byVersion[vd.Version]; !ok {
+			s.byVersion[vd.Version] = i
+		}
+		for j, cat := range vd.Changes {
+			s.indexCategory(i, j, vd.Version, cat)
+		}
+	}
+	return s
+}
+
+// indexCategory adds the category at data[i].Changes[j] to the indexes.
+func (s *Store) indexCategory(i, j int, version string, cat gover.ChangeCategory) {
+	if cat.Package != "" {
+		s.packages[cat.Package] = append(s.packages[cat.Package], categoryRef{version: i, category: j})
+	}
+	base := gover.SearchResult{Version: version, Category: cat.Category, Package: cat.Package}
+	r := base
+	r.Type, r.Text = cat.Type, cat.Description
+	s.records = append(s.records, record{r, strings.ToLower(strings.Join(append([]string{cat.Category, cat.Title, cat.Package, cat.Description}, cat.Examples...), " "))})
+
+	var pkgs []string
+	changes := make(map[string][]int)
+	for k, sc := range cat.Changes {
+		r := base
+		r.Symbol, r.Type, r.Text = sc.Symbol, gover.NormalizeChangeType(sc.Type), sc.Description
+		s.records = append(s.records, record{r, strings.ToLower(sc.Symbol + " " + sc.Description)})
+		if sc.Symbol == "" {
+			continue
+		}
+		m := gover.SymbolMatch{Version: version, Package: cat.Package, Change: sc, Score: 100}
+		full := strings.ToLower(sc.Symbol)
+		s.symbols[full] = append(s.symbols[full], m)
+		if short := full[strings.LastIndex(full, "/")+1:]; short != full {
+			m.Score = 85
+			s.symbols[short] = append(s.symbols[short], m)
+		}
+		if pkg := gover.SymbolPackage(sc.Symbol); pkg != sc.Symbol && pkg != cat.Package {
+			if _, ok := changes[pkg]; !ok {
+				pkgs = append(pkgs, pkg)
+			}
+			changes[pkg] = append(changes[pkg], k)
+		}
+	}
+	for _, pkg := range pkgs {
+		s.packages[pkg] = append(s.packages[pkg], categoryRef{version: i, category: j, changes: changes[pkg]})
+	}
+}
+
+// Versions returns the versions of the dataset, in the order they were loaded.
+func (s *Store) Versions() []gover.VersionData {
+	return slices.Clip(s.versions)
+}
+
+// ByVersion returns the entry for version v, like gover.FindVersion.
+func (s *Store) ByVersion(v string) (gover.VersionData, bool) {
+	i, ok := s.byVersion[gover.NormalizeVersion(v)]
+	if !ok {
+		return gover.VersionData{}, false
+	}
+	return s.versions[i], true
+}
+
+// ByPackage returns, for each version, only the changes that concern the import path pkg,
+// like gover.PackageChanges.
+func (s *Store) ByPackage(pkg string) []gover.VersionData {
+	var out []gover.VersionData
+	last := -1 // the version of the last entry of out
+	for _, ref := range s.packages[pkg] {
+		vd := s.versions[ref.version]
+		cat := vd.Changes[ref.category]
+		if ref.changes != nil {
+			cat.Changes = make([]gover.SymbolChange, len(ref.changes))
+			for i, k := range ref.changes {
+				cat.Changes[i] = vd.Changes[ref.category].Changes[k]
+			}
+		}
+		if ref.version != last {
+			vd.Changes = nil
+			out = append(out, vd)
+			last = ref.version
+		}
+		out[len(out)-1].Changes = append(out[len(out)-1].Changes, cat)
+	}
+	return out
+}
+
+// BySymbol returns the changes to symbol, given with its import path
+// ("net/http.Client.Do") or only the last element of it ("http.Client.Do"), compared
+// case-insensitively. For partial and fuzzy matches use gover.LookupSymbol.
+func (s *Store) BySymbol(symbol string) []gover.SymbolMatch {
+	return slices.Clip(s.symbols[strings.ToLower(strings.TrimSpace(symbol))])
+}
+
+// Search finds changes whose text contains every word of query, like gover.Search.
+func (s *Store) Search(query string) []gover.SearchResult {
+	terms := strings.Fields(strings.ToLower(query))
+	var results []gover.SearchResult
+	for _, rec := range s.records {
+		if score := gover.TermScore(rec.text, terms); score > 0 || len(terms) == 0 {
+			r := rec.result
+			r.Score = score
+			results = append(results, r)
+		}
+	}
+	slices.SortStableFunc(results, func(a, b gover.SearchResult) int {
+		return cmp.Or(cmp.Compare(b.Score, a.Score), gover.CompareVersions(b.Version, a.Version))
+	})
+	return results
+}
@@ -0,0 +1,68 @@
+package gover
+
+import (
+	"slices"
+	"time"
+)
+
+// Timeline is the release history as release lines with their support windows, which the
+// mermaid and dot formats draw as a chart, see NewTimeline.
+type Timeline struct {
+	Date   string         `json:"date"`             // when it was made, where the windows of supported lines end
+	Pinned string         `json:"pinned,omitempty"` // the release highlighted, e.g. "go1.21.3"
+	Lines  []TimelineLine `json:"lines"`            // oldest first
+}
+
+// TimelineLine is a release line of a Timeline.
+type TimelineLine struct {
+	Version string  `json:"version"` // e.g. "go1.22"
+	Date    string  `json:"date"`
+	EOLDate string  `json:"eolDate,omitempty"` // when the second newer line came out, ending support
+	Patches []Patch `json:"patches,omitempty"` // oldest first
+	Pinned  bool    `json:"pinned,omitempty"`  // the line of Timeline.Pinned
+}
+
+// Supported reports whether the line is still supported.
+func (l TimelineLine) Supported() bool {
+	return l.EOLDate == ""
+}
+
+// End returns the end of the support window of the line in t.
+func (l TimelineLine) End(t *Timeline) string {
+	if l.Supported() {
+		return t.Date
+	}
+	return l.EOLDate
+}
+
+// NewTimeline arranges releases, as returned by Client.ReleaseHistory, into a timeline
+// made at now, highlighting the release pinned, such as "go1.21.3", if set.
+func NewTimeline(releases []Release, pinned string, now time.Time) *Timeline {
+	t := &Timeline{Date: now.Format(DateLayout)}
+	if pinned != "" {
+		t.Pinned = releaseName(pinned)
+	}
+	pinnedLine := minorVersion(t.Pinned)
+	for i, r := range releases { // newest first
+		l := TimelineLine{Version: r.Version, Date: r.Date, Patches: r.Patches, Pinned: r.Version == pinnedLine}
+		if i >= 2 {
+			l.EOLDate = releases[i-2].Date
+		}
+		t.Lines = append(t.Lines, l)
+	}
+	slices.Reverse(t.Lines)
+	return t
+}
+
+// timelineOf returns v as a Timeline, made now if v is the releases of a release history.
+func timelineOf(v any) (*Timeline, bool) {
+	switch v := v.(type) {
+	case *Timeline:
+		return v, true
+	case Timeline:
+		return &v, true
+	case []Release:
+		return NewTimeline(v, "", time.Now()), true
+	}
+	return nil, false
+}
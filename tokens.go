@@ -0,0 +1,134 @@
+package gover
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// charsPerToken is the rough number of characters per token of typical LLM tokenizers on English text.
+const charsPerToken = 4
+
+// ApproxTokens estimates the number of LLM tokens in s.
+func ApproxTokens(s string) int {
+	return (utf8.RuneCountInString(s) + charsPerToken - 1) / charsPerToken
+}
+
+// categoryText is the text of a category that is counted and exported, excluding its symbol changes.
+func categoryText(cat ChangeCategory) string {
+	parts := []string{cat.Category, cat.Title, cat.Description}
+	parts = append(parts, cat.Examples...)
+	return joinNonEmpty(parts)
+}
+
+// symbolText is the text of a symbol change that is counted and exported.
+func symbolText(sc SymbolChange) string {
+	return joinNonEmpty([]string{sc.Symbol, sc.Description})
+}
+
+func joinNonEmpty(parts []string) string {
+	var out []string
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// CountTokens returns a copy of data with the character and approximate token counts of
+// every category and symbol change filled in. Scrape does this already; use it for
+// datasets scraped by earlier versions.
+func CountTokens(data []VersionData) []VersionData {
+	out := make([]VersionData, 0, len(data))
+	for _, vd := range data {
+		vd.Changes = countCategoryTokens(vd.Changes)
+		out = append(out, vd)
+	}
+	return out
+}
+
+// countCategoryTokens returns a copy of categories with their counts filled in.
+func countCategoryTokens(categories []ChangeCategory) []ChangeCategory {
+	out := make([]ChangeCategory, 0, len(categories))
+	for _, cat := range categories {
+		text := categoryText(cat)
+		cat.Chars, cat.Tokens = utf8.RuneCountInString(text), ApproxTokens(text)
+		changes := make([]SymbolChange, 0, len(cat.Changes))
+		for _, sc := range cat.Changes {
+			text := symbolText(sc)
+			sc.Chars, sc.Tokens = utf8.RuneCountInString(text), ApproxTokens(text)
+			changes = append(changes, sc)
+		}
+		if cat.Changes != nil {
+			cat.Changes = changes
+		}
+		out = append(out, cat)
+	}
+	return out
+}
+
+// Chunk is a single change record prepared for embedding or retrieval.
+type Chunk struct {
+	ID        string     `json:"id"` // e.g. "go1.22/3" or "go1.22/3/1" for a symbol change
+	Version   string     `json:"version"`
+	Category  string     `json:"category"`
+	Package   string     `json:"package,omitempty"`
+	Symbol    string     `json:"symbol,omitempty"`
+	Type      ChangeType `json:"type,omitempty"`
+	Text      string     `json:"text"`
+	Chars     int        `json:"chars"`
+	Tokens    int        `json:"tokens"`
+	Truncated bool       `json:"truncated,omitempty"`
+}
+
+// Chunks splits data into one chunk per category and per symbol change. If maxTokens is
+// positive, longer texts are cut at a word boundary to fit and marked Truncated.
+func Chunks(data []VersionData, maxTokens int) []Chunk {
+	var chunks []Chunk
+	for _, vd := range data {
+		for i, cat := range vd.Changes {
+			chunks = append(chunks, newChunk(Chunk{
+				ID:       fmt.Sprintf("%s/%d", vd.Version, i),
+				Version:  vd.Version,
+				Category: cat.Category,
+				Package:  cat.Package,
+				Type:     cat.Type,
+			}, categoryText(cat), maxTokens))
+			for j, sc := range cat.Changes {
+				chunks = append(chunks, newChunk(Chunk{
+					ID:       fmt.Sprintf("%s/%d/%d", vd.Version, i, j),
+					Version:  vd.Version,
+					Category: cat.Category,
+					Package:  cat.Package,
+					Symbol:   sc.Symbol,
+					Type:     NormalizeChangeType(sc.Type),
+				}, symbolText(sc), maxTokens))
+			}
+		}
+	}
+	return chunks
+}
+
+// newChunk sets the text of c, trimmed to maxTokens, and its counts.
+func newChunk(c Chunk, text string, maxTokens int) Chunk {
+	if maxTokens > 0 && ApproxTokens(text) > maxTokens {
+		text, c.Truncated = truncateWords(text, maxTokens*charsPerToken), true
+	}
+	c.Text = text
+	c.Chars, c.Tokens = utf8.RuneCountInString(text), ApproxTokens(text)
+	return c
+}
+
+// truncateWords shortens s to at most n runes, cutting at the last space if there is one.
+func truncateWords(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	cut := string(runes[:n])
+	if i := strings.LastIndexAny(cut, " \n"); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimSpace(cut)
+}
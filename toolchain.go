@@ -0,0 +1,80 @@
+package gover
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// defaultModuleProxy is the module proxy toolchains are listed from unless WithModuleProxy is given.
+	defaultModuleProxy = "https://proxy.golang.org"
+	// defaultSumDB is the checksum database consulted unless WithSumDB is given.
+	defaultSumDB = "https://sum.golang.org"
+)
+
+// toolchainListPath lists every toolchain published to the module proxy, one
+// "v0.0.1-go1.N.P.GOOS-GOARCH" version per line. Toolchains are published from go1.21.0 on.
+const toolchainListPath = "/golang.org/toolchain/@v/list"
+
+// ToolchainVersions returns the Go releases published as downloadable toolchains on the
+// module proxy, oldest first, including pre-releases.
+func (c *Client) ToolchainVersions(ctx context.Context) ([]string, error) {
+	body, err := c.get(ctx, c.opts.moduleProxy+toolchainListPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch toolchain list: %w", err)
+	}
+	seen := make(map[string]bool)
+	var versions []string
+	for _, line := range strings.Split(string(body), "\n") {
+		v, ok := toolchainVersion(line)
+		if ok && !seen[v] {
+			seen[v] = true
+			versions = append(versions, v)
+		}
+	}
+	slices.SortFunc(versions, CompareVersions)
+	return versions, nil
+}
+
+// FetchToolchainVersions is a shorthand for NewClient().ToolchainVersions.
+func FetchToolchainVersions() ([]string, error) {
+	return NewClient().ToolchainVersions(context.Background())
+}
+
+// toolchainVersion extracts "go1.21.3" from a toolchain module version such as "v0.0.1-go1.21.3.linux-amd64".
+func toolchainVersion(modVersion string) (string, bool) {
+	_, v, ok := strings.Cut(strings.TrimSpace(modVersion), "-go")
+	if !ok {
+		return "", false
+	}
+	// Drop the ".GOOS-GOARCH" suffix.
+	if i := strings.LastIndex(v, "."); i >= 0 && strings.Contains(v[i:], "-") {
+		v = v[:i]
+	}
+	if _, ok := parseGoVersion(v); !ok {
+		return "", false
+	}
+	return "go" + v, true
+}
+
+// LatestPatches returns the newest stable release of each minor version in versions, oldest first.
+func LatestPatches(versions []string) []string {
+	latest := make(map[int]string)
+	for _, v := range versions {
+		gv, ok := parseGoVersion(v)
+		if !ok || gv.pre != "" {
+			continue
+		}
+		if cur, ok := latest[gv.minor]; !ok || CompareVersions(v, cur) > 0 {
+			latest[gv.minor] = v
+		}
+	}
+	out := make([]string, 0, len(latest))
+	for _, v := range latest {
+		out = append(out, v)
+	}
+	slices.SortFunc(out, CompareVersions)
+	return out
+}
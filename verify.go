@@ -0,0 +1,173 @@
+package gover
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Checks made by VerifyDataset.
+const (
+	CheckSchema   = "schema"   // the file decodes into a Dataset, with no unknown fields or missing values
+	CheckChecksum = "checksum" // the versions match the recorded checksum
+	CheckSummary  = "summary"  // the summary matches the versions
+	CheckOrder    = "order"    // the versions are distinct and newest first
+	CheckDates    = "dates"    // release dates are valid, in the past and follow the order of the versions
+	CheckGaps     = "gaps"     // no minor version between the oldest and the newest is missing
+)
+
+// DatasetProblem is a problem VerifyDataset found in a dataset.
+type DatasetProblem struct {
+	Level   string `json:"level"` // LevelWarning or LevelError
+	Check   string `json:"check"` // one of the Check constants
+	Version string `json:"version,omitempty"`
+	Message string `json:"message"`
+}
+
+// DatasetProblems are the problems VerifyDataset found, errors first.
+type DatasetProblems []DatasetProblem
+
+// Errors returns the number of problems of level LevelError.
+func (p DatasetProblems) Errors() int {
+	n := 0
+	for _, pr := range p {
+		if pr.Level == LevelError {
+			n++
+		}
+	}
+	return n
+}
+
+// Table implements Tabular.
+func (p DatasetProblems) Table() [][]string {
+	table := [][]string{{"Level", "Check", "Version", "Message"}}
+	for _, pr := range p {
+		table = append(table, []string{pr.Level, pr.Check, pr.Version, pr.Message})
+	}
+	return table
+}
+
+// VerifyDataset checks a dataset file's contents before it is published: that it decodes
+// into a Dataset without fields this package does not know of, that its versions match
+// its checksum and summary, that they are distinct and newest first, that their release
+// dates are valid, not after now and in the same order, and that no minor version is
+// missing between the oldest and the newest. Files without a checksum, written before
+// checksums were recorded, and undated versions only get warnings. It returns an error
+// only if data is not a dataset at all.
+func VerifyDataset(data []byte, now time.Time) (DatasetProblems, error) {
+	ds, err := decodeDataset(data)
+	if err != nil {
+		return nil, parseError(fmt.Errorf("failed to decode dataset: %w", err))
+	}
+	var problems DatasetProblems
+	report := func(level, check, version, format string, args ...any) {
+		problems = append(problems, DatasetProblem{level, check, version, fmt.Sprintf(format, args...)})
+	}
+
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		dec := json.NewDecoder(bytes.NewReader(trimmed))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(new(Dataset)); err != nil {
+			report(LevelWarning, CheckSchema, "", "%v", err)
+		}
+		switch sum := ds.ContentChecksum(); {
+		case ds.Checksum == "":
+			report(LevelWarning, CheckChecksum, "", "no checksum recorded")
+		case ds.Checksum != sum:
+			report(LevelError, CheckChecksum, "", "checksum %s recorded, but the versions have %s", ds.Checksum, sum)
+		}
+		want := summarize(ds.Versions)
+		if ds.Summary.Versions != want.Versions || ds.Summary.Complete != want.Complete ||
+			!slices.Equal(ds.Summary.Partial, want.Partial) || !slices.Equal(ds.Summary.Missing, want.Missing) {
+			report(LevelError, CheckSummary, "", "summary lists %d versions, %d complete, but there are %d, %d complete", ds.Summary.Versions, ds.Summary.Complete, want.Versions, want.Complete)
+		}
+	} else {
+		report(LevelWarning, CheckSchema, "", "a bare list of versions, without summary or checksum")
+	}
+
+	var (
+		seen     = make(map[string]bool)
+		minors   = make(map[int]string) // minor number to release name
+		prev     VersionData
+		prevDate time.Time
+	)
+	for i, vd := range ds.Versions {
+		v, ok := parseGoVersion(vd.Version)
+		if !ok {
+			report(LevelError, CheckSchema, vd.Version, "invalid version %q", vd.Version)
+			continue
+		}
+		if v.pre == "" {
+			minors[v.minor] = minorVersion(vd.Version)
+		}
+		for j, cat := range vd.Changes {
+			if cat.Category == "" {
+				report(LevelError, CheckSchema, vd.Version, "category %d has no name", j+1)
+			}
+			for _, sc := range cat.Changes {
+				if sc.Symbol == "" {
+					report(LevelError, CheckSchema, vd.Version, "category %q has a symbol change without a symbol", cat.Category)
+				}
+			}
+		}
+
+		switch {
+		case seen[vd.Version]:
+			report(LevelError, CheckOrder, vd.Version, "listed more than once")
+		case i > 0 && CompareVersions(prev.Version, vd.Version) < 0:
+			report(LevelError, CheckOrder, vd.Version, "listed after the older %s", prev.Version)
+		}
+		seen[vd.Version] = true
+
+		if vd.ReleaseDate == "" {
+			report(LevelWarning, CheckDates, vd.Version, "no release date")
+		} else if date, err := time.Parse(DateLayout, vd.ReleaseDate); err != nil {
+			report(LevelError, CheckDates, vd.Version, "invalid release date %q", vd.ReleaseDate)
+		} else {
+			if date.After(now) {
+				report(LevelError, CheckDates, vd.Version, "released %s, in the future", vd.ReleaseDate)
+			}
+			if !prevDate.IsZero() && date.After(prevDate) {
+				report(LevelError, CheckDates, vd.Version, "released %s, after the newer %s (%s)", vd.ReleaseDate, prev.Version, prev.ReleaseDate)
+			}
+			prevDate = date
+		}
+		prev = vd
+	}
+
+	if len(minors) > 0 {
+		lo, hi := minMaxKey(minors)
+		for m := lo + 1; m < hi; m++ {
+			if _, ok := minors[m]; !ok {
+				report(LevelError, CheckGaps, "", "go1.%d is missing between %s and %s", m, minors[lo], minors[hi])
+			}
+		}
+	}
+	slices.SortStableFunc(problems, func(a, b DatasetProblem) int {
+		if a.Level == b.Level {
+			return 0
+		}
+		if a.Level == LevelError {
+			return -1
+		}
+		return 1
+	})
+	return problems, nil
+}
+
+// minMaxKey returns the smallest and largest keys of m, which must not be empty.
+func minMaxKey(m map[int]string) (lo, hi int) {
+	first := true
+	for k := range m {
+		if first || k < lo {
+			lo = k
+		}
+		if first || k > hi {
+			hi = k
+		}
+		first = false
+	}
+	return lo, hi
+}
@@ -0,0 +1,143 @@
+package gover
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// verifyNow is the time the datasets of the VerifyDataset tests are verified at.
+var verifyNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+// verifyVersion returns a version released on date, with a change.
+func verifyVersion(version, date string) VersionData {
+	return VersionData{Version: version, ReleaseDate: date, Changes: []ChangeCategory{
+		{Category: "net/http", Changes: []SymbolChange{{Symbol: "net/http.Request.PathValue", Type: ChangeAdded}}},
+	}}
+}
+
+// verifyVersions returns go1.22, go1.21 and go1.20, newest first.
+func verifyVersions() []VersionData {
+	return []VersionData{
+		verifyVersion("go1.22", "2024-02-06"),
+		verifyVersion("go1.21", "2023-08-08"),
+		verifyVersion("go1.20", "2023-02-01"),
+	}
+}
+
+// encodeVerifyDataset returns the JSON of ds after edit, if not nil, has changed it.
+func encodeVerifyDataset(t *testing.T, versions []VersionData, edit func(*Dataset)) []byte {
+	t.Helper()
+	ds := NewDataset(versions)
+	if edit != nil {
+		edit(ds)
+	}
+	b, err := json.Marshal(ds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestVerifyDataset(t *testing.T) {
+	tests := []struct {
+		name string
+		data func(t *testing.T) []byte
+		want []string // "level check version" of each problem
+	}{
+		{"valid", func(t *testing.T) []byte {
+			return encodeVerifyDataset(t, verifyVersions(), nil)
+		}, nil},
+		{"checksum mismatch", func(t *testing.T) []byte {
+			return encodeVerifyDataset(t, verifyVersions(), func(ds *Dataset) { ds.Versions[0].URL = "https://go.dev/doc/go1.22" })
+		}, []string{"error checksum "}},
+		{"no checksum", func(t *testing.T) []byte {
+			return encodeVerifyDataset(t, verifyVersions(), func(ds *Dataset) { ds.Checksum = "" })
+		}, []string{"warning checksum "}},
+		{"stale summary", func(t *testing.T) []byte {
+			return encodeVerifyDataset(t, verifyVersions(), func(ds *Dataset) { ds.Summary.Complete = 2 })
+		}, []string{"error summary "}},
+		{"unknown field", func(t *testing.T) []byte {
+			b := encodeVerifyDataset(t, verifyVersions(), nil)
+			return []byte(strings.Replace(string(b), `{"generatedAt"`, `{"extra":1,"generatedAt"`, 1))
+		}, []string{"warning schema "}},
+		{"bare list", func(t *testing.T) []byte {
+			b, _ := json.Marshal(verifyVersions())
+			return b
+		}, []string{"warning schema "}},
+		{"invalid version and unnamed category", func(t *testing.T) []byte {
+			vs := verifyVersions()
+			vs[1].Changes[0].Category = ""
+			vs[1].Changes[0].Changes[0].Symbol = ""
+			return encodeVerifyDataset(t, append(vs, verifyVersion("go2", "2023-01-01")), nil)
+		}, []string{"error schema go1.21", "error schema go1.21", "error schema go2"}},
+		{"out of order", func(t *testing.T) []byte {
+			vs := verifyVersions()
+			vs[0], vs[1] = vs[1], vs[0]
+			return encodeVerifyDataset(t, vs, nil)
+		}, []string{"error order go1.22", "error dates go1.22"}},
+		{"listed twice", func(t *testing.T) []byte {
+			vs := verifyVersions()
+			return encodeVerifyDataset(t, append(vs[:2:2], vs[1], vs[2]), nil)
+		}, []string{"error order go1.21"}},
+		{"bad dates", func(t *testing.T) []byte {
+			vs := verifyVersions()
+			vs[0].ReleaseDate = "2024-07-01"
+			vs[1].ReleaseDate = "08/08/2023"
+			vs[2].ReleaseDate = ""
+			return encodeVerifyDataset(t, vs, nil)
+		}, []string{"error dates go1.22", "error dates go1.21", "warning dates go1.20"}},
+		{"released after a newer version", func(t *testing.T) []byte {
+			vs := verifyVersions()
+			vs[2].ReleaseDate = "2023-09-01"
+			return encodeVerifyDataset(t, vs, nil)
+		}, []string{"error dates go1.20"}},
+		{"gap", func(t *testing.T) []byte {
+			vs := verifyVersions()
+			return encodeVerifyDataset(t, []VersionData{vs[0], vs[2], verifyVersion("go1.22rc1", "2023-12-19")}, nil)
+		}, []string{"error order go1.22rc1", "error dates go1.22rc1", "error gaps "}},
+	}
+	for _, tt := range tests {
+		problems, err := VerifyDataset(tt.data(t), verifyNow)
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		var got []string
+		for _, p := range problems {
+			got = append(got, p.Level+" "+p.Check+" "+p.Version)
+		}
+		if !slices.Equal(sorted(got), sorted(tt.want)) {
+			t.Errorf("%s: problems %q, want %q (%v)", tt.name, got, tt.want, problems)
+		}
+		if i := slices.IndexFunc(problems, func(p DatasetProblem) bool { return p.Level != LevelError }); i >= 0 && problems[i:].Errors() > 0 {
+			t.Errorf("%s: errors not listed first: %v", tt.name, problems)
+		}
+	}
+}
+
+func TestVerifyDatasetGapMessage(t *testing.T) {
+	vs := []VersionData{verifyVersion("go1.2", "2013-12-01"), verifyVersion("go1", "2012-03-28")}
+	problems, err := VerifyDataset(encodeVerifyDataset(t, vs, nil), verifyNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || problems[0].Message != "go1.1 is missing between go1 and go1.2" {
+		t.Errorf("problems %v, want go1.1 missing between go1 and go1.2", problems)
+	}
+}
+
+func TestVerifyDatasetNotADataset(t *testing.T) {
+	for _, data := range []string{"", "nope", `{"versions": 1}`} {
+		if _, err := VerifyDataset([]byte(data), verifyNow); err == nil {
+			t.Errorf("VerifyDataset(%q) succeeded, want an error", data)
+		}
+	}
+}
+
+// sorted returns a sorted copy of s.
+func sorted(s []string) []string {
+	return slices.Sorted(slices.Values(s))
+}
@@ -0,0 +1,176 @@
+package gover
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// goVersion is a parsed Go release name such as "go1.21.3", "go1.22rc1" or "go1.20".
+type goVersion struct {
+	minor int
+	patch int
+	pre   string // "rc1", "beta2", or empty for a stable release
+}
+
+// parseGoVersion parses a Go release name. The "go" prefix is optional.
+func parseGoVersion(v string) (goVersion, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "go")
+	rest, ok := strings.CutPrefix(v, "1")
+	if !ok {
+		return goVersion{}, false
+	}
+	if rest == "" {
+		return goVersion{}, true // go1
+	}
+	rest, ok = strings.CutPrefix(rest, ".")
+	if !ok {
+		return goVersion{}, false
+	}
+
+	var gv goVersion
+	if i := strings.IndexAny(rest, "abcdefghijklmnopqrstuvwxyz"); i >= 0 {
+		rest, gv.pre = rest[:i], rest[i:]
+	}
+	minor, patch, hasPatch := strings.Cut(rest, ".")
+	n, err := strconv.Atoi(minor)
+	if err != nil {
+		return goVersion{}, false
+	}
+	gv.minor = n
+	if hasPatch {
+		if gv.patch, err = strconv.Atoi(patch); err != nil {
+			return goVersion{}, false
+		}
+	}
+	return gv, true
+}
+
+// minorVersion returns the minor release of a Go release name, such as "go1.21" for
+// "go1.21.3" and "go1" for "go1.0.2", or "" if it is not a stable release.
+func minorVersion(v string) string {
+	gv, ok := parseGoVersion(v)
+	if !ok || gv.pre != "" {
+		return ""
+	}
+	if gv.minor == 0 {
+		return "go1"
+	}
+	return fmt.Sprintf("go1.%d", gv.minor)
+}
+
+// CompareVersions compares two Go release names, returning -1, 0 or +1.
+// Pre-releases sort before the corresponding stable release ("go1.22rc1" < "go1.22.0").
+// Unparsable versions sort first.
+func CompareVersions(a, b string) int {
+	va, oka := parseGoVersion(a)
+	vb, okb := parseGoVersion(b)
+	switch {
+	case !oka || !okb:
+		return cmp.Compare(boolInt(oka), boolInt(okb))
+	case va.minor != vb.minor:
+		return cmp.Compare(va.minor, vb.minor)
+	case va.patch != vb.patch:
+		return cmp.Compare(va.patch, vb.patch)
+	case va.pre == vb.pre:
+		return 0
+	case va.pre == "":
+		return 1
+	case vb.pre == "":
+		return -1
+	}
+	return strings.Compare(va.pre, vb.pre)
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// Constraint is a set of conditions on Go minor versions, such as ">=1.21" or ">=1.20,<1.23".
+type Constraint []constraintClause
+
+type constraintClause struct {
+	op    string
+	minor int
+}
+
+// ParseConstraint parses a comma- or space-separated list of clauses, each an optional
+// operator (=, >, >=, <, <=) followed by a version ("1.21" or "go1.21"). A version
+// matches the constraint if it satisfies every clause. Patch levels are ignored.
+func ParseConstraint(s string) (Constraint, error) {
+	var c Constraint
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		op := strings.TrimRight(field, "1234567890.go")
+		if !strings.HasPrefix(field, op) {
+			return nil, fmt.Errorf("invalid constraint %q", field)
+		}
+		switch op {
+		case "":
+			op = "="
+		case "=", "==", ">", ">=", "<", "<=":
+		default:
+			return nil, fmt.Errorf("invalid operator %q in constraint %q", op, field)
+		}
+		gv, ok := parseGoVersion(strings.TrimPrefix(field, op))
+		if !ok || strings.TrimPrefix(field, op) == "" {
+			return nil, fmt.Errorf("invalid version in constraint %q", field)
+		}
+		c = append(c, constraintClause{op: strings.TrimPrefix(op, "="), minor: gv.minor})
+	}
+	if len(c) == 0 {
+		return nil, fmt.Errorf("empty version constraint")
+	}
+	return c, nil
+}
+
+// Match reports whether version satisfies every clause of the constraint.
+func (c Constraint) Match(version string) bool {
+	gv, ok := parseGoVersion(version)
+	if !ok {
+		return false
+	}
+	for _, cl := range c {
+		var ok bool
+		switch cl.op {
+		case "", "=":
+			ok = gv.minor == cl.minor
+		case ">":
+			ok = gv.minor > cl.minor
+		case ">=":
+			ok = gv.minor >= cl.minor
+		case "<":
+			ok = gv.minor < cl.minor
+		case "<=":
+			ok = gv.minor <= cl.minor
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// VersionFromURL returns the release, such as "go1.22", whose release notes rawURL
+// addresses, going by the last segment of its path, so that the URL a redirect ended at
+// can be checked: "https://go.dev/doc/go1.22", "/doc/go1.22/", "/doc/go1.22#language"
+// and "/doc/go1.22.html" all give "go1.22". It reports false for other URLs.
+func VersionFromURL(rawURL string) (string, bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false
+	}
+	segments := strings.FieldsFunc(u.Path, func(r rune) bool { return r == '/' })
+	if len(segments) == 0 {
+		return "", false
+	}
+	last := strings.TrimSuffix(strings.ToLower(segments[len(segments)-1]), ".html")
+	if _, ok := parseGoVersion(last); !ok || !strings.HasPrefix(last, "go") {
+		return "", false
+	}
+	return NormalizeVersion(last), true
+}
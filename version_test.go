@@ -0,0 +1,83 @@
+package gover
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConstraint(t *testing.T) {
+	versions := []string{"go1", "go1.19", "go1.20.3", "go1.21", "go1.21rc2", "go1.22.1", "go1.23"}
+	tests := []struct {
+		constraint string
+		want       []string // the versions matching
+	}{
+		{"1.21", []string{"go1.21", "go1.21rc2"}},
+		{"go1.21", []string{"go1.21", "go1.21rc2"}},
+		{"=1.21.4", []string{"go1.21", "go1.21rc2"}},
+		{"==go1.21", []string{"go1.21", "go1.21rc2"}},
+		{">1.21", []string{"go1.22.1", "go1.23"}},
+		{">=1.21", []string{"go1.21", "go1.21rc2", "go1.22.1", "go1.23"}},
+		{"<1.20", []string{"go1", "go1.19"}},
+		{"<=go1.20", []string{"go1", "go1.19", "go1.20.3"}},
+		{">=1.20,<1.23", []string{"go1.20.3", "go1.21", "go1.21rc2", "go1.22.1"}},
+		{">=1.20 <1.22", []string{"go1.20.3", "go1.21", "go1.21rc2"}},
+		{" >=1.22 , <=1.22 ", []string{"go1.22.1"}},
+		{">1.22,<1.22", nil},
+	}
+	for _, tt := range tests {
+		c, err := ParseConstraint(tt.constraint)
+		if err != nil {
+			t.Errorf("ParseConstraint(%q): %v", tt.constraint, err)
+			continue
+		}
+		var got []string
+		for _, v := range versions {
+			if c.Match(v) {
+				got = append(got, v)
+			}
+		}
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("ParseConstraint(%q) matches %q, want %q", tt.constraint, got, tt.want)
+		}
+	}
+}
+
+func TestConstraintMatchInvalidVersion(t *testing.T) {
+	c, err := ParseConstraint(">=1.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"", "devel", "go2.0", "1.x"} {
+		if c.Match(v) {
+			t.Errorf("%q matches >=1.0, want no match", v)
+		}
+	}
+}
+
+func TestParseConstraintErrors(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string // in the error
+	}{
+		{"", "empty version constraint"},
+		{" , ", "empty version constraint"},
+		{"~1.21", `invalid operator "~"`},
+		{"!=1.21", `invalid operator "!="`},
+		{"=>1.21", `invalid operator "=>"`},
+		{"1.21x", "invalid operator"},
+		{">=", `invalid version in constraint ">="`},
+		{">=go", `invalid version in constraint ">=go"`},
+		{">=2.1", `invalid version in constraint ">=2.1"`},
+		{">=1.20,<", `invalid version in constraint "<"`},
+	}
+	for _, tt := range tests {
+		_, err := ParseConstraint(tt.constraint)
+		if err == nil {
+			t.Errorf("ParseConstraint(%q) succeeded, want an error", tt.constraint)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseConstraint(%q) = %v, want %s", tt.constraint, err, tt.want)
+		}
+	}
+}
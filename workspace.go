@@ -0,0 +1,171 @@
+package gover
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Workspace is a go.work file: modules developed together, as in a monorepo.
+type Workspace struct {
+	Dir     string            `json:"dir"` // of the go.work file
+	Go      string            `json:"go,omitempty"`
+	Modules []WorkspaceModule `json:"modules"` // in the order of its use directives
+}
+
+// WorkspaceModule is a module of a Workspace.
+type WorkspaceModule struct {
+	Path string `json:"path"` // the module path declared by its go.mod
+	Dir  string `json:"dir"`
+	Go   string `json:"go,omitempty"` // the go directive of its go.mod
+}
+
+// LoadWorkspace reads the go.work file at path and the go.mod files of the modules it uses.
+func LoadWorkspace(path string) (*Workspace, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workspace: %w", err)
+	}
+	w := &Workspace{Dir: filepath.Dir(path)}
+	var dirs []string
+	inUse := false
+	for _, line := range modFileLines(b) {
+		verb, arg, _ := strings.Cut(line, " ")
+		arg = strings.TrimSpace(arg)
+		switch {
+		case inUse && line == ")":
+			inUse = false
+		case inUse:
+			dirs = append(dirs, unquoteModPath(line))
+		case verb == "go":
+			w.Go = arg
+		case verb == "use" && arg == "(":
+			inUse = true
+		case verb == "use":
+			dirs = append(dirs, unquoteModPath(arg))
+		}
+	}
+	for _, dir := range dirs {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(w.Dir, dir)
+		}
+		m, err := loadWorkspaceModule(dir)
+		if err != nil {
+			return nil, parseError(fmt.Errorf("%s: %w", path, err))
+		}
+		w.Modules = append(w.Modules, m)
+	}
+	return w, nil
+}
+
+// loadWorkspaceModule reads the module path and go directive of the go.mod file in dir.
+func loadWorkspaceModule(dir string) (WorkspaceModule, error) {
+	b, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return WorkspaceModule{}, err
+	}
+	m := WorkspaceModule{Dir: dir}
+	for _, line := range modFileLines(b) {
+		verb, arg, _ := strings.Cut(line, " ")
+		switch verb {
+		case "module":
+			m.Path = unquoteModPath(strings.TrimSpace(arg))
+		case "go":
+			m.Go = strings.TrimSpace(arg)
+		}
+	}
+	if m.Path == "" {
+		return WorkspaceModule{}, fmt.Errorf("no module directive in %s", filepath.Join(dir, "go.mod"))
+	}
+	return m, nil
+}
+
+// modFileLines returns the lines of a go.mod or go.work file without comments and
+// surrounding space, skipping blank lines.
+func modFileLines(b []byte) []string {
+	var lines []string
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for sc.Scan() {
+		line, _, _ := strings.Cut(sc.Text(), "//")
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// unquoteModPath unquotes a path of a go.mod or go.work file if it is quoted.
+func unquoteModPath(s string) string {
+	if u, err := strconv.Unquote(s); err == nil {
+		return u
+	}
+	return s
+}
+
+// ScanSymbolUses scans each module of w for symbol uses, as ScanSymbolUses does, by
+// module path. The directories of other modules of w nested in a module's are left to
+// those modules.
+func (w *Workspace) ScanSymbolUses() (map[string][]SymbolUse, error) {
+	dirs := make([]string, len(w.Modules))
+	for i, m := range w.Modules {
+		dirs[i] = filepath.Clean(m.Dir)
+	}
+	uses := make(map[string][]SymbolUse)
+	for _, m := range w.Modules {
+		root := filepath.Clean(m.Dir)
+		found, err := scanSymbolUses(root, func(dir string) bool {
+			return dir != root && slices.Contains(dirs, filepath.Clean(dir))
+		})
+		if err != nil {
+			return nil, err
+		}
+		uses[m.Path] = append(uses[m.Path], found...)
+	}
+	return uses, nil
+}
+
+// ModuleFindings counts the findings in a module of a workspace.
+type ModuleFindings struct {
+	Module   string `json:"module"`
+	Dir      string `json:"dir"`
+	Errors   int    `json:"errors"`
+	Warnings int    `json:"warnings"`
+}
+
+// ModuleBreakdown counts findings module by module, see BreakDown.
+type ModuleBreakdown []ModuleFindings
+
+// BreakDown counts findings by the module they are in, listing every module of modules,
+// in order, even those without findings.
+func BreakDown(modules []WorkspaceModule, findings []Finding) ModuleBreakdown {
+	b := make(ModuleBreakdown, len(modules))
+	for i, m := range modules {
+		b[i] = ModuleFindings{Module: m.Path, Dir: m.Dir}
+	}
+	for _, f := range findings {
+		i := slices.IndexFunc(b, func(mf ModuleFindings) bool { return mf.Module == f.Module })
+		if i < 0 {
+			continue
+		}
+		if f.Level == LevelError {
+			b[i].Errors++
+		} else {
+			b[i].Warnings++
+		}
+	}
+	return b
+}
+
+// Table implements Tabular.
+func (b ModuleBreakdown) Table() [][]string {
+	table := [][]string{{"Module", "Dir", "Errors", "Warnings"}}
+	for _, mf := range b {
+		table = append(table, []string{mf.Module, mf.Dir, strconv.Itoa(mf.Errors), strconv.Itoa(mf.Warnings)})
+	}
+	return table
+}